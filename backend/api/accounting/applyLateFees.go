@@ -0,0 +1,27 @@
+package accounting
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// ApplyLateFees runs the late fee job immediately instead of waiting for the
+// next scheduler tick.
+func ApplyLateFees(c *gin.Context) {
+	run, err := billing.ApplyLateFees(db.DB.WithContext(c), time.Now().UTC())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error applying late fees", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Late fees applied",
+		"marked_overdue": len(run.MarkedOverdue),
+		"fees":           run.Fees,
+		"count":          len(run.Fees),
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/geoo115/property-manager/billing"
 	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/models"
 	"github.com/gin-gonic/gin"
@@ -19,6 +20,7 @@ func CreateExpense(c *gin.Context) {
 		Category    string  `json:"category" binding:"required"`
 		Amount      float64 `json:"amount" binding:"required,gt=0"`
 		ExpenseDate string  `json:"expense_date" binding:"required"`
+		VendorID    *uint   `json:"vendor_id"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -36,15 +38,31 @@ func CreateExpense(c *gin.Context) {
 		}
 	}
 
+	userID, _ := c.Get("user_id")
 	expense := models.Expense{
-		PropertyID:  input.PropertyID,
-		Description: input.Description,
-		Category:    input.Category,
-		Amount:      input.Amount,
-		ExpenseDate: expenseDate,
+		PropertyID:    input.PropertyID,
+		CreatedByID:   userID.(uint),
+		ExpenseNumber: billing.NewExpenseNumber(),
+		Description:   input.Description,
+		Category:      input.Category,
+		Amount:        input.Amount,
+		ExpenseDate:   expenseDate,
 	}
 
-	if err := db.DB.Create(&expense).Error; err != nil {
+	// Vendor details come from the directory rather than free text
+	if input.VendorID != nil {
+		var vendor models.Vendor
+		if err := db.DB.Where("deleted_at IS NULL").First(&vendor, *input.VendorID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Vendor not found"})
+			return
+		}
+		expense.VendorID = &vendor.ID
+		expense.VendorName = vendor.Name
+		expense.VendorEmail = vendor.Email
+		expense.VendorPhone = vendor.Phone
+	}
+
+	if err := db.DB.WithContext(c).Create(&expense).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating expense"})
 		return
 	}
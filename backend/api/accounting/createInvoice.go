@@ -6,21 +6,29 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/geoo115/property-manager/api/unit"
+	"github.com/geoo115/property-manager/billing"
 	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/models"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func CreateInvoice(c *gin.Context) {
 	var input struct {
 		TenantID       uint    `json:"tenant_id" binding:"required"`
 		PropertyID     uint    `json:"property_id" binding:"required"`
+		LeaseID        *uint   `json:"lease_id"`
+		UnitID         *uint   `json:"unit_id"`
 		Amount         float64 `json:"amount" binding:"required,gt=0"`
-		PaidAmount     float64 `json:"paid_amount" binding:"required,gte=0"`
+		PaidAmount     float64 `json:"paid_amount" binding:"gte=0"`
+		PaymentMethod  string  `json:"payment_method" binding:"omitempty,oneof=cash bank_transfer card cheque"`
 		InvoiceDateStr string  `json:"invoice_date" binding:"required"`
 		Category       string  `json:"category" binding:"required,oneof=rent deposit"`
 		DueDateStr     string  `json:"due_date" binding:"required"`
-		PaymentStatus  string  `json:"payment_status" binding:"required"`
+		PaymentStatus  string  `json:"payment_status" binding:"omitempty,oneof=pending paid overdue cancelled"`
+		Recurring      bool    `json:"recurring"`
+		Interval       string  `json:"recurring_interval" binding:"omitempty,oneof=monthly quarterly yearly"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -53,19 +61,83 @@ func CreateInvoice(c *gin.Context) {
 		return
 	}
 
+	if _, err := unit.ValidateForProperty(input.UnitID, property.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit", "details": err.Error()})
+		return
+	}
+
+	if input.LeaseID != nil {
+		var lease models.Lease
+		if err := db.DB.Where("id = ? AND tenant_id = ? AND property_id = ?", *input.LeaseID, tenant.ID, property.ID).
+			First(&lease).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid lease",
+				"details": fmt.Sprintf("lease %d does not belong to tenant %d on property %d", *input.LeaseID, tenant.ID, property.ID),
+			})
+			return
+		}
+	}
+
+	if input.Recurring && input.Interval == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recurring_interval is required for recurring invoices"})
+		return
+	}
+
+	if input.PaidAmount > input.Amount {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "paid_amount cannot exceed amount"})
+		return
+	}
+	if input.PaidAmount > 0 && input.PaymentMethod == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "payment_method is required when paid_amount is set"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdByID, _ := userID.(uint)
+
 	invoice := models.Invoice{
+		InvoiceNumber: billing.NewInvoiceNumber(),
+		CreatedByID:   createdByID,
 		TenantID:      input.TenantID,
 		PropertyID:    input.PropertyID,
+		LeaseID:       input.LeaseID,
+		UnitID:        input.UnitID,
 		Amount:        input.Amount,
-		PaidAmount:    input.PaidAmount,
 		InvoiceDate:   invoiceDate,
 		Category:      input.Category,
 		DueDate:       dueDate,
-		PaymentStatus: input.PaymentStatus,
+		PaymentStatus: "pending",
+		Recurring:     input.Recurring,
+	}
+	if input.Recurring {
+		invoice.RecurringInterval = input.Interval
+	}
+	if input.PaymentStatus == "cancelled" {
+		invoice.PaymentStatus = "cancelled"
 	}
 
-	// Create invoice
-	if err := db.DB.Create(&invoice).Error; err != nil {
+	// Create the invoice and record any amount already received in the ledger
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&invoice).Error; err != nil {
+			return err
+		}
+		if err := billing.PublishInvoice(tx, models.WebhookInvoiceCreated, invoice); err != nil {
+			return err
+		}
+		if input.PaidAmount > 0 {
+			_, err := billing.RecordInvoicePayment(tx, invoice.ID, billing.PaymentInput{
+				RecordedByID: createdByID,
+				Amount:       input.PaidAmount,
+				Method:       input.PaymentMethod,
+				PaymentDate:  invoiceDate,
+				Notes:        "Recorded when the invoice was created",
+			})
+			return err
+		}
+		_, err := billing.RecalculateInvoice(tx, invoice.ID)
+		return err
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating invoice", "details": err.Error()})
 		return
 	}
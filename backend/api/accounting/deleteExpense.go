@@ -25,7 +25,7 @@ func DeleteExpense(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Delete(&expense).Error; err != nil {
+	if err := db.DB.WithContext(c).Delete(&expense).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting expense"})
 		return
 	}
@@ -25,7 +25,7 @@ func DeleteInvoice(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Delete(&invoice).Error; err != nil {
+	if err := db.DB.WithContext(c).Delete(&invoice).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting invoice"})
 		return
 	}
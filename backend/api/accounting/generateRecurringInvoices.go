@@ -0,0 +1,26 @@
+package accounting
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// GenerateRecurringInvoices runs the recurring invoice engine immediately
+// instead of waiting for the next scheduler tick.
+func GenerateRecurringInvoices(c *gin.Context) {
+	invoices, err := billing.GenerateRecurringInvoices(db.DB.WithContext(c), time.Now().UTC())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating recurring invoices", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Recurring invoices generated",
+		"invoices": invoices,
+		"count":    len(invoices),
+	})
+}
@@ -0,0 +1,73 @@
+package accounting
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetLateFeePolicies lists every landlord's late fee policy
+func GetLateFeePolicies(c *gin.Context) {
+	var policies []models.LateFeePolicy
+	if err := db.DB.Where("deleted_at IS NULL").Order("landlord_id").Find(&policies).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching late fee policies"})
+		return
+	}
+
+	responses := make([]models.LateFeePolicyResponse, 0, len(policies))
+	for i := range policies {
+		responses = append(responses, policies[i].ToResponse())
+	}
+	c.JSON(http.StatusOK, gin.H{"policies": responses})
+}
+
+// GetLateFeePolicy returns the late fee policy of a landlord. Admins pass the
+// landlord in the URL; landlords always get their own policy.
+func GetLateFeePolicy(c *gin.Context) {
+	landlordID, ok := policyLandlordID(c)
+	if !ok {
+		return
+	}
+
+	var policy models.LateFeePolicy
+	if err := db.DB.Where("landlord_id = ?", landlordID).First(&policy).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No late fee policy configured"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching late fee policy"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"policy": policy.ToResponse()})
+}
+
+// policyLandlordID resolves whose late fee policy a request refers to
+func policyLandlordID(c *gin.Context) (uint, bool) {
+	role, _ := c.Get("user_role")
+	if role != "admin" {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return 0, false
+		}
+		return userID.(uint), true
+	}
+
+	landlordID, err := strconv.ParseUint(c.Param("landlordID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid landlord ID"})
+		return 0, false
+	}
+
+	var landlord models.User
+	if err := db.DB.Where("id = ? AND role = ?", landlordID, "landlord").First(&landlord).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Landlord not found"})
+		return 0, false
+	}
+	return landlord.ID, true
+}
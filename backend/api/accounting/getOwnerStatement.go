@@ -0,0 +1,50 @@
+package accounting
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetOwnerStatement returns a landlord's owner statement for a period as a
+// PDF, or as JSON with ?format=json. Landlords always get their own
+// statement; admins pass the landlord in the URL.
+func GetOwnerStatement(c *gin.Context) {
+	landlordID, ok := statementSubjectID(c, "landlord")
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseStatementPeriod(c)
+	if !ok {
+		return
+	}
+
+	var landlord models.User
+	if err := db.DB.Where("id = ? AND role = ?", landlordID, "landlord").First(&landlord).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Landlord not found"})
+		return
+	}
+
+	statement, err := billing.OwnerStatementFor(db.DB, landlord, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error building statement", "details": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.JSON(http.StatusOK, gin.H{"statement": statement})
+		return
+	}
+
+	pdf, err := billing.RenderOwnerStatementPDF(*statement)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error rendering statement", "details": err.Error()})
+		return
+	}
+	writeStatementPDF(c, fmt.Sprintf("owner-statement-%d-%s.pdf", landlord.ID, to.Format("2006-01-02")), pdf)
+}
@@ -0,0 +1,106 @@
+package accounting
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetPayments lists ledger entries, optionally filtered by tenant_id and type
+func GetPayments(c *gin.Context) {
+	query := db.DB.Preload("Tenant").Preload("RecordedBy").Preload("Allocations.Invoice").
+		Where("deleted_at IS NULL")
+	if tenantID := c.Query("tenant_id"); tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	if paymentType := c.Query("type"); paymentType != "" {
+		query = query.Where("type = ?", paymentType)
+	}
+
+	var payments []models.Payment
+	if err := query.Order("payment_date DESC, id DESC").Find(&payments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching payments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"payments": paymentResponses(payments)})
+}
+
+// GetPaymentByID returns a single payment receipt
+func GetPaymentByID(c *gin.Context) {
+	var payment models.Payment
+	if err := db.DB.Preload("Tenant").Preload("RecordedBy").Preload("Allocations.Invoice").
+		First(&payment, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching payment"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"payment": payment.ToResponse()})
+}
+
+// GetInvoicePayments lists the payments and refunds allocated to an invoice
+func GetInvoicePayments(c *gin.Context) {
+	var invoice models.Invoice
+	if err := db.DB.First(&invoice, c.Param("id")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invoice not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching invoice"})
+		}
+		return
+	}
+
+	var payments []models.Payment
+	if err := db.DB.Preload("Tenant").Preload("RecordedBy").Preload("Allocations.Invoice").
+		Where("deleted_at IS NULL AND id IN (?)",
+			db.DB.Model(&models.PaymentAllocation{}).Select("payment_id").Where("invoice_id = ?", invoice.ID)).
+		Order("payment_date, id").
+		Find(&payments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching payments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invoice_id":      invoice.ID,
+		"amount":          invoice.Amount,
+		"paid_amount":     invoice.PaidAmount,
+		"refunded_amount": invoice.RefundedAmount,
+		"payment_status":  invoice.PaymentStatus,
+		"payments":        paymentResponses(payments),
+	})
+}
+
+// GetPaymentsForTenant lists the authenticated tenant's own payments
+func GetPaymentsForTenant(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var payments []models.Payment
+	if err := db.DB.Preload("Tenant").Preload("RecordedBy").Preload("Allocations.Invoice").
+		Where("tenant_id = ? AND deleted_at IS NULL", userID).
+		Order("payment_date DESC, id DESC").
+		Find(&payments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching payments for tenant"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"payments": paymentResponses(payments)})
+}
+
+func paymentResponses(payments []models.Payment) []models.PaymentResponse {
+	responses := make([]models.PaymentResponse, 0, len(payments))
+	for i := range payments {
+		responses = append(responses, payments[i].ToResponse())
+	}
+	return responses
+}
@@ -0,0 +1,42 @@
+package accounting
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// GetRecurringPreview is a dry run of the recurring invoice engine: it lists
+// the invoices that would be generated as of the given date without creating them.
+func GetRecurringPreview(c *gin.Context) {
+	asOf := time.Now().UTC()
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		parsed, err := time.Parse("2006-01-02", asOfStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		asOf = parsed
+	}
+
+	candidates, err := billing.PlanRecurringInvoices(db.DB, asOf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error planning recurring invoices", "details": err.Error()})
+		return
+	}
+
+	var total float64
+	for _, candidate := range candidates {
+		total += candidate.Amount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"as_of":        asOf.Format("2006-01-02"),
+		"invoices":     candidates,
+		"count":        len(candidates),
+		"total_amount": total,
+	})
+}
@@ -0,0 +1,108 @@
+package accounting
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTenantStatement returns a tenant's rent statement for a period as a PDF,
+// or as JSON with ?format=json. Tenants always get their own statement;
+// admins pass the tenant in the URL.
+func GetTenantStatement(c *gin.Context) {
+	tenantID, ok := statementSubjectID(c, "tenant")
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseStatementPeriod(c)
+	if !ok {
+		return
+	}
+
+	var tenant models.User
+	if err := db.DB.Where("id = ? AND role = ?", tenantID, "tenant").First(&tenant).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		return
+	}
+
+	statement, err := billing.TenantStatementFor(db.DB, tenant, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error building statement", "details": err.Error()})
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.JSON(http.StatusOK, gin.H{"statement": statement})
+		return
+	}
+
+	pdf, err := billing.RenderTenantStatementPDF(*statement)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error rendering statement", "details": err.Error()})
+		return
+	}
+	writeStatementPDF(c, fmt.Sprintf("tenant-statement-%d-%s.pdf", tenant.ID, to.Format("2006-01-02")), pdf)
+}
+
+// statementSubjectID resolves whose statement is requested: admins name the
+// user in the URL, everyone else gets their own
+func statementSubjectID(c *gin.Context, role string) (uint, bool) {
+	userRole, _ := c.Get("user_role")
+	if userRole == "admin" {
+		var id uint
+		if _, err := fmt.Sscan(c.Param("id"), &id); err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + role + " ID"})
+			return 0, false
+		}
+		return id, true
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return 0, false
+	}
+	return userID.(uint), true
+}
+
+// parseStatementPeriod reads ?from= and ?to= (YYYY-MM-DD), defaulting to the
+// current month up to today
+func parseStatementPeriod(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format, use YYYY-MM-DD", "details": err.Error()})
+			return from, to, false
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format, use YYYY-MM-DD", "details": err.Error()})
+			return from, to, false
+		}
+		to = parsed
+	}
+
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return from, to, false
+	}
+	return from, to, true
+}
+
+func writeStatementPDF(c *gin.Context, filename string, pdf []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
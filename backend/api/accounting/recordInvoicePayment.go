@@ -0,0 +1,45 @@
+package accounting
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RecordInvoicePayment records a full or partial payment against one invoice
+func RecordInvoicePayment(c *gin.Context) {
+	invoiceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	var input models.PaymentCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment data", "details": err.Error()})
+		return
+	}
+
+	paymentInput, ok := parsePaymentInput(c, input)
+	if !ok {
+		return
+	}
+
+	var payment *models.Payment
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		payment, err = billing.RecordInvoicePayment(tx, uint(invoiceID), paymentInput)
+		return err
+	})
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": "Error recording payment", "details": err.Error()})
+		return
+	}
+
+	respondWithPayment(c, http.StatusCreated, "Payment recorded successfully", payment.ID)
+}
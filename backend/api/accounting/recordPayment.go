@@ -0,0 +1,121 @@
+package accounting
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RecordPayment records a tenant payment and allocates it across their
+// outstanding invoices, oldest due date first
+func RecordPayment(c *gin.Context) {
+	var input models.PaymentCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment data", "details": err.Error()})
+		return
+	}
+
+	if input.TenantID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tenant_id is required"})
+		return
+	}
+
+	paymentInput, ok := parsePaymentInput(c, input)
+	if !ok {
+		return
+	}
+
+	var tenant models.User
+	if err := db.DB.First(&tenant, input.TenantID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		return
+	}
+	paymentInput.TenantID = tenant.ID
+
+	var payment *models.Payment
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		payment, err = billing.RecordTenantPayment(tx, paymentInput)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recording payment", "details": err.Error()})
+		return
+	}
+
+	respondWithPayment(c, http.StatusCreated, "Payment recorded successfully", payment.ID)
+}
+
+// parsePaymentInput validates a payment request and converts it into the
+// billing input, writing the error response itself when it fails
+func parsePaymentInput(c *gin.Context, input models.PaymentCreateRequest) (billing.PaymentInput, bool) {
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment data", "details": err.Error()})
+		return billing.PaymentInput{}, false
+	}
+
+	paymentDate := time.Now().UTC()
+	if input.PaymentDate != "" {
+		parsed, err := time.Parse("2006-01-02", input.PaymentDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment date format, use YYYY-MM-DD", "details": err.Error()})
+			return billing.PaymentInput{}, false
+		}
+		paymentDate = parsed
+	}
+
+	userID, _ := c.Get("user_id")
+	recordedByID, _ := userID.(uint)
+
+	return billing.PaymentInput{
+		TenantID:     input.TenantID,
+		RecordedByID: recordedByID,
+		Amount:       input.Amount,
+		Method:       input.Method,
+		PaymentDate:  paymentDate,
+		Reference:    input.Reference,
+		Notes:        input.Notes,
+	}, true
+}
+
+// respondWithPayment reloads a payment with its allocations, invalidates the
+// caches of the invoices it touched and writes the receipt
+func respondWithPayment(c *gin.Context, status int, message string, paymentID uint) {
+	var payment models.Payment
+	if err := db.DB.Preload("Tenant").Preload("RecordedBy").Preload("Allocations.Invoice").
+		First(&payment, paymentID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching payment", "details": err.Error()})
+		return
+	}
+
+	invoices := make([]models.Invoice, 0, len(payment.Allocations))
+	for _, allocation := range payment.Allocations {
+		invoices = append(invoices, allocation.Invoice)
+	}
+	billing.InvalidateInvoiceCaches(db.DB, invoices...)
+
+	c.JSON(status, gin.H{
+		"message": message,
+		"payment": payment.ToResponse(),
+	})
+}
+
+// paymentErrorStatus maps ledger errors onto HTTP status codes
+func paymentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, billing.ErrOverpayment), errors.Is(err, billing.ErrOverRefund):
+		return http.StatusBadRequest
+	case errors.Is(err, billing.ErrInvoiceCancelled):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
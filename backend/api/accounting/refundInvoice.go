@@ -0,0 +1,61 @@
+package accounting
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RefundInvoice refunds part or all of the money paid against an invoice
+func RefundInvoice(c *gin.Context) {
+	invoiceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice ID"})
+		return
+	}
+
+	var input struct {
+		models.PaymentCreateRequest
+		RefundOfID *uint `json:"refund_of_id"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid refund data", "details": err.Error()})
+		return
+	}
+
+	refundInput, ok := parsePaymentInput(c, input.PaymentCreateRequest)
+	if !ok {
+		return
+	}
+
+	// A refund may point at the original payment it reverses
+	if input.RefundOfID != nil {
+		var count int64
+		db.DB.Model(&models.PaymentAllocation{}).
+			Joins("JOIN payments ON payments.id = payment_allocations.payment_id").
+			Where("payments.id = ? AND payments.type = ? AND payment_allocations.invoice_id = ?", *input.RefundOfID, "payment", invoiceID).
+			Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "refund_of_id must be a payment allocated to this invoice"})
+			return
+		}
+	}
+
+	var refund *models.Payment
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		refund, err = billing.RefundInvoice(tx, uint(invoiceID), refundInput, input.RefundOfID)
+		return err
+	})
+	if err != nil {
+		c.JSON(paymentErrorStatus(err), gin.H{"error": "Error recording refund", "details": err.Error()})
+		return
+	}
+
+	respondWithPayment(c, http.StatusCreated, "Refund recorded successfully", refund.ID)
+}
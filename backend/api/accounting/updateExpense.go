@@ -53,7 +53,7 @@ func UpdateExpense(c *gin.Context) {
 	expense.Amount = input.Amount
 	expense.ExpenseDate = expenseDate
 
-	if err := db.DB.Save(&expense).Error; err != nil {
+	if err := db.DB.WithContext(c).Save(&expense).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating expense"})
 		return
 	}
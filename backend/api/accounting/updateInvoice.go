@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/geoo115/property-manager/api/unit"
+	"github.com/geoo115/property-manager/billing"
 	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/models"
 	"github.com/gin-gonic/gin"
@@ -28,13 +30,12 @@ func UpdateInvoice(c *gin.Context) {
 	var input struct {
 		TenantID          uint    `json:"tenant_id" binding:"required"`
 		PropertyID        uint    `json:"property_id" binding:"required"`
+		UnitID            *uint   `json:"unit_id"`
 		Amount            float64 `json:"amount" binding:"required,gt=0"`
-		PaidAmount        float64 `json:"paid_amount" binding:"required,gte=0"`
 		InvoiceDateStr    string  `json:"invoice_date" binding:"required"`
 		Category          string  `json:"category" binding:"required,oneof=rent deposit"`
 		DueDateStr        string  `json:"due_date" binding:"required"`
-		PaymentStatus     string  `json:"payment_status" binding:"required"`
-		RefundedAmount    float64 `json:"refunded_amount" binding:"gte=0"` // Removed 'required'
+		PaymentStatus     string  `json:"payment_status" binding:"omitempty,oneof=pending paid overdue cancelled"`
 		RecurringInterval string  `json:"recurring_interval"`
 		Recurring         bool    `json:"recurring"`
 	}
@@ -56,19 +57,37 @@ func UpdateInvoice(c *gin.Context) {
 		return
 	}
 
+	if _, err := unit.ValidateForProperty(input.UnitID, input.PropertyID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit", "details": err.Error()})
+		return
+	}
+
+	invoice.UnitID = input.UnitID
 	invoice.TenantID = input.TenantID
 	invoice.PropertyID = input.PropertyID
 	invoice.Amount = input.Amount
-	invoice.PaidAmount = input.PaidAmount
 	invoice.InvoiceDate = invoiceDate
 	invoice.Category = input.Category
 	invoice.DueDate = dueDate
-	invoice.PaymentStatus = input.PaymentStatus
-	invoice.RefundedAmount = input.RefundedAmount
 	invoice.RecurringInterval = input.RecurringInterval
 	invoice.Recurring = input.Recurring
 
-	if err := db.DB.Save(&invoice).Error; err != nil {
+	// Paid and refunded amounts come from the payments ledger; only a
+	// cancellation (or reinstating a cancelled invoice) is taken as given
+	if input.PaymentStatus == "cancelled" {
+		invoice.PaymentStatus = "cancelled"
+	} else if invoice.PaymentStatus == "cancelled" && input.PaymentStatus != "" {
+		invoice.PaymentStatus = "pending"
+	}
+
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&invoice).Error; err != nil {
+			return err
+		}
+		_, err := billing.RecalculateInvoice(tx, invoice.ID)
+		return err
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating invoice"})
 		return
 	}
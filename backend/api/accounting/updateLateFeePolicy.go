@@ -0,0 +1,58 @@
+package accounting
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UpdateLateFeePolicy creates or replaces a landlord's late fee policy
+func UpdateLateFeePolicy(c *gin.Context) {
+	landlordID, ok := policyLandlordID(c)
+	if !ok {
+		return
+	}
+
+	var input models.LateFeePolicyRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid late fee policy", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid late fee policy", "details": err.Error()})
+		return
+	}
+
+	var policy models.LateFeePolicy
+	err := db.DB.Where("landlord_id = ?", landlordID).First(&policy).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching late fee policy"})
+		return
+	}
+
+	status := http.StatusOK
+	if err == gorm.ErrRecordNotFound {
+		policy = models.LateFeePolicy{LandlordID: landlordID, Enabled: true}
+		status = http.StatusCreated
+	}
+	if input.Enabled != nil {
+		policy.Enabled = *input.Enabled
+	}
+	policy.FlatFee = input.FlatFee
+	policy.Percentage = input.Percentage
+	policy.GraceDays = input.GraceDays
+	policy.MaxFee = input.MaxFee
+
+	if err := db.DB.Save(&policy).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error saving late fee policy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"message": "Late fee policy saved successfully",
+		"policy":  policy.ToResponse(),
+	})
+}
@@ -0,0 +1,42 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// DeleteAttachment removes a file. Admins and the owning landlord can remove
+// any file on a record; other users only files they uploaded.
+func DeleteAttachment(c *gin.Context) {
+	var attachment models.Attachment
+	if err := db.DB.First(&attachment, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Attachment not found"})
+		return
+	}
+	if !authorizeEntity(c, attachment.EntityType, attachment.EntityID, true) {
+		return
+	}
+
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+	if userRole != "admin" && userRole != "landlord" && attachment.UploadedByID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only delete files you uploaded"})
+		return
+	}
+
+	if err := db.DB.WithContext(c).Delete(&attachment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting attachment"})
+		return
+	}
+	if err := storage.Files.Delete(c, attachment.StorageKey); err != nil {
+		logger.LogError(err, "Failed to delete stored file", logrus.Fields{"key": attachment.StorageKey})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
@@ -0,0 +1,55 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadAttachment serves a file through a signed URL handed out by the
+// attachment endpoints. The signature is the credential, so this route does
+// not need a bearer token.
+func DownloadAttachment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+	if err := storage.VerifySignedURL(uint(id), c.Query("expires"), c.Query("signature")); err != nil {
+		status := http.StatusForbidden
+		if errors.Is(err, storage.ErrURLExpired) {
+			status = http.StatusGone
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	var attachment models.Attachment
+	if err := db.DB.First(&attachment, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	reader, err := storage.Files.Open(c, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error reading file"})
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, reader, map[string]string{
+		"Content-Disposition":    fmt.Sprintf("attachment; filename=%q", attachment.FileName),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
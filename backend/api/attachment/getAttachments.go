@@ -0,0 +1,173 @@
+package attachment
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// Entities maps the path segment of each attachment route to the entity type
+// files are attached to
+var Entities = map[string]string{
+	"properties":  "property",
+	"leases":      "lease",
+	"maintenance": "maintenance",
+	"expenses":    "expense",
+	"comments":    "maintenance_comment",
+}
+
+// GetAttachments lists the files attached to a record with signed download
+// URLs, e.g. /admin/leases/12/attachments
+func GetAttachments(c *gin.Context) {
+	entityType, entityID, ok := entityFromPath(c)
+	if !ok || !authorizeEntity(c, entityType, entityID, false) {
+		return
+	}
+
+	var attachments []models.Attachment
+	if err := db.DB.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at").
+		Find(&attachments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching attachments"})
+		return
+	}
+
+	responses := make([]models.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		responses = append(responses, attachmentResponse(attachments[i]))
+	}
+	c.JSON(http.StatusOK, gin.H{"attachments": responses})
+}
+
+func attachmentResponse(attachment models.Attachment) models.AttachmentResponse {
+	url, expires := storage.SignedURL(attachment.ID)
+	return attachment.ToResponse(url, expires)
+}
+
+// entityFromPath reads the entity from the matched route, e.g. "leases" in
+// ".../leases/:id/attachments", and its ":id" path parameter
+func entityFromPath(c *gin.Context) (string, uint, bool) {
+	var segment string
+	segments := strings.Split(c.FullPath(), "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == ":id" {
+			segment = segments[i-1]
+		}
+	}
+	entityType, ok := Entities[segment]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown entity"})
+		return "", 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return "", 0, false
+	}
+	return entityType, uint(id), true
+}
+
+// authorizeEntity checks that the record exists and the current user may see
+// its files, or attach files to it when write is set. Admins reach every
+// record and landlords those on their own properties. Tenants only reach
+// their own leases and the maintenance requests they raised or that belong to
+// their leases; the maintenance team reaches maintenance requests. Comments
+// follow their request, except that tenants never reach internal comments and
+// only the author, an admin or the landlord may change a comment's files. It
+// writes the error response itself and returns false when the request must
+// stop.
+func authorizeEntity(c *gin.Context, entityType string, entityID uint, write bool) bool {
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+
+	var propertyID uint
+	var tenantIDs []uint
+	switch entityType {
+	case "property":
+		var property models.Property
+		if err := db.DB.First(&property, entityID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Property not found"})
+			return false
+		}
+		propertyID = property.ID
+	case "lease":
+		var lease models.Lease
+		if err := db.DB.First(&lease, entityID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Lease not found"})
+			return false
+		}
+		propertyID = lease.PropertyID
+		tenantIDs = append(tenantIDs, lease.TenantID)
+	case "maintenance":
+		var maintenance models.Maintenance
+		if err := db.DB.Preload("Lease").First(&maintenance, entityID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance request not found"})
+			return false
+		}
+		propertyID = maintenance.PropertyID
+		tenantIDs = append(tenantIDs, maintenance.RequestedByID)
+		if maintenance.Lease != nil {
+			tenantIDs = append(tenantIDs, maintenance.Lease.TenantID)
+		}
+		if userRole == "maintenanceTeam" {
+			return true
+		}
+	case "maintenance_comment":
+		var comment models.MaintenanceComment
+		if err := db.DB.Preload("Maintenance.Lease").First(&comment, entityID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+			return false
+		}
+		if userRole == "tenant" && comment.IsInternal() {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Comment not found"})
+			return false
+		}
+		if write && comment.AuthorID != userID && userRole != "admin" && userRole != "landlord" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only the author may attach files to a comment"})
+			return false
+		}
+		propertyID = comment.Maintenance.PropertyID
+		tenantIDs = append(tenantIDs, comment.Maintenance.RequestedByID)
+		if comment.Maintenance.Lease != nil {
+			tenantIDs = append(tenantIDs, comment.Maintenance.Lease.TenantID)
+		}
+		if userRole == "maintenanceTeam" {
+			return true
+		}
+	case "expense":
+		var expense models.Expense
+		if err := db.DB.First(&expense, entityID).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
+			return false
+		}
+		propertyID = expense.PropertyID
+	}
+
+	switch userRole {
+	case "admin":
+		return true
+	case "landlord":
+		var property models.Property
+		if err := db.DB.Select("owner_id").First(&property, propertyID).Error; err == nil && property.OwnerID == userID {
+			return true
+		}
+	case "tenant":
+		for _, tenantID := range tenantIDs {
+			if tenantID == userID {
+				return true
+			}
+		}
+	}
+
+	message := "You do not have access to these files"
+	if write {
+		message = "You may not attach files to this record"
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": message})
+	return false
+}
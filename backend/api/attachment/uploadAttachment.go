@@ -0,0 +1,117 @@
+package attachment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// multipartOverhead allows for the multipart framing around the file itself
+const multipartOverhead = 1 << 20
+
+// UploadAttachment stores a file sent as the "file" field of a multipart form
+// and attaches it to a record. The type is sniffed from the contents; only
+// images and PDFs are accepted.
+func UploadAttachment(c *gin.Context) {
+	entityType, entityID, ok := entityFromPath(c)
+	if !ok || !authorizeEntity(c, entityType, entityID, true) {
+		return
+	}
+
+	maxSize := storage.MaxFileSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+multipartOverhead)
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large", "max_file_size": maxSize})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required in the \"file\" form field", "details": err.Error()})
+		return
+	}
+	if header.Size > maxSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "File is too large", "max_file_size": maxSize})
+		return
+	}
+	if header.Size == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is empty"})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Error reading file", "details": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Error reading file", "details": err.Error()})
+		return
+	}
+	contentType, allowed := storage.SniffContentType(head[:n])
+	if !allowed {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only JPEG, PNG, GIF, WebP images and PDF documents can be uploaded", "content_type": contentType})
+		return
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error reading file"})
+		return
+	}
+
+	name, err := randomName()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error storing file"})
+		return
+	}
+	key := fmt.Sprintf("%s/%d/%s%s", entityType, entityID, name, storage.Extension(contentType))
+	if err := storage.Files.Put(c, key, file, header.Size, contentType); err != nil {
+		logger.LogError(err, "Failed to store upload", logrus.Fields{"key": key})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error storing file"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	attachment := models.Attachment{
+		EntityType:   entityType,
+		EntityID:     entityID,
+		StorageKey:   key,
+		FileName:     storage.CleanFileName(header.Filename),
+		ContentType:  contentType,
+		Size:         header.Size,
+		UploadedByID: userID.(uint),
+	}
+	if err := db.DB.WithContext(c).Create(&attachment).Error; err != nil {
+		if err := storage.Files.Delete(c, key); err != nil {
+			logger.LogError(err, "Failed to remove orphaned upload", logrus.Fields{"key": key})
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error saving attachment", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "File uploaded successfully",
+		"attachment": attachmentResponse(attachment),
+	})
+}
+
+func randomName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
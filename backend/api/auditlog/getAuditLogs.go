@@ -0,0 +1,95 @@
+package auditlog
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// GetAuditLogs lists audit entries, newest first. Filters: user_id, action,
+// entity_type, entity_id and a from/to date range (YYYY-MM-DD, inclusive).
+// Paginated with page and page_size.
+func GetAuditLogs(c *gin.Context) {
+	page, pageSize := 1, defaultPageSize
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if s, err := strconv.Atoi(c.Query("page_size")); err == nil && s > 0 {
+		pageSize = s
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	query := db.DB.Model(&models.AuditLog{})
+	if userID := c.Query("user_id"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			return
+		}
+		query = query.Where("user_id = ?", id)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if entityID := c.Query("entity_id"); entityID != "" {
+		id, err := strconv.ParseUint(entityID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entity_id"})
+			return
+		}
+		query = query.Where("entity_id = ?", id)
+	}
+	if from := c.Query("from"); from != "" {
+		fromDate, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		query = query.Where("created_at >= ?", fromDate)
+	}
+	if to := c.Query("to"); to != "" {
+		toDate, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		query = query.Where("created_at < ?", toDate.AddDate(0, 0, 1))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting audit logs"})
+		return
+	}
+
+	var logs []models.AuditLog
+	if err := query.Preload("User").
+		Order("created_at DESC, id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&logs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching audit logs"})
+		return
+	}
+
+	entries := make([]models.AuditLogResponse, 0, len(logs))
+	for i := range logs {
+		entries = append(entries, logs[i].ToResponse())
+	}
+
+	response.Paginated(c, entries, response.CalculatePagination(page, pageSize, int(total)), "Audit logs retrieved successfully")
+}
@@ -0,0 +1,65 @@
+package auditlog
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/geoo115/property-manager/audit"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// HistoryEntities maps the path segment of each history route to the audited
+// entity type
+var HistoryEntities = map[string]string{
+	"users":       "user",
+	"properties":  "property",
+	"leases":      "lease",
+	"invoices":    "invoice",
+	"expenses":    "expense",
+	"maintenance": "maintenance",
+}
+
+// GetEntityHistory returns the chronological change timeline of one record,
+// e.g. /admin/leases/12/history
+func GetEntityHistory(c *gin.Context) {
+	entityType, ok := HistoryEntities[routeEntity(c)]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown entity"})
+		return
+	}
+	entityID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		return
+	}
+
+	var logs []models.AuditLog
+	if err := db.DB.Preload("User").
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at, id").
+		Find(&logs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+		"history":     audit.Timeline(logs),
+	})
+}
+
+// routeEntity returns the path segment naming the entity of the matched
+// route, e.g. "leases" for ".../leases/:id/history"
+func routeEntity(c *gin.Context) string {
+	segments := strings.Split(c.FullPath(), "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == ":id" {
+			return segments[i-1]
+		}
+	}
+	return ""
+}
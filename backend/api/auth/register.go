@@ -60,7 +60,7 @@ func RegisterHandler(c *gin.Context) {
 		IsActive:  true,
 	}
 
-	if err := db.DB.Create(&user).Error; err != nil {
+	if err := db.DB.WithContext(c).Create(&user).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating user"})
 		return
 	}
@@ -0,0 +1,99 @@
+package deposit
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errDepositNotHeld          = errors.New("deposit is not held")
+	errDeductionsExceedDeposit = errors.New("deductions exceed the deposit received")
+)
+
+// AddDeduction itemises an amount to keep back from the deposit, optionally
+// linked to the maintenance request or expense it covers
+func AddDeduction(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, false)
+	if !ok {
+		return
+	}
+
+	var input models.DepositDeductionRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deduction data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deduction data", "details": err.Error()})
+		return
+	}
+
+	// Linked records must belong to the leased property
+	if input.MaintenanceID != nil {
+		var count int64
+		db.DB.Model(&models.Maintenance{}).Where("id = ? AND property_id = ?", *input.MaintenanceID, lease.PropertyID).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Maintenance request not found for this property"})
+			return
+		}
+	}
+	if input.ExpenseID != nil {
+		var count int64
+		db.DB.Model(&models.Expense{}).Where("id = ? AND property_id = ?", *input.ExpenseID, lease.PropertyID).Count(&count)
+		if count == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Expense not found for this property"})
+			return
+		}
+	}
+
+	// The deposit row stays locked from summing its deductions to adding this
+	// one, so concurrent deductions cannot together exceed what was received
+	userID, _ := c.Get("user_id")
+	var deposit models.Deposit
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("lease_id = ?", lease.ID).First(&models.Deposit{}).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var err error
+		if deposit, err = findDeposit(tx, lease); err != nil {
+			return err
+		}
+		if !deposit.CanDeduct() {
+			return errDepositNotHeld
+		}
+		if deposit.TotalDeductions()+input.Amount > deposit.ReceivedAmount {
+			return errDeductionsExceedDeposit
+		}
+
+		return tx.Create(&models.DepositDeduction{
+			DepositID:     deposit.ID,
+			Category:      input.Category,
+			Description:   input.Description,
+			Amount:        input.Amount,
+			MaintenanceID: input.MaintenanceID,
+			ExpenseID:     input.ExpenseID,
+			CreatedByID:   userID.(uint),
+		}).Error
+	})
+	switch {
+	case errors.Is(err, errDepositNotHeld):
+		c.JSON(http.StatusConflict, gin.H{"error": "Deductions can only be made from a deposit that is held", "status": deposit.Status})
+		return
+	case errors.Is(err, errDeductionsExceedDeposit):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Deductions cannot exceed the deposit received"})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error adding deduction", "details": err.Error()})
+		return
+	}
+
+	respondWithDeposit(c, http.StatusCreated, "Deduction added successfully", lease)
+}
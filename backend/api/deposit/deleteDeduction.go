@@ -0,0 +1,40 @@
+package deposit
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteDeduction removes a deduction while the deposit is still held or
+// being settled
+func DeleteDeduction(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, false)
+	if !ok {
+		return
+	}
+
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+	if !deposit.CanDeduct() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Deductions can no longer be changed", "status": deposit.Status})
+		return
+	}
+
+	result := db.DB.Where("id = ? AND deposit_id = ?", c.Param("deductionID"), deposit.ID).Delete(&models.DepositDeduction{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting deduction"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deduction not found"})
+		return
+	}
+
+	respondWithDeposit(c, http.StatusOK, "Deduction deleted successfully", lease)
+}
@@ -0,0 +1,47 @@
+package deposit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// DisputeDeposit lets a tenant dispute the proposed deductions from their
+// deposit
+func DisputeDeposit(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, true)
+	if !ok {
+		return
+	}
+
+	var input models.DepositDisputeRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dispute data", "details": err.Error()})
+		return
+	}
+
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+	if deposit.Status != "settlement_proposed" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only a proposed settlement can be disputed", "status": deposit.Status})
+		return
+	}
+
+	now := time.Now().UTC()
+	if err := db.DB.Model(&models.Deposit{}).Where("id = ?", deposit.ID).Updates(map[string]interface{}{
+		"status":         "disputed",
+		"dispute_reason": input.Reason,
+		"disputed_at":    now,
+	}).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recording dispute"})
+		return
+	}
+
+	respondWithDeposit(c, http.StatusOK, "Deposit dispute recorded", lease)
+}
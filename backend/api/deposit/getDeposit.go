@@ -0,0 +1,91 @@
+package deposit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetDeposit returns the deposit of a lease with its deductions and the
+// amount due back to the tenant
+func GetDeposit(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, true)
+	if !ok {
+		return
+	}
+
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deposit": deposit.ToResponse()})
+}
+
+// loadAccessibleLease loads the lease from the ":id" path parameter and makes
+// sure landlords only reach leases of their own properties and, when
+// allowTenant is set, tenants only their own leases. It writes the error
+// response itself and returns false when the request must stop.
+func loadAccessibleLease(c *gin.Context, allowTenant bool) (models.Lease, bool) {
+	var lease models.Lease
+	if err := db.DB.Preload("Property").First(&lease, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lease not found"})
+		return lease, false
+	}
+
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+	switch userRole {
+	case "admin":
+	case "landlord":
+		if lease.Property.OwnerID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return lease, false
+		}
+	case "tenant":
+		if !allowTenant || lease.TenantID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return lease, false
+		}
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return lease, false
+	}
+	return lease, true
+}
+
+// findDeposit loads the deposit of a lease, or a new unsaved one awaiting
+// receipt when nothing has been recorded yet
+func findDeposit(tx *gorm.DB, lease models.Lease) (models.Deposit, error) {
+	var deposit models.Deposit
+	err := tx.Preload("Deductions", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("lease_id = ?", lease.ID).First(&deposit).Error
+	if err == gorm.ErrRecordNotFound {
+		return models.Deposit{
+			LeaseID:  lease.ID,
+			TenantID: lease.TenantID,
+			Amount:   lease.SecurityDeposit,
+			Status:   "awaiting",
+		}, nil
+	}
+	return deposit, err
+}
+
+// respondWithDeposit reloads a deposit, clears cached data and writes it out
+func respondWithDeposit(c *gin.Context, status int, message string, lease models.Lease) {
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+	db.RedisClient.FlushDB(context.Background())
+	c.JSON(status, gin.H{
+		"message": message,
+		"deposit": deposit.ToResponse(),
+	})
+}
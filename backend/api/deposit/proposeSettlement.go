@@ -0,0 +1,40 @@
+package deposit
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// ProposeSettlement puts the itemised deductions to the tenant once the lease
+// has ended. The tenant can accept it by waiting for the return or dispute it.
+func ProposeSettlement(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, false)
+	if !ok {
+		return
+	}
+	if lease.Status != "expired" && lease.Status != "terminated" {
+		c.JSON(http.StatusConflict, gin.H{"error": "The deposit can only be settled after the lease has ended"})
+		return
+	}
+
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+	if deposit.Status != "held" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only a held deposit can be put forward for settlement", "status": deposit.Status})
+		return
+	}
+
+	if err := db.DB.Model(&models.Deposit{}).Where("id = ?", deposit.ID).
+		Update("status", "settlement_proposed").Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error proposing settlement"})
+		return
+	}
+
+	respondWithDeposit(c, http.StatusOK, "Deposit settlement proposed", lease)
+}
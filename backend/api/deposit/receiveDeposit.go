@@ -0,0 +1,83 @@
+package deposit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// ReceiveDeposit records receipt of a lease's deposit and the protection
+// scheme it is registered with. While the deposit is held it can be called
+// again to update the protection details.
+func ReceiveDeposit(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, false)
+	if !ok {
+		return
+	}
+
+	var input models.DepositReceiptRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deposit data", "details": err.Error()})
+		return
+	}
+
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+	if deposit.Status != "awaiting" && deposit.Status != "held" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Deposit is already being settled", "status": deposit.Status})
+		return
+	}
+
+	receivedAt := time.Now().UTC()
+	if input.ReceivedAt != "" {
+		if receivedAt, err = time.Parse("2006-01-02", input.ReceivedAt); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid received date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+	}
+	if input.Amount < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "amount must not be negative"})
+		return
+	}
+	if input.Amount == 0 {
+		input.Amount = deposit.Amount
+	}
+
+	if deposit.Status == "awaiting" || input.ReceivedAt != "" {
+		deposit.ReceivedAt = &receivedAt
+	}
+	deposit.ReceivedAmount = input.Amount
+	deposit.Status = "held"
+	if input.ProtectionScheme != "" {
+		deposit.ProtectionScheme = input.ProtectionScheme
+	}
+	if input.ProtectionReference != "" {
+		deposit.ProtectionReference = input.ProtectionReference
+	}
+	if input.ProtectedAt != "" {
+		protectedAt, err := time.Parse("2006-01-02", input.ProtectedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid protected date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		deposit.ProtectedAt = &protectedAt
+	} else if deposit.ProtectedAt == nil && deposit.ProtectionReference != "" {
+		deposit.ProtectedAt = &receivedAt
+	}
+	if input.Notes != "" {
+		deposit.Notes = input.Notes
+	}
+
+	if err := db.DB.Omit("Deductions").Save(&deposit).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recording deposit", "details": err.Error()})
+		return
+	}
+
+	respondWithDeposit(c, http.StatusOK, "Deposit recorded successfully", lease)
+}
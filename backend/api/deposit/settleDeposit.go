@@ -0,0 +1,64 @@
+package deposit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// SettleDeposit returns the deposit less deductions to the tenant. A disputed
+// deposit needs a dispute_resolution describing how it was resolved.
+func SettleDeposit(c *gin.Context) {
+	lease, ok := loadAccessibleLease(c, false)
+	if !ok {
+		return
+	}
+
+	var input models.DepositSettlementRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid settlement data", "details": err.Error()})
+		return
+	}
+
+	deposit, err := findDeposit(db.DB, lease)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching deposit"})
+		return
+	}
+	if deposit.Status != "settlement_proposed" && deposit.Status != "disputed" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Propose a settlement before returning the deposit", "status": deposit.Status})
+		return
+	}
+	if deposit.Status == "disputed" && input.DisputeResolution == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dispute_resolution is required to settle a disputed deposit"})
+		return
+	}
+
+	returnedAt := time.Now().UTC()
+	if input.ReturnedAt != "" {
+		if returnedAt, err = time.Parse("2006-01-02", input.ReturnedAt); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid returned date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+	}
+
+	updates := map[string]interface{}{
+		"status":           "returned",
+		"returned_amount":  deposit.AmountToReturn(),
+		"returned_at":      returnedAt,
+		"return_method":    input.ReturnMethod,
+		"return_reference": input.ReturnReference,
+	}
+	if input.DisputeResolution != "" {
+		updates["dispute_resolution"] = input.DisputeResolution
+	}
+	if err := db.DB.Model(&models.Deposit{}).Where("id = ?", deposit.ID).Updates(updates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error settling deposit"})
+		return
+	}
+
+	respondWithDeposit(c, http.StatusOK, "Deposit returned successfully", lease)
+}
@@ -0,0 +1,36 @@
+package event
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/geoo115/property-manager/events"
+	"github.com/gin-gonic/gin"
+)
+
+// catalogEntry is an event type with the JSON schema of its data
+type catalogEntry struct {
+	events.EventType
+	Schema json.RawMessage `json:"schema"`
+}
+
+// GetCatalog lists the domain events published to Kafka with the schema of
+// each one's current version
+func GetCatalog(c *gin.Context) {
+	catalog := events.Catalog()
+	entries := make([]catalogEntry, 0, len(catalog))
+	for _, e := range catalog {
+		schema, err := events.Schema(e.Aggregate, e.Version)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error loading event schema", "details": err.Error()})
+			return
+		}
+		entries = append(entries, catalogEntry{EventType: e, Schema: schema})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"spec_version": events.SpecVersion,
+		"source":       events.Source,
+		"events":       entries,
+	})
+}
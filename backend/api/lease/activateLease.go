@@ -0,0 +1,110 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ActivateLease starts a pending lease ahead of the lifecycle job
+func ActivateLease(c *gin.Context) {
+	lease, ok := loadLeaseForAction(c, false)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		return leasing.Activate(tx, &lease, userID.(uint))
+	})
+	if err != nil {
+		respondLifecycleError(c, "Error activating lease", err)
+		return
+	}
+
+	respondWithLease(c, http.StatusOK, "Lease activated successfully", lease.ID)
+}
+
+// loadLeaseForAction loads the lease in the URL and checks the caller may act
+// on it: admins on any lease, landlords on leases of their properties and,
+// when allowTenant is set, tenants on their own leases
+func loadLeaseForAction(c *gin.Context, allowTenant bool) (models.Lease, bool) {
+	var lease models.Lease
+	if err := db.DB.Preload("Property").First(&lease, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lease not found"})
+		return lease, false
+	}
+
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+	switch userRole {
+	case "admin":
+	case "landlord":
+		if lease.Property.OwnerID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return lease, false
+		}
+	case "tenant":
+		if !allowTenant || lease.TenantID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return lease, false
+		}
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return lease, false
+	}
+	return lease, true
+}
+
+// respondLifecycleError maps lifecycle errors onto HTTP status codes
+func respondLifecycleError(c *gin.Context, message string, err error) {
+	if respondOverlap(c, err) {
+		return
+	}
+	status := http.StatusInternalServerError
+	if errors.Is(err, leasing.ErrInvalidRenewal) {
+		status = http.StatusBadRequest
+	} else if errors.Is(err, leasing.ErrInvalidTransition) || errors.Is(err, leasing.ErrAlreadyRenewed) || errors.Is(err, leasing.ErrUnderNotice) {
+		status = http.StatusConflict
+	}
+	c.JSON(status, gin.H{"error": message, "details": err.Error()})
+}
+
+// respondOverlap writes a 409 listing the conflicting leases when err is a
+// double-booking, and reports whether it did
+func respondOverlap(c *gin.Context, err error) bool {
+	var overlap *leasing.OverlapError
+	if errors.As(err, &overlap) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error":              "Lease overlaps an existing lease for this property or unit",
+			"conflicting_leases": overlap.Conflicts,
+		})
+		return true
+	}
+	if leasing.IsOverlapViolation(err) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Lease overlaps an existing lease for this property or unit", "details": err.Error()})
+		return true
+	}
+	return false
+}
+
+// respondWithLease reloads a lease, clears cached data and writes it out
+func respondWithLease(c *gin.Context, status int, message string, leaseID uint) {
+	var lease models.Lease
+	if err := db.DB.Preload("Tenant").Preload("Property.Owner").Preload("Unit").
+		First(&lease, leaseID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching lease details"})
+		return
+	}
+	db.RedisClient.FlushDB(context.Background())
+	c.JSON(status, gin.H{
+		"message": message,
+		"lease":   lease,
+	})
+}
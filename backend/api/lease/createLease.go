@@ -5,15 +5,20 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/geoo115/property-manager/api/unit"
 	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
 	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/webhooks"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func CreateLease(c *gin.Context) {
 	var input struct {
 		TenantID        uint    `json:"tenant_id" binding:"required"`
 		PropertyID      uint    `json:"property_id" binding:"required"`
+		UnitID          *uint   `json:"unit_id"`
 		StartDate       string  `json:"start_date" binding:"required"`
 		EndDate         string  `json:"end_date" binding:"required"`
 		MonthlyRent     float64 `json:"monthly_rent" binding:"required,gte=0"`
@@ -50,23 +55,53 @@ func CreateLease(c *gin.Context) {
 		return
 	}
 
+	if _, err := unit.ValidateForProperty(input.UnitID, property.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit", "details": err.Error()})
+		return
+	}
+
 	// Create the lease
 	lease := models.Lease{
 		TenantID:        input.TenantID,
 		PropertyID:      input.PropertyID,
+		UnitID:          input.UnitID,
 		StartDate:       startDate,
 		EndDate:         endDate,
 		MonthlyRent:     input.MonthlyRent,
 		SecurityDeposit: input.SecurityDeposit,
+		Status:          "pending",
 	}
 
-	if err := db.DB.Create(&lease).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating lease"})
+	userID, _ := c.Get("user_id")
+	createdByID, _ := userID.(uint)
+
+	// Leases starting in the future stay pending until the lifecycle job
+	// activates them; others start straight away with their invoices raised
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := leasing.CheckOverlap(tx, lease); err != nil {
+			return err
+		}
+		if err := tx.Create(&lease).Error; err != nil {
+			return err
+		}
+		if err := webhooks.Publish(tx, models.WebhookLeaseCreated, lease.PropertyID, webhooks.LeaseData(lease)); err != nil {
+			return err
+		}
+		if lease.StartDate.After(time.Now().UTC()) {
+			return nil
+		}
+		return leasing.Activate(tx, &lease, createdByID)
+	})
+	if err != nil {
+		if respondOverlap(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating lease", "details": err.Error()})
 		return
 	}
 
 	// Reload the lease with preloaded Tenant & Property data
-	if err := db.DB.Preload("Tenant").Preload("Property.Owner").First(&lease, lease.ID).Error; err != nil {
+	if err := db.DB.Preload("Tenant").Preload("Property.Owner").Preload("Unit").First(&lease, lease.ID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching lease details"})
 		return
 	}
@@ -5,8 +5,10 @@ import (
 	"net/http"
 
 	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
 	"github.com/geoo115/property-manager/models"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func DeleteLease(c *gin.Context) {
@@ -18,7 +20,14 @@ func DeleteLease(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Delete(&lease).Error; err != nil {
+	// Free the unit or property the lease held along with deleting it
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := leasing.Vacate(tx, lease); err != nil {
+			return err
+		}
+		return tx.Delete(&lease).Error
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting lease"})
 		return
 	}
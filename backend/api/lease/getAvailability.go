@@ -0,0 +1,83 @@
+package lease
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geoo115/property-manager/api/unit"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPropertyAvailability returns the date ranges in which a property, or one
+// of its units with ?unit_id=, is free to let. The window defaults to the next
+// twelve months and can be set with ?from= and ?to= (YYYY-MM-DD).
+func GetPropertyAvailability(c *gin.Context) {
+	var property models.Property
+	if err := db.DB.First(&property, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Property not found"})
+		return
+	}
+
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+	if userRole == "landlord" && property.OwnerID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
+
+	var unitID *uint
+	if value := c.Query("unit_id"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit_id"})
+			return
+		}
+		id := uint(parsed)
+		if _, err := unit.ValidateForProperty(&id, property.ID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit", "details": err.Error()})
+			return
+		}
+		unitID = &id
+	}
+
+	from := time.Now().UTC()
+	to := from.AddDate(1, 0, 0)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must not be before from"})
+		return
+	}
+
+	free, err := leasing.Availability(db.DB, property.ID, unitID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking availability", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"property_id": property.ID,
+		"unit_id":     unitID,
+		"from":        from.Format("2006-01-02"),
+		"to":          to.Format("2006-01-02"),
+		"available":   free,
+	})
+}
@@ -29,7 +29,7 @@ func GetLeaseByID(c *gin.Context) {
 
 	// Fetch from database if cache miss
 	var lease models.Lease
-	if err := db.DB.Preload("Tenant").Preload("Property.Owner").
+	if err := db.DB.Preload("Tenant").Preload("Property.Owner").Preload("Unit").
 		First(&lease, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Lease not found"})
 		return
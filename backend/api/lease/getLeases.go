@@ -27,7 +27,7 @@ func GetLeases(c *gin.Context) {
 	}
 
 	var leases []models.Lease
-	if err := db.DB.Preload("Tenant").Preload("Property.Owner").Find(&leases).Error; err != nil {
+	if err := db.DB.Preload("Tenant").Preload("Property.Owner").Preload("Unit").Find(&leases).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching leases"})
 		return
 	}
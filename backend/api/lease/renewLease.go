@@ -0,0 +1,51 @@
+package lease
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RenewLease creates a pending successor lease that starts the day after the
+// current one ends
+func RenewLease(c *gin.Context) {
+	lease, ok := loadLeaseForAction(c, false)
+	if !ok {
+		return
+	}
+
+	var input models.LeaseRenewalRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid renewal data", "details": err.Error()})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", input.EndDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format, use YYYY-MM-DD", "details": err.Error()})
+		return
+	}
+
+	renewal := leasing.Renewal{EndDate: endDate, RenewalTerms: input.RenewalTerms}
+	if input.MonthlyRent != nil {
+		renewal.MonthlyRent = *input.MonthlyRent
+	}
+
+	var successor *models.Lease
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		successor, err = leasing.Renew(tx, &lease, renewal)
+		return err
+	})
+	if err != nil {
+		respondLifecycleError(c, "Error renewing lease", err)
+		return
+	}
+
+	respondWithLease(c, http.StatusCreated, "Lease renewed successfully", successor.ID)
+}
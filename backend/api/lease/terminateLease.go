@@ -0,0 +1,82 @@
+package lease
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TerminateLease gives notice to end a lease early. The last day is pushed
+// back to the end of the lease's notice period; only admins and landlords may
+// waive the notice.
+func TerminateLease(c *gin.Context) {
+	lease, ok := loadLeaseForAction(c, true)
+	if !ok {
+		return
+	}
+
+	var input models.LeaseTerminationRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid termination data", "details": err.Error()})
+		return
+	}
+
+	termination := leasing.Termination{
+		NoticeDate:  time.Now().UTC(),
+		Reason:      input.Reason,
+		WaiveNotice: input.WaiveNotice,
+	}
+	if input.NoticeDate != "" {
+		noticeDate, err := time.Parse("2006-01-02", input.NoticeDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notice date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		termination.NoticeDate = noticeDate
+	}
+	if input.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", input.EndDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end date format, use YYYY-MM-DD", "details": err.Error()})
+			return
+		}
+		termination.EndDate = endDate
+	}
+
+	userRole, _ := c.Get("user_role")
+	if userRole == "tenant" && input.WaiveNotice {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Tenants cannot waive the notice period"})
+		return
+	}
+	if lease.UnderNotice() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Notice has already been given on this lease"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	actorID := userID.(uint)
+	if userRole == "tenant" {
+		// Invoices raised on a tenant's behalf are attributed to the landlord
+		actorID = lease.Property.OwnerID
+	}
+
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		_, err := leasing.Terminate(tx, &lease, termination, actorID)
+		return err
+	})
+	if err != nil {
+		respondLifecycleError(c, "Error terminating lease", err)
+		return
+	}
+
+	message := "Lease terminated successfully"
+	if lease.Status == "active" {
+		message = "Notice recorded; lease ends on " + lease.EndDate.Format("2006-01-02")
+	}
+	respondWithLease(c, http.StatusOK, message, lease.ID)
+}
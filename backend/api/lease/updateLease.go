@@ -5,9 +5,14 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/geoo115/property-manager/api/unit"
+	"github.com/geoo115/property-manager/billing"
 	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
 	"github.com/geoo115/property-manager/models"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func UpdateLease(c *gin.Context) {
@@ -16,10 +21,12 @@ func UpdateLease(c *gin.Context) {
 	var input struct {
 		TenantID        uint      `json:"tenant_id" binding:"required"`
 		PropertyID      uint      `json:"property_id" binding:"required"`
+		UnitID          *uint     `json:"unit_id"`
 		StartDate       time.Time `json:"start_date" binding:"required"`
 		EndDate         time.Time `json:"end_date" binding:"required"`
 		MonthlyRent     float64   `json:"monthly_rent" binding:"required,gte=0"`
 		SecurityDeposit float64   `json:"security_deposit" binding:"required,gte=0"`
+		Status          string    `json:"status" binding:"omitempty,oneof=active expired terminated pending"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -52,7 +59,14 @@ func UpdateLease(c *gin.Context) {
 		return
 	}
 
+	if _, err := unit.ValidateForProperty(input.UnitID, input.PropertyID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit", "details": err.Error()})
+		return
+	}
+
 	// Update lease
+	previous := lease
+	lease.UnitID = input.UnitID
 	lease.TenantID = input.TenantID
 	lease.PropertyID = input.PropertyID
 	lease.StartDate = input.StartDate
@@ -60,13 +74,44 @@ func UpdateLease(c *gin.Context) {
 	lease.MonthlyRent = input.MonthlyRent
 	lease.SecurityDeposit = input.SecurityDeposit
 
-	if err := db.DB.Save(&lease).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating lease"})
+	// Status changes go through the lifecycle rules
+	statusChanged := input.Status != "" && input.Status != lease.Status
+	if statusChanged && !leasing.CanTransition(lease.Status, input.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Invalid lease status transition", "details": lease.Status + " to " + input.Status})
+		return
+	}
+
+	// Save the lease and cancel or regenerate its unpaid future invoices
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		candidate := lease
+		if statusChanged {
+			candidate.Status = input.Status
+		}
+		if err := leasing.CheckOverlap(tx, candidate); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(&lease).Error; err != nil {
+			return err
+		}
+		if err := leasing.UpdateOccupancy(tx, previous, lease); err != nil {
+			return err
+		}
+		if statusChanged {
+			return leasing.Transition(tx, &lease, input.Status, userID.(uint))
+		}
+		_, err := billing.SyncLeaseSchedule(tx, lease, userID.(uint))
+		return err
+	})
+	if err != nil {
+		if respondOverlap(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating lease", "details": err.Error()})
 		return
 	}
 
 	// Reload with preloaded data
-	if err := db.DB.Preload("Tenant").Preload("Property.Owner").
+	if err := db.DB.Preload("Tenant").Preload("Property.Owner").Preload("Unit").
 		First(&lease, lease.ID).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching updated lease"})
 		return
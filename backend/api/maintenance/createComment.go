@@ -0,0 +1,52 @@
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateComment adds a comment to a maintenance request's thread. Staff may
+// mark a comment internal to hide it from the tenant.
+func CreateComment(c *gin.Context) {
+	maintenance, ok := threadMaintenance(c)
+	if !ok {
+		return
+	}
+
+	var input models.MaintenanceCommentRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment data", "details": err.Error()})
+		return
+	}
+	if input.Visibility == "" {
+		input.Visibility = "public"
+	}
+
+	userRole, _ := c.Get("user_role")
+	if userRole == "tenant" && input.Visibility == "internal" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Tenants cannot post internal comments"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	comment := models.MaintenanceComment{
+		MaintenanceID: maintenance.ID,
+		AuthorID:      userID.(uint),
+		Body:          input.Body,
+		Visibility:    input.Visibility,
+	}
+	if err := db.DB.WithContext(c).Create(&comment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add comment", "details": err.Error()})
+		return
+	}
+	db.DB.First(&comment.Author, comment.AuthorID)
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Comment added successfully", "comment": comment.ToResponse(nil)})
+}
@@ -8,15 +8,17 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/geoo115/property-manager/api/unit"
 	"github.com/geoo115/property-manager/db"
-	"github.com/geoo115/property-manager/events"
 	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // CreateMaintenanceByProperty creates a maintenance request for a property and invalidates Redis caches.
 func CreateMaintenanceByProperty(c *gin.Context) {
-	propertyIDStr := c.Param("propertyID")
+	propertyIDStr := c.Param("id")
 	propertyID, err := strconv.ParseUint(propertyIDStr, 10, 32)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid property ID"})
@@ -25,6 +27,7 @@ func CreateMaintenanceByProperty(c *gin.Context) {
 
 	var input struct {
 		Description string `json:"description" binding:"required"`
+		UnitID      *uint  `json:"unit_id"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -32,6 +35,11 @@ func CreateMaintenanceByProperty(c *gin.Context) {
 		return
 	}
 
+	if _, err := unit.ValidateForProperty(input.UnitID, uint(propertyID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit", "details": err.Error()})
+		return
+	}
+
 	userRole, _ := c.Get("user_role")
 	userID, _ := c.Get("user_id")
 
@@ -50,12 +58,24 @@ func CreateMaintenanceByProperty(c *gin.Context) {
 	maintenance := models.Maintenance{
 		RequestedByID: userID.(uint),
 		PropertyID:    uint(propertyID),
+		UnitID:        input.UnitID,
 		Description:   input.Description,
 		RequestedAt:   time.Now(),
 		Status:        "pending",
 	}
+	servicing.StartClock(&maintenance, maintenance.RequestedAt)
 
-	if err := db.DB.Create(&maintenance).Error; err != nil {
+	requesterID := userID.(uint)
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&maintenance).Error; err != nil {
+			return err
+		}
+		if err := servicing.RecordStatusChange(tx, maintenance.ID, "", maintenance.Status, &requesterID); err != nil {
+			return err
+		}
+		return servicing.PublishMaintenance(tx, models.WebhookMaintenanceCreated, &maintenance)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating maintenance request"})
 		return
 	}
@@ -65,11 +85,6 @@ func CreateMaintenanceByProperty(c *gin.Context) {
 		return
 	}
 
-	if err := events.ProduceMaintenanceRequest(maintenance); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send Kafka event"})
-		return
-	}
-
 	// Invalidate Redis caches
 	ctx := context.Background()
 	cacheKeys := []string{
@@ -84,6 +99,7 @@ func CreateMaintenanceByProperty(c *gin.Context) {
 		}
 	}
 
+	maintenance.WithSLA(time.Now())
 	c.JSON(http.StatusCreated, gin.H{
 		"message":     "Maintenance request created successfully",
 		"maintenance": maintenance,
@@ -91,20 +107,7 @@ func CreateMaintenanceByProperty(c *gin.Context) {
 }
 
 func CreateMaintenanceByLease(c *gin.Context) {
-	// Log all URL parameters for debugging
-	log.Printf("All URL params: %+v", c.Params)
-
-	leaseID := c.Param("leaseID")
-	if leaseID == "" {
-		log.Printf("leaseID is empty, falling back to check other params")
-		// Fallback check for common param names
-		leaseID = c.Param("id") // Alternative if misnamed in route
-		if leaseID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Missing lease ID in URL"})
-			return
-		}
-	}
-	log.Printf("Received leaseID from URL: %s", leaseID)
+	leaseID := c.Param("id")
 
 	var input struct {
 		Description string `json:"description" binding:"required"`
@@ -122,7 +125,6 @@ func CreateMaintenanceByLease(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Lease not found"})
 		return
 	}
-	log.Printf("Fetched lease: %+v", lease)
 
 	if lease.EndDate.Before(time.Now()) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "No active lease found for tenant on this property"})
@@ -146,12 +148,25 @@ func CreateMaintenanceByLease(c *gin.Context) {
 	maintenance := models.Maintenance{
 		RequestedByID: userID.(uint),
 		PropertyID:    lease.PropertyID,
+		LeaseID:       &lease.ID,
+		UnitID:        lease.UnitID,
 		Description:   input.Description,
 		RequestedAt:   time.Now(),
 		Status:        "pending",
 	}
+	servicing.StartClock(&maintenance, maintenance.RequestedAt)
 
-	if err := db.DB.Create(&maintenance).Error; err != nil {
+	requesterID := userID.(uint)
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&maintenance).Error; err != nil {
+			return err
+		}
+		if err := servicing.RecordStatusChange(tx, maintenance.ID, "", maintenance.Status, &requesterID); err != nil {
+			return err
+		}
+		return servicing.PublishMaintenance(tx, models.WebhookMaintenanceCreated, &maintenance)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating maintenance request"})
 		return
 	}
@@ -161,11 +176,6 @@ func CreateMaintenanceByLease(c *gin.Context) {
 		return
 	}
 
-	if err := events.ProduceMaintenanceRequest(maintenance); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send Kafka event"})
-		return
-	}
-
 	// Invalidate Redis caches
 	ctx := context.Background()
 	cacheKeys := []string{
@@ -180,6 +190,7 @@ func CreateMaintenanceByLease(c *gin.Context) {
 		}
 	}
 
+	maintenance.WithSLA(time.Now())
 	c.JSON(http.StatusCreated, gin.H{
 		"message":     "Maintenance request created successfully",
 		"maintenance": maintenance,
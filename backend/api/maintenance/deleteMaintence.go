@@ -20,7 +20,7 @@ func DeleteMaintenance(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Delete(&maintenance).Error; err != nil {
+	if err := db.DB.WithContext(c).Delete(&maintenance).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting maintenance request"})
 		return
 	}
@@ -0,0 +1,134 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DispatchMaintenance books a technician for a request. Without a
+// technician_id it suggests the skilled technicians ranked by availability
+// and load; with auto set it assigns the top available one.
+func DispatchMaintenance(c *gin.Context) {
+	var input struct {
+		ScheduledAt    time.Time  `json:"scheduled_at" binding:"required"`
+		ScheduledEndAt *time.Time `json:"scheduled_end_at"`
+		TechnicianID   *uint      `json:"technician_id"`
+		Auto           bool       `json:"auto"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid dispatch data", "details": err.Error()})
+		return
+	}
+	if input.ScheduledEndAt != nil && !input.ScheduledEndAt.After(input.ScheduledAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scheduled_end_at must be after scheduled_at"})
+		return
+	}
+
+	var maintenance models.Maintenance
+	if err := db.DB.First(&maintenance, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		return
+	}
+	if !maintenance.IsOpen() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only open maintenance requests can be dispatched"})
+		return
+	}
+	servicing.Schedule(&maintenance, input.ScheduledAt, input.ScheduledEndAt)
+	start, end, _ := servicing.Appointment(&maintenance)
+
+	technicianID := input.TechnicianID
+	if technicianID == nil {
+		candidates, err := servicing.Candidates(db.DB, &maintenance, start, end)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error finding technicians", "details": err.Error()})
+			return
+		}
+		if !input.Auto {
+			c.JSON(http.StatusOK, gin.H{"candidates": candidates})
+			return
+		}
+		if len(candidates) == 0 || !candidates[0].Available {
+			c.JSON(http.StatusConflict, gin.H{"error": "No skilled technician is available at that time", "candidates": candidates})
+			return
+		}
+		technicianID = &candidates[0].Technician.ID
+	} else {
+		var technician models.User
+		if err := db.DB.Where("id = ? AND role = ? AND is_active = ?", *technicianID, "maintenanceTeam", true).First(&technician).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Technician must be an active maintenance team member"})
+			return
+		}
+	}
+
+	previousStatus := maintenance.Status
+	maintenance.AssignedToID = technicianID
+	if maintenance.Status == "pending" {
+		if err := servicing.Transition(&maintenance, "assigned", time.Now()); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "Invalid maintenance status change", "details": err.Error()})
+			return
+		}
+	}
+
+	userID, _ := c.Get("user_id")
+	changedByID := userID.(uint)
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := servicing.CheckAppointment(tx, &maintenance); err != nil {
+			return err
+		}
+		if err := tx.Save(&maintenance).Error; err != nil {
+			return err
+		}
+		if err := servicing.PublishMaintenance(tx, models.WebhookMaintenanceUpdated, &maintenance); err != nil {
+			return err
+		}
+		if maintenance.Status == previousStatus {
+			return nil
+		}
+		if err := servicing.RecordStatusChange(tx, maintenance.ID, previousStatus, maintenance.Status, &changedByID); err != nil {
+			return err
+		}
+		return servicing.NotifyStatusChange(tx, &maintenance, previousStatus)
+	})
+	if err != nil {
+		if !scheduleConflict(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error dispatching maintenance request", "details": err.Error()})
+		}
+		return
+	}
+
+	servicing.InvalidateCache(context.Background())
+
+	db.DB.Preload("AssignedTo").Preload("Property").First(&maintenance, maintenance.ID)
+	maintenance.WithSLA(time.Now())
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Maintenance dispatched successfully",
+		"maintenance": maintenance,
+	})
+}
+
+// scheduleConflict writes a 409 when err means the technician cannot take
+// the appointment and reports whether it did
+func scheduleConflict(c *gin.Context, err error) bool {
+	var conflict *servicing.ConflictError
+	switch {
+	case errors.As(err, &conflict):
+		ids := make([]uint, 0, len(conflict.Conflicts))
+		for _, m := range conflict.Conflicts {
+			ids = append(ids, m.ID)
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": "Technician is already booked at that time", "details": err.Error(), "conflicting_request_ids": ids})
+	case errors.Is(err, servicing.ErrTimeOff), errors.Is(err, servicing.ErrOutsideWorkingHours):
+		c.JSON(http.StatusConflict, gin.H{"error": "Technician is not available at that time", "details": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
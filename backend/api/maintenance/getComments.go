@@ -0,0 +1,112 @@
+package maintenance
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// GetComments lists the comments on a maintenance request with their files.
+// Tenants only see public comments.
+func GetComments(c *gin.Context) {
+	maintenance, ok := threadMaintenance(c)
+	if !ok {
+		return
+	}
+
+	query := db.DB.Preload("Author").Where("maintenance_id = ? AND deleted_at IS NULL", maintenance.ID)
+	if userRole, _ := c.Get("user_role"); userRole == "tenant" {
+		query = query.Where("visibility = ?", "public")
+	}
+
+	var comments []models.MaintenanceComment
+	if err := query.Order("created_at, id").Find(&comments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching comments", "details": err.Error()})
+		return
+	}
+
+	// Load every comment's files in one query
+	files := map[uint][]models.AttachmentResponse{}
+	if len(comments) > 0 {
+		ids := make([]uint, 0, len(comments))
+		for _, comment := range comments {
+			ids = append(ids, comment.ID)
+		}
+		var attachments []models.Attachment
+		if err := db.DB.Where("entity_type = ? AND entity_id IN ?", "maintenance_comment", ids).
+			Order("created_at").Find(&attachments).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching attachments", "details": err.Error()})
+			return
+		}
+		for _, attachment := range attachments {
+			url, expires := storage.SignedURL(attachment.ID)
+			files[attachment.EntityID] = append(files[attachment.EntityID], attachment.ToResponse(url, expires))
+		}
+	}
+
+	responses := make([]models.MaintenanceCommentResponse, 0, len(comments))
+	for i := range comments {
+		responses = append(responses, comments[i].ToResponse(files[comments[i].ID]))
+	}
+	c.JSON(http.StatusOK, gin.H{"comments": responses})
+}
+
+// threadMaintenance loads the request a comment or history route points at
+// and checks the current user may see it. Admin and maintenance team routes
+// address the request as /maintenance/:id. Landlord and tenant routes nest it
+// under their property or lease as .../:id/maintenance(s)/:requestID, and the
+// request must belong to that property or lease. It writes the error
+// response itself and returns false when the request must stop.
+func threadMaintenance(c *gin.Context) (models.Maintenance, bool) {
+	var maintenance models.Maintenance
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+
+	requestID := c.Param("requestID")
+	if requestID == "" {
+		requestID = c.Param("id")
+	}
+	id, err := strconv.ParseUint(requestID, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid maintenance ID"})
+		return maintenance, false
+	}
+	if err := db.DB.First(&maintenance, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		return maintenance, false
+	}
+
+	switch userRole {
+	case "admin", "maintenanceTeam":
+		return maintenance, true
+	case "landlord":
+		var property models.Property
+		if err := db.DB.Where("id = ? AND owner_id = ?", c.Param("id"), userID).First(&property).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Property not found or you do not own this property"})
+			return maintenance, false
+		}
+		if maintenance.PropertyID == property.ID {
+			return maintenance, true
+		}
+	case "tenant":
+		var lease models.Lease
+		if err := db.DB.Where("id = ? AND tenant_id = ?", c.Param("id"), userID).First(&lease).Error; err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Lease not found or access denied"})
+			return maintenance, false
+		}
+		onLease := maintenance.LeaseID != nil && *maintenance.LeaseID == lease.ID
+		if maintenance.PropertyID == lease.PropertyID && (onLease || maintenance.RequestedByID == userID) {
+			return maintenance, true
+		}
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "Unauthorized"})
+		return maintenance, false
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+	return maintenance, false
+}
@@ -22,6 +22,7 @@ func GetMaintenance(c *gin.Context) {
 	if err == nil {
 		var maintenance models.Maintenance
 		if json.Unmarshal([]byte(cachedData), &maintenance) == nil {
+			maintenance.WithSLA(time.Now())
 			c.JSON(http.StatusOK, gin.H{"maintenance": maintenance, "cache": "hit"})
 			return
 		}
@@ -39,5 +40,6 @@ func GetMaintenance(c *gin.Context) {
 	jsonData, _ := json.Marshal(maintenance)
 	db.RedisClient.Set(ctx, cacheKey, jsonData, 10*time.Minute)
 
+	maintenance.WithSLA(time.Now())
 	c.JSON(http.StatusOK, gin.H{"maintenance": maintenance, "cache": "miss"})
 }
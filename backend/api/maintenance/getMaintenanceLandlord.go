@@ -31,6 +31,7 @@ func GetLandlordMaintenances(c *gin.Context) {
 	if err == nil {
 		var maintenances []models.Maintenance
 		if json.Unmarshal([]byte(cachedData), &maintenances) == nil {
+			withSLA(maintenances)
 			c.JSON(http.StatusOK, gin.H{"maintenances": maintenances, "cache": "hit"})
 			return
 		}
@@ -55,5 +56,6 @@ func GetLandlordMaintenances(c *gin.Context) {
 	jsonData, _ := json.Marshal(maintenances)
 	db.RedisClient.Set(ctx, cacheKey, jsonData, 10*time.Minute)
 
+	withSLA(maintenances)
 	c.JSON(http.StatusOK, gin.H{"maintenances": maintenances, "cache": "miss"})
 }
@@ -0,0 +1,31 @@
+package maintenance
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetStatusHistory lists every status a maintenance request has moved
+// through, oldest first
+func GetStatusHistory(c *gin.Context) {
+	maintenance, ok := threadMaintenance(c)
+	if !ok {
+		return
+	}
+
+	var changes []models.MaintenanceStatusChange
+	if err := db.DB.Preload("ChangedBy").Where("maintenance_id = ?", maintenance.ID).
+		Order("created_at, id").Find(&changes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching status history", "details": err.Error()})
+		return
+	}
+
+	responses := make([]models.MaintenanceStatusChangeResponse, 0, len(changes))
+	for i := range changes {
+		responses = append(responses, changes[i].ToResponse())
+	}
+	c.JSON(http.StatusOK, gin.H{"history": responses})
+}
@@ -40,6 +40,7 @@ func GetMaintenances(c *gin.Context) {
 	cachedData, err := db.RedisClient.Get(ctx, cacheKey).Result()
 	if err == nil {
 		if json.Unmarshal([]byte(cachedData), &maintenances) == nil {
+			withSLA(maintenances)
 			c.JSON(http.StatusOK, gin.H{"maintenances": maintenances, "cache": "hit"})
 			return
 		}
@@ -83,5 +84,15 @@ func GetMaintenances(c *gin.Context) {
 	jsonData, _ := json.Marshal(maintenances)
 	db.RedisClient.Set(ctx, cacheKey, jsonData, 10*time.Minute)
 
+	withSLA(maintenances)
 	c.JSON(http.StatusOK, gin.H{"maintenances": maintenances, "cache": "miss"})
 }
+
+// withSLA fills in the SLA breach flags, which change with time and so are
+// computed per response rather than cached
+func withSLA(maintenances []models.Maintenance) {
+	now := time.Now()
+	for i := range maintenances {
+		maintenances[i].WithSLA(now)
+	}
+}
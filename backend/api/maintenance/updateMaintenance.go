@@ -2,12 +2,17 @@ package maintenance
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/geoo115/property-manager/billing"
 	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // UpdateMaintenance updates a maintenance request and invalidates Redis caches.
@@ -15,9 +20,15 @@ func UpdateMaintenance(c *gin.Context) {
 	id := c.Param("id")
 
 	var input struct {
-		Description *string `json:"description"`
-		PropertyID  *uint   `json:"property_id"`
-		Status      *string `json:"status"`
+		Description    *string    `json:"description"`
+		PropertyID     *uint      `json:"property_id"`
+		Status         *string    `json:"status"`
+		AssignedToID   *uint      `json:"assigned_to_id"`
+		ScheduledAt    *time.Time `json:"scheduled_at"`
+		ScheduledEndAt *time.Time `json:"scheduled_end_at"`
+		ActualCost     *float64   `json:"actual_cost"`
+		RechargeTenant *bool      `json:"recharge_tenant"`
+		RechargeAmount *float64   `json:"recharge_amount"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -25,6 +36,18 @@ func UpdateMaintenance(c *gin.Context) {
 		return
 	}
 
+	if (input.ActualCost != nil && *input.ActualCost < 0) || (input.RechargeAmount != nil && *input.RechargeAmount < 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Costs cannot be negative"})
+		return
+	}
+
+	// Charging a tenant is a billing decision, not a technician's
+	userRole, _ := c.Get("user_role")
+	if (input.RechargeTenant != nil || input.RechargeAmount != nil) && userRole != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only admins can recharge tenants"})
+		return
+	}
+
 	var maintenance models.Maintenance
 	if err := db.DB.First(&maintenance, id).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
@@ -38,14 +61,112 @@ func UpdateMaintenance(c *gin.Context) {
 	if input.PropertyID != nil {
 		maintenance.PropertyID = *input.PropertyID
 	}
-	if input.Status != nil {
-		maintenance.Status = *input.Status
+	if input.AssignedToID != nil {
+		var assignee models.User
+		if err := db.DB.Where("id = ? AND role = ?", *input.AssignedToID, "maintenanceTeam").First(&assignee).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Assignee must be a maintenance team member"})
+			return
+		}
+		maintenance.AssignedToID = input.AssignedToID
+
+		// Assigning a pending request moves it along the workflow
+		if input.Status == nil && maintenance.Status == "pending" {
+			assigned := "assigned"
+			input.Status = &assigned
+		}
+	}
+
+	if input.ScheduledAt != nil {
+		if input.ScheduledEndAt != nil && !input.ScheduledEndAt.After(*input.ScheduledAt) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "scheduled_end_at must be after scheduled_at"})
+			return
+		}
+		servicing.Schedule(&maintenance, *input.ScheduledAt, input.ScheduledEndAt)
+	}
+	rebooked := input.AssignedToID != nil || input.ScheduledAt != nil
+
+	if input.ActualCost != nil {
+		maintenance.ActualCost = *input.ActualCost
+	}
+	if input.RechargeTenant != nil {
+		maintenance.RechargeTenant = *input.RechargeTenant
+	}
+	if input.RechargeAmount != nil {
+		maintenance.RechargeAmount = *input.RechargeAmount
+	}
+	costed := input.ActualCost != nil || input.RechargeTenant != nil || input.RechargeAmount != nil
+
+	// Status changes go through the workflow so SLA clocks stay accurate
+	previousStatus := maintenance.Status
+	if input.Status != nil && *input.Status != maintenance.Status {
+		if err := servicing.Transition(&maintenance, *input.Status, time.Now()); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, servicing.ErrInvalidTransition) {
+				status = http.StatusConflict
+			} else if errors.Is(err, servicing.ErrAssigneeRequired) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, gin.H{"error": "Invalid maintenance status change", "details": err.Error()})
+			return
+		}
 	}
 
-	if err := db.DB.Save(&maintenance).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating maintenance request"})
+	userID, _ := c.Get("user_id")
+	changedByID := userID.(uint)
+	var posting *servicing.CostPosting
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if rebooked {
+			if err := servicing.CheckAppointment(tx, &maintenance); err != nil {
+				return err
+			}
+		}
+		if err := tx.Save(&maintenance).Error; err != nil {
+			return err
+		}
+		// Completing a request, or correcting a completed one's cost, posts it to
+		// accounting; reopening a completed one takes the posting back
+		if previousStatus == "completed" && maintenance.Status != "completed" {
+			var err error
+			if posting, err = servicing.ReverseCosts(tx, &maintenance); err != nil {
+				return err
+			}
+		} else if maintenance.Status != previousStatus || costed {
+			var err error
+			if posting, err = servicing.PostCosts(tx, &maintenance, changedByID, time.Now()); err != nil {
+				return err
+			}
+		}
+		if err := servicing.PublishMaintenance(tx, models.WebhookMaintenanceUpdated, &maintenance); err != nil {
+			return err
+		}
+		if maintenance.Status == previousStatus {
+			return nil
+		}
+		if err := servicing.RecordStatusChange(tx, maintenance.ID, previousStatus, maintenance.Status, &changedByID); err != nil {
+			return err
+		}
+		return servicing.NotifyStatusChange(tx, &maintenance, previousStatus)
+	})
+	if err != nil {
+		switch {
+		case scheduleConflict(c, err):
+		case errors.Is(err, servicing.ErrNoTenantToRecharge):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot recharge tenant", "details": err.Error()})
+		case errors.Is(err, servicing.ErrRechargePaid):
+			c.JSON(http.StatusConflict, gin.H{"error": "Cannot change recharge", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating maintenance request"})
+		}
 		return
 	}
+	if posting != nil {
+		if posting.Expense != nil {
+			billing.InvalidateExpenseCaches(db.DB, *posting.Expense)
+		}
+		if posting.Invoice != nil {
+			billing.InvalidateInvoiceCaches(db.DB, *posting.Invoice)
+		}
+	}
 
 	// Reload with associations
 	if err := db.DB.Preload("RequestedBy").Preload("Property.Owner").First(&maintenance, maintenance.ID).Error; err != nil {
@@ -67,6 +188,7 @@ func UpdateMaintenance(c *gin.Context) {
 		}
 	}
 
+	maintenance.WithSLA(time.Now())
 	c.JSON(http.StatusOK, gin.H{
 		"message":     "Maintenance updated successfully",
 		"maintenance": maintenance,
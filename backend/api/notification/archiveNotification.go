@@ -0,0 +1,32 @@
+package notification
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// ArchiveNotification moves a notification out of the signed-in user's inbox.
+// Archiving an unread notification also marks it read.
+func ArchiveNotification(c *gin.Context) {
+	n, ok := loadInboxNotification(c)
+	if !ok {
+		return
+	}
+
+	if n.ArchivedAt == nil {
+		now := time.Now()
+		n.ArchivedAt = &now
+		if n.ReadAt == nil {
+			n.ReadAt = &now
+		}
+		if err := db.DB.WithContext(c).Model(&n).Select("read_at", "archived_at").Updates(&n).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error archiving notification", "details": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification archived", "notification": n})
+}
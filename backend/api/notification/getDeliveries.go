@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// GetDeliveries is the notification delivery log, newest first. Filters:
+// status, channel, event_type and user_id. Paginated with page and page_size.
+func GetDeliveries(c *gin.Context) {
+	page, pageSize := 1, defaultPageSize
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if s, err := strconv.Atoi(c.Query("page_size")); err == nil && s > 0 {
+		pageSize = s
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	query := db.DB.Model(&models.Notification{})
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if channel := c.Query("channel"); channel != "" {
+		query = query.Where("channel = ?", channel)
+	}
+	if eventType := c.Query("event_type"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+	if userID := c.Query("user_id"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			return
+		}
+		query = query.Where("user_id = ?", id)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting notifications"})
+		return
+	}
+
+	var deliveries []models.Notification
+	if err := query.Order("created_at DESC, id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&deliveries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching notifications"})
+		return
+	}
+
+	response.Paginated(c, deliveries, response.CalculatePagination(page, pageSize, int(total)), "Notifications retrieved successfully")
+}
@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetInbox lists the signed-in user's in-app notifications, newest first.
+// Archived notifications are left out unless archived=true; unread=true
+// keeps only those not yet read. Paginated with page and page_size.
+func GetInbox(c *gin.Context) {
+	page, pageSize := 1, defaultPageSize
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if s, err := strconv.Atoi(c.Query("page_size")); err == nil && s > 0 {
+		pageSize = s
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	userID, _ := c.Get("user_id")
+	query := inbox(userID.(uint))
+	if c.Query("archived") == "true" {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+	if c.Query("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+	if eventType := c.Query("event_type"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting notifications"})
+		return
+	}
+
+	var items []models.Notification
+	if err := query.Order("created_at DESC, id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&items).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching notifications"})
+		return
+	}
+
+	response.Paginated(c, items, response.CalculatePagination(page, pageSize, int(total)), "Notifications retrieved successfully")
+}
+
+// inbox scopes a query to a user's in-app notifications
+func inbox(userID uint) *gorm.DB {
+	return db.DB.Model(&models.Notification{}).Where("user_id = ? AND channel = ?", userID, models.ChannelInApp)
+}
+
+// countUnread counts the notifications in a user's inbox they have not read
+func countUnread(userID uint) (int64, error) {
+	var unread int64
+	err := inbox(userID).Where("read_at IS NULL AND archived_at IS NULL").Count(&unread).Error
+	return unread, err
+}
+
+// loadInboxNotification loads a notification from the signed-in user's inbox,
+// writing a 404 if it is not theirs
+func loadInboxNotification(c *gin.Context) (models.Notification, bool) {
+	userID, _ := c.Get("user_id")
+
+	var n models.Notification
+	if err := inbox(userID.(uint)).First(&n, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return n, false
+	}
+	return n, true
+}
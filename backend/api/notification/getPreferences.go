@@ -0,0 +1,48 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/notifications"
+	"github.com/gin-gonic/gin"
+)
+
+// preference is one event and channel as the user currently receives it
+type preference struct {
+	EventType string `json:"event_type"`
+	Channel   string `json:"channel"`
+	Enabled   bool   `json:"enabled"`
+	Target    string `json:"target,omitempty"`
+}
+
+// GetPreferences lists every event and channel with whether the signed-in
+// user receives it, falling back to the channel defaults where they have not
+// chosen
+func GetPreferences(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	prefs, err := loadPreferences(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching notification preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+func loadPreferences(userID uint) ([]preference, error) {
+	var stored []models.NotificationPreference
+	if err := db.DB.Where("user_id = ?", userID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+
+	prefs := make([]preference, 0, len(models.NotificationEvents)*len(models.NotificationChannels))
+	for _, event := range models.NotificationEvents {
+		for _, channel := range models.NotificationChannels {
+			enabled, target := notifications.Wants(stored, event, channel)
+			prefs = append(prefs, preference{EventType: event, Channel: channel, Enabled: enabled, Target: target})
+		}
+	}
+	return prefs, nil
+}
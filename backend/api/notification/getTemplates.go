@@ -0,0 +1,25 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTemplates lists the notification templates, optionally for one
+// event_type
+func GetTemplates(c *gin.Context) {
+	query := db.DB.Model(&models.NotificationTemplate{})
+	if eventType := c.Query("event_type"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	var templates []models.NotificationTemplate
+	if err := query.Order("event_type, channel").Find(&templates).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching notification templates"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
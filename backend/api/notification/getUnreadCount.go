@@ -0,0 +1,20 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnreadCount returns how many notifications in the signed-in user's inbox
+// are unread
+func GetUnreadCount(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	unread, err := countUnread(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting notifications"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"unread_count": unread})
+}
@@ -0,0 +1,22 @@
+package notification
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MarkAllRead marks every unread notification in the signed-in user's inbox
+// as read
+func MarkAllRead(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	result := inbox(userID.(uint)).WithContext(c).Where("read_at IS NULL").Update("read_at", time.Now())
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating notifications", "details": result.Error.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notifications marked as read", "updated": result.RowsAffected})
+}
@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// MarkRead marks a notification in the signed-in user's inbox as read
+func MarkRead(c *gin.Context) {
+	n, ok := loadInboxNotification(c)
+	if !ok {
+		return
+	}
+
+	if n.ReadAt == nil {
+		now := time.Now()
+		n.ReadAt = &now
+		if err := db.DB.WithContext(c).Model(&n).Update("read_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating notification", "details": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read", "notification": n})
+}
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// RetryDelivery queues a failed notification again with a fresh set of
+// attempts
+func RetryDelivery(c *gin.Context) {
+	var n models.Notification
+	if err := db.DB.First(&n, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+	if n.Status != "failed" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only failed notifications can be retried"})
+		return
+	}
+
+	n.Status = "queued"
+	n.Attempts = 0
+	n.NextAttemptAt = time.Now()
+	if err := db.DB.WithContext(c).Model(&n).Select("status", "attempts", "next_attempt_at").Updates(&n).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrying notification", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification queued for delivery", "notification": n})
+}
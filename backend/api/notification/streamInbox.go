@@ -0,0 +1,95 @@
+package notification
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/notifications"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// heartbeatInterval keeps idle streams open through proxies
+	heartbeatInterval = 25 * time.Second
+	// maxReplay caps how many missed notifications a reconnecting stream is sent
+	maxReplay = 100
+)
+
+// StreamInbox pushes the signed-in user's new in-app notifications as
+// Server-Sent Events. The stream opens with an "unread_count" event; each
+// "notification" event carries the notification and the new unread count.
+// A client reconnecting with Last-Event-ID first gets what it missed.
+func StreamInbox(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	uid := userID.(uint)
+
+	updates, unsubscribe := notifications.Subscribe(uid)
+	defer unsubscribe()
+
+	// The server's write timeout is for ordinary requests, not streams
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logger.LogDebug("Could not clear write deadline for notification stream", logrus.Fields{"error": err.Error()})
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	unread, err := countUnread(uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting notifications"})
+		return
+	}
+	c.Render(-1, sse.Event{Event: "unread_count", Data: gin.H{"unread_count": unread}})
+
+	if lastID, err := strconv.ParseUint(c.GetHeader("Last-Event-ID"), 10, 64); err == nil {
+		var missed []models.Notification
+		if err := inbox(uid).Where("id > ?", lastID).Order("id").Limit(maxReplay).Find(&missed).Error; err != nil {
+			logger.LogWarning("Failed to replay missed notifications", logrus.Fields{
+				"user_id": uid,
+				"error":   err.Error(),
+			})
+		}
+		for i := range missed {
+			pushNotification(c, &missed[i], uid)
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case n, ok := <-updates:
+			if !ok {
+				return
+			}
+			pushNotification(c, n, uid)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			c.Writer.WriteString(": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func pushNotification(c *gin.Context, n *models.Notification, userID uint) {
+	unread, err := countUnread(userID)
+	if err != nil {
+		logger.LogWarning("Failed to count unread notifications", logrus.Fields{
+			"user_id": userID,
+			"error":   err.Error(),
+		})
+	}
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(uint64(n.ID), 10),
+		Event: "notification",
+		Data:  gin.H{"notification": n, "unread_count": unread},
+	})
+}
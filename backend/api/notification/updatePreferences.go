@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm/clause"
+)
+
+// UpdatePreferences sets the signed-in user's choice for the events and
+// channels given; the rest are left as they are
+func UpdatePreferences(c *gin.Context) {
+	var input models.NotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification preferences", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification preferences", "details": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	if len(input.Preferences) > 0 {
+		rows := make([]models.NotificationPreference, 0, len(input.Preferences))
+		for _, pref := range input.Preferences {
+			rows = append(rows, models.NotificationPreference{
+				UserID:    userID.(uint),
+				EventType: pref.EventType,
+				Channel:   pref.Channel,
+				Enabled:   pref.Enabled,
+				Target:    pref.Target,
+			})
+		}
+		err := db.DB.WithContext(c).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}, {Name: "channel"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "target", "updated_at"}),
+		}).Create(&rows).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating notification preferences", "details": err.Error()})
+			return
+		}
+	}
+
+	prefs, err := loadPreferences(userID.(uint))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching notification preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notification preferences updated successfully", "preferences": prefs})
+}
@@ -0,0 +1,42 @@
+package notification
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateTemplate changes the subject or body of a notification template.
+// Notifications already queued keep the text they were rendered with.
+func UpdateTemplate(c *gin.Context) {
+	var tmpl models.NotificationTemplate
+	if err := db.DB.First(&tmpl, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification template not found"})
+		return
+	}
+
+	var input models.NotificationTemplateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification template", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification template", "details": err.Error()})
+		return
+	}
+
+	if input.Subject != nil {
+		tmpl.Subject = *input.Subject
+	}
+	if input.Body != nil {
+		tmpl.Body = *input.Body
+	}
+	if err := db.DB.WithContext(c).Save(&tmpl).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating notification template", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification template updated successfully", "template": tmpl})
+}
@@ -53,7 +53,7 @@ func CreateProperty(c *gin.Context) {
 	}
 
 	// Insert into the database
-	if err := db.DB.Create(&property).Error; err != nil {
+	if err := db.DB.WithContext(c).Create(&property).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating property"})
 		return
 	}
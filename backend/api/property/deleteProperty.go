@@ -19,7 +19,7 @@ func DeleteProperty(c *gin.Context) {
 	}
 
 	// Delete the property
-	if err := db.DB.Delete(&property).Error; err != nil {
+	if err := db.DB.WithContext(c).Delete(&property).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting property"})
 		return
 	}
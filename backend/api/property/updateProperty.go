@@ -54,7 +54,7 @@ func UpdateProperty(c *gin.Context) {
 	property.Available = input.Available
 
 	// Save updated property to the database
-	if err := db.DB.Save(&property).Error; err != nil {
+	if err := db.DB.WithContext(c).Save(&property).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating property"})
 		return
 	}
@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTimeOff books time off for a technician. Appointments already booked
+// in that period are returned so they can be rescheduled.
+func CreateTimeOff(c *gin.Context) {
+	technician, ok := technicianFromPath(c)
+	if !ok {
+		return
+	}
+
+	var input models.TimeOffRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time off data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid time off data", "details": err.Error()})
+		return
+	}
+
+	timeOff := models.TimeOff{
+		UserID:   technician.ID,
+		StartsAt: input.StartsAt,
+		EndsAt:   input.EndsAt,
+		Reason:   input.Reason,
+	}
+	if err := db.DB.WithContext(c).Create(&timeOff).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating time off", "details": err.Error()})
+		return
+	}
+
+	cal, err := servicing.LoadCalendar(db.DB, technician.ID, timeOff.StartsAt, timeOff.EndsAt, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error checking appointments", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":                  "Time off created successfully",
+		"time_off":                 timeOff,
+		"conflicting_appointments": cal.Appointments,
+	})
+}
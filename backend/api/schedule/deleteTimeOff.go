@@ -0,0 +1,29 @@
+package schedule
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteTimeOff cancels a technician's time off
+func DeleteTimeOff(c *gin.Context) {
+	technician, ok := technicianFromPath(c)
+	if !ok {
+		return
+	}
+
+	var timeOff models.TimeOff
+	if err := db.DB.Where("id = ? AND user_id = ?", c.Param("timeOffID"), technician.ID).First(&timeOff).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Time off not found"})
+		return
+	}
+	if err := db.DB.WithContext(c).Delete(&timeOff).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting time off", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time off deleted successfully"})
+}
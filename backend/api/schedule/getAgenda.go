@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/gin-gonic/gin"
+)
+
+// agendaDays is how far ahead the agenda looks when no range is given
+const agendaDays = 7
+
+// GetAgenda returns a technician's skills, weekly hours, time off and
+// booked appointments between from and to (RFC 3339 or YYYY-MM-DD),
+// defaulting to the next week
+func GetAgenda(c *gin.Context) {
+	technician, ok := technicianFromPath(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	from, err := parseTime(c.Query("from"), time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date", "details": err.Error()})
+		return
+	}
+	to, err := parseTime(c.Query("to"), from.AddDate(0, 0, agendaDays))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date", "details": err.Error()})
+		return
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	cal, err := servicing.LoadCalendar(db.DB, technician.ID, from, to, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching schedule", "details": err.Error()})
+		return
+	}
+	var skills []string
+	if err := db.DB.Model(&models.TechnicianSkill{}).Where("user_id = ?", technician.ID).
+		Order("category").Pluck("category", &skills).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching skills", "details": err.Error()})
+		return
+	}
+
+	for i := range cal.Appointments {
+		cal.Appointments[i].WithSLA(now)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"technician":    technician.ToParticipant(),
+		"from":          from,
+		"to":            to,
+		"skills":        skills,
+		"working_hours": cal.Hours,
+		"time_off":      cal.TimeOff,
+		"appointments":  cal.Appointments,
+	})
+}
+
+// technicianFromPath loads the technician a schedule route is for: the
+// ":id" path parameter on admin routes, otherwise the signed-in maintenance
+// team member. It writes the error response itself and returns false when
+// the request must stop.
+func technicianFromPath(c *gin.Context) (models.User, bool) {
+	var technician models.User
+	var id interface{} = c.Param("id")
+	if id == "" {
+		id, _ = c.Get("user_id")
+	}
+	if err := db.DB.Where("id = ? AND role = ?", id, "maintenanceTeam").First(&technician).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Technician not found"})
+		return technician, false
+	}
+	return technician, true
+}
+
+// parseTime parses an RFC 3339 timestamp or a YYYY-MM-DD date, returning
+// fallback for an empty value
+func parseTime(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
@@ -0,0 +1,57 @@
+package schedule
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UpdateSkills replaces the maintenance categories a technician can be
+// dispatched to
+func UpdateSkills(c *gin.Context) {
+	technician, ok := technicianFromPath(c)
+	if !ok {
+		return
+	}
+
+	var input models.SkillsRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skills", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid skills", "details": err.Error()})
+		return
+	}
+
+	seen := map[string]bool{}
+	skills := []models.TechnicianSkill{}
+	categories := []string{}
+	for _, category := range input.Categories {
+		if seen[category] {
+			continue
+		}
+		seen[category] = true
+		skills = append(skills, models.TechnicianSkill{UserID: technician.ID, Category: category})
+		categories = append(categories, category)
+	}
+
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", technician.ID).Delete(&models.TechnicianSkill{}).Error; err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return nil
+		}
+		return tx.Create(&skills).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating skills", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Skills updated successfully", "skills": categories})
+}
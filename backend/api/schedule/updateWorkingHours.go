@@ -0,0 +1,55 @@
+package schedule
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UpdateWorkingHours replaces a technician's weekly hours. An empty list
+// clears them, leaving the technician bookable at any time.
+func UpdateWorkingHours(c *gin.Context) {
+	technician, ok := technicianFromPath(c)
+	if !ok {
+		return
+	}
+
+	var input models.WorkingHoursRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid working hours", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid working hours", "details": err.Error()})
+		return
+	}
+
+	hours := make([]models.WorkingHours, 0, len(input.Hours))
+	for _, shift := range input.Hours {
+		hours = append(hours, models.WorkingHours{
+			UserID:    technician.ID,
+			Weekday:   shift.Weekday,
+			StartTime: shift.StartTime,
+			EndTime:   shift.EndTime,
+		})
+	}
+
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", technician.ID).Delete(&models.WorkingHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating working hours", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Working hours updated successfully", "working_hours": hours})
+}
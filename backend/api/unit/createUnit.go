@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateUnit adds a unit to a multi-unit property.
+func CreateUnit(c *gin.Context) {
+	property, ok := loadAccessibleProperty(c)
+	if !ok {
+		return
+	}
+
+	var input models.UnitCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit data", "details": err})
+		return
+	}
+
+	// Unit names must be unique within a property
+	var existing int64
+	db.DB.Model(&models.Unit{}).
+		Where("property_id = ? AND name = ?", property.ID, input.Name).
+		Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "A unit with this name already exists for the property"})
+		return
+	}
+
+	unit := models.Unit{
+		PropertyID:  property.ID,
+		Name:        input.Name,
+		Description: input.Description,
+		Price:       input.Price,
+		Available:   true,
+	}
+	if input.Available != nil {
+		unit.Available = *input.Available
+	}
+
+	if err := db.DB.Create(&unit).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating unit"})
+		return
+	}
+
+	// Properties are cached with their units preloaded
+	db.RedisClient.FlushDB(context.Background())
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Unit created successfully",
+		"unit":    unit,
+	})
+}
@@ -0,0 +1,40 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteUnit removes a unit that has no active or pending lease.
+func DeleteUnit(c *gin.Context) {
+	property, ok := loadAccessibleProperty(c)
+	if !ok {
+		return
+	}
+
+	var unit models.Unit
+	if err := db.DB.Where("property_id = ?", property.ID).First(&unit, c.Param("unitID")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unit not found"})
+		return
+	}
+
+	var activeLeases int64
+	db.DB.Model(&models.Lease{}).
+		Where("unit_id = ? AND status IN ?", unit.ID, []string{"active", "pending"}).
+		Count(&activeLeases)
+	if activeLeases > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Unit has an active or pending lease"})
+		return
+	}
+
+	if err := db.DB.Delete(&unit).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting unit"})
+		return
+	}
+	db.RedisClient.FlushDB(context.Background())
+	c.JSON(http.StatusOK, gin.H{"message": "Unit deleted successfully"})
+}
@@ -0,0 +1,42 @@
+package unit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnitByID fetches a single unit of a property.
+func GetUnitByID(c *gin.Context) {
+	property, ok := loadAccessibleProperty(c)
+	if !ok {
+		return
+	}
+
+	var unit models.Unit
+	if err := db.DB.Where("property_id = ?", property.ID).
+		Preload("Tenant").
+		First(&unit, c.Param("unitID")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unit not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unit": unit})
+}
+
+// ValidateForProperty checks that an optional unit reference points at a unit
+// of the given property. A nil unitID is valid and means the whole property.
+func ValidateForProperty(unitID *uint, propertyID uint) (*models.Unit, error) {
+	if unitID == nil {
+		return nil, nil
+	}
+
+	var unit models.Unit
+	if err := db.DB.Where("id = ? AND property_id = ?", *unitID, propertyID).First(&unit).Error; err != nil {
+		return nil, fmt.Errorf("unit %d does not belong to property %d", *unitID, propertyID)
+	}
+	return &unit, nil
+}
@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetUnits fetches all units of a property with Redis caching.
+func GetUnits(c *gin.Context) {
+	property, ok := loadAccessibleProperty(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	cacheKey := fmt.Sprintf("units:property:%d", property.ID)
+
+	// Try to get cached data from Redis
+	cachedData, err := db.RedisClient.Get(ctx, cacheKey).Result()
+	if err == nil {
+		var units []models.Unit
+		if json.Unmarshal([]byte(cachedData), &units) == nil {
+			c.JSON(http.StatusOK, gin.H{"units": units, "cache": "hit"})
+			return
+		}
+	}
+
+	var units []models.Unit
+	if err := db.DB.Where("property_id = ?", property.ID).
+		Preload("Tenant").
+		Order("name").
+		Find(&units).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching units"})
+		return
+	}
+
+	// Store in Redis
+	jsonData, _ := json.Marshal(units)
+	db.RedisClient.Set(ctx, cacheKey, jsonData, 10*time.Minute)
+
+	c.JSON(http.StatusOK, gin.H{"units": units, "cache": "miss"})
+}
+
+// loadAccessibleProperty loads the property from the ":id" path parameter and
+// makes sure landlords only reach their own properties. It writes the error
+// response itself and returns false when the request must stop.
+func loadAccessibleProperty(c *gin.Context) (models.Property, bool) {
+	var property models.Property
+	if err := db.DB.First(&property, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Property not found"})
+		return property, false
+	}
+
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+	if userRole == "landlord" && property.OwnerID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You do not own this property"})
+		return property, false
+	}
+
+	return property, true
+}
@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateUnit updates the provided fields of a unit.
+func UpdateUnit(c *gin.Context) {
+	property, ok := loadAccessibleProperty(c)
+	if !ok {
+		return
+	}
+
+	var input models.UnitUpdateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid unit data", "details": err})
+		return
+	}
+
+	var unit models.Unit
+	if err := db.DB.Where("property_id = ?", property.ID).First(&unit, c.Param("unitID")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unit not found"})
+		return
+	}
+
+	// Update only provided fields
+	if input.Name != nil && *input.Name != unit.Name {
+		var existing int64
+		db.DB.Model(&models.Unit{}).
+			Where("property_id = ? AND name = ? AND id <> ?", property.ID, *input.Name, unit.ID).
+			Count(&existing)
+		if existing > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "A unit with this name already exists for the property"})
+			return
+		}
+		unit.Name = *input.Name
+	}
+	if input.Description != nil {
+		unit.Description = *input.Description
+	}
+	if input.Price != nil {
+		unit.Price = *input.Price
+	}
+	if input.TenantID != nil {
+		if *input.TenantID == 0 {
+			unit.TenantID = nil
+			unit.Available = true
+		} else {
+			var tenant models.User
+			if err := db.DB.First(&tenant, *input.TenantID).Error; err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Tenant does not exist"})
+				return
+			}
+			unit.TenantID = input.TenantID
+			unit.Available = false
+		}
+	}
+	if input.Available != nil {
+		unit.Available = *input.Available
+	}
+
+	if err := db.DB.Save(&unit).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating unit"})
+		return
+	}
+
+	if err := db.DB.Preload("Tenant").First(&unit, unit.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching updated unit"})
+		return
+	}
+	db.RedisClient.FlushDB(context.Background())
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Unit updated successfully",
+		"unit":    unit,
+	})
+}
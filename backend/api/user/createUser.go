@@ -46,7 +46,7 @@ func CreateUser(c *gin.Context) {
 	}
 
 	// Insert into database
-	if err := db.DB.Create(&user).Error; err != nil {
+	if err := db.DB.WithContext(c).Create(&user).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating user"})
 		return
 	}
@@ -18,7 +18,7 @@ func DeleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := db.DB.Delete(&models.User{}, id).Error; err != nil {
+	if err := db.DB.WithContext(c).Delete(&models.User{}, id).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error deleting user"})
 	}
 	db.RedisClient.FlushDB(context.Background())
@@ -34,7 +34,7 @@ func UpdateUser(c *gin.Context) {
 		updatePayload.Password = hashedPassword
 	}
 
-	if err := db.DB.Model(&user).Updates(updatePayload).Error; err != nil {
+	if err := db.DB.WithContext(c).Model(&user).Updates(updatePayload).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "error updating user"})
 		return
 	}
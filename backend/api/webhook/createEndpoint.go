@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/webhooks"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateEndpoint registers a webhook endpoint for the signed-in user. The
+// signing secret is only returned here and when it is rotated.
+func CreateEndpoint(c *gin.Context) {
+	var input models.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook endpoint data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook endpoint data", "details": err.Error()})
+		return
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating webhook endpoint"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	endpoint := models.WebhookEndpoint{
+		OwnerID:     userID.(uint),
+		URL:         input.URL,
+		Description: input.Description,
+		Secret:      secret,
+		EventTypes:  input.EventTypes,
+		IsActive:    true,
+	}
+	if err := db.DB.WithContext(c).Create(&endpoint).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating webhook endpoint", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Webhook endpoint created successfully",
+		"endpoint": endpoint,
+		"secret":   secret,
+	})
+}
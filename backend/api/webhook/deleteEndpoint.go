@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteEndpoint removes a webhook endpoint along with its delivery log
+func DeleteEndpoint(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+
+	if err := db.DB.WithContext(c).Delete(&endpoint).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting webhook endpoint", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook endpoint deleted successfully"})
+}
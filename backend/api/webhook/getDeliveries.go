@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// GetDeliveries is an endpoint's delivery log, newest first. Filters: status
+// and event_type. Paginated with page and page_size.
+func GetDeliveries(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize := 1, defaultPageSize
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		page = p
+	}
+	if s, err := strconv.Atoi(c.Query("page_size")); err == nil && s > 0 {
+		pageSize = s
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	query := db.DB.Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", endpoint.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if eventType := c.Query("event_type"); eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error counting webhook deliveries"})
+		return
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Order("created_at DESC, id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).
+		Find(&deliveries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching webhook deliveries"})
+		return
+	}
+
+	response.Paginated(c, deliveries, response.CalculatePagination(page, pageSize, int(total)), "Webhook deliveries retrieved successfully")
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetEndpoint returns one webhook endpoint
+func GetEndpoint(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"endpoint": endpoint})
+}
+
+// endpoints scopes a query to the webhook endpoints the signed-in user may
+// manage: every endpoint for admins, their own for landlords
+func endpoints(c *gin.Context) *gorm.DB {
+	query := db.DB.Model(&models.WebhookEndpoint{})
+	if role, _ := c.Get("user_role"); role != "admin" {
+		userID, _ := c.Get("user_id")
+		query = query.Where("owner_id = ?", userID)
+	}
+	return query
+}
+
+// loadEndpoint loads the webhook endpoint in the id parameter, writing a 404
+// if the signed-in user may not manage it
+func loadEndpoint(c *gin.Context) (models.WebhookEndpoint, bool) {
+	var endpoint models.WebhookEndpoint
+	if err := endpoints(c).First(&endpoint, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+		return endpoint, false
+	}
+	return endpoint, true
+}
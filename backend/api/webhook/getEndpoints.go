@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// GetEndpoints lists the webhook endpoints the signed-in user manages
+func GetEndpoints(c *gin.Context) {
+	var list []models.WebhookEndpoint
+	if err := endpoints(c).Order("id").Find(&list).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching webhook endpoints"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"endpoints": list})
+}
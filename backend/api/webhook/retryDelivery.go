@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// RetryDelivery queues a failed delivery again with a fresh set of attempts
+func RetryDelivery(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+
+	var delivery models.WebhookDelivery
+	if err := db.DB.Where("endpoint_id = ?", endpoint.ID).First(&delivery, c.Param("deliveryID")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook delivery not found"})
+		return
+	}
+	if delivery.Status != "failed" || delivery.EventType == models.WebhookTest {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only failed event deliveries can be retried"})
+		return
+	}
+
+	delivery.Status = "queued"
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := db.DB.WithContext(c).Model(&delivery).Select("status", "attempts", "next_attempt_at").Updates(&delivery).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error retrying webhook delivery", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook delivery queued", "delivery": delivery})
+}
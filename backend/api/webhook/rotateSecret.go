@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/webhooks"
+	"github.com/gin-gonic/gin"
+)
+
+// RotateSecret replaces an endpoint's signing secret. Deliveries sent from
+// now on are signed with the new one.
+func RotateSecret(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error rotating webhook secret"})
+		return
+	}
+	if err := db.DB.WithContext(c).Model(&endpoint).Update("secret", secret).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error rotating webhook secret", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook secret rotated successfully", "endpoint": endpoint, "secret": secret})
+}
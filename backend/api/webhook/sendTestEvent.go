@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/webhooks"
+	"github.com/gin-gonic/gin"
+)
+
+// SendTestEvent sends a webhook.test event to the endpoint straight away and
+// returns the delivery with the endpoint's response
+func SendTestEvent(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := webhooks.SendTest(c.Request.Context(), db.DB.WithContext(c), &endpoint, time.Now().UTC())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error sending test event", "details": err.Error()})
+		return
+	}
+
+	message := "Test event delivered"
+	if delivery.Status != "sent" {
+		message = "Test event could not be delivered"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "delivery": delivery})
+}
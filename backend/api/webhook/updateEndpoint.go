@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateEndpoint changes a webhook endpoint. Enabling an endpoint that was
+// disabled clears its failures; its queued deliveries are then sent.
+func UpdateEndpoint(c *gin.Context) {
+	endpoint, ok := loadEndpoint(c)
+	if !ok {
+		return
+	}
+
+	var input models.WebhookEndpointUpdateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook endpoint data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook endpoint data", "details": err.Error()})
+		return
+	}
+
+	if input.URL != nil {
+		endpoint.URL = *input.URL
+	}
+	if input.Description != nil {
+		endpoint.Description = *input.Description
+	}
+	if input.EventTypes != nil {
+		endpoint.EventTypes = *input.EventTypes
+	}
+	if input.IsActive != nil && *input.IsActive != endpoint.IsActive {
+		endpoint.IsActive = *input.IsActive
+		if endpoint.IsActive {
+			endpoint.ConsecutiveFailures = 0
+			endpoint.DisabledAt = nil
+			endpoint.DisabledReason = ""
+		}
+	}
+
+	if err := db.DB.WithContext(c).Model(&endpoint).
+		Select("url", "description", "event_types", "is_active", "consecutive_failures", "disabled_at", "disabled_reason").
+		Updates(&endpoint).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating webhook endpoint", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook endpoint updated successfully", "endpoint": endpoint})
+}
@@ -0,0 +1,46 @@
+package workorder
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AddQuote records a vendor's quote on a work order
+func AddQuote(c *gin.Context) {
+	order, ok := loadWorkOrder(c, false)
+	if !ok {
+		return
+	}
+
+	var input models.QuoteCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quote data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quote data", "details": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	quote, err := servicing.AddQuote(db.DB.WithContext(c), &order, input, userID.(uint))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Vendor not found"})
+		case errors.Is(err, servicing.ErrWorkOrderClosed), errors.Is(err, servicing.ErrVendorInactive):
+			c.JSON(http.StatusConflict, gin.H{"error": "Quote cannot be added", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error adding quote", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Quote added successfully", "quote": quote})
+}
@@ -0,0 +1,48 @@
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ApproveQuote accepts a quote, rejecting the competing ones, and assigns
+// its vendor to the work order
+func ApproveQuote(c *gin.Context) {
+	order, ok := loadWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	quoteID, err := strconv.ParseUint(c.Param("quoteID"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid quote ID"})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	err = db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		return servicing.ApproveQuote(tx, &order, uint(quoteID), userID.(uint), time.Now())
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Quote not found"})
+		case errors.Is(err, servicing.ErrWorkOrderClosed), errors.Is(err, servicing.ErrQuoteExpired),
+			errors.Is(err, servicing.ErrVendorInactive), errors.Is(err, servicing.ErrVendorUninsured):
+			c.JSON(http.StatusConflict, gin.H{"error": "Quote cannot be approved", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error approving quote", "details": err.Error()})
+		}
+		return
+	}
+
+	withDetails(db.DB).First(&order, order.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Quote approved successfully", "work_order": order})
+}
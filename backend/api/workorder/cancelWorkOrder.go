@@ -0,0 +1,28 @@
+package workorder
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/gin-gonic/gin"
+)
+
+// CancelWorkOrder cancels a work order that has not been invoiced
+func CancelWorkOrder(c *gin.Context) {
+	order, ok := loadWorkOrder(c, true)
+	if !ok {
+		return
+	}
+	if order.Status == "invoiced" || order.Status == "cancelled" {
+		c.JSON(http.StatusConflict, gin.H{"error": "Work order is already " + order.Status})
+		return
+	}
+
+	order.Status = "cancelled"
+	if err := db.DB.WithContext(c).Model(&order).Update("status", "cancelled").Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error cancelling work order", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Work order cancelled successfully", "work_order": order})
+}
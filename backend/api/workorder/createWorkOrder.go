@@ -0,0 +1,43 @@
+package workorder
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateWorkOrder raises a work order from a maintenance request so vendors
+// can quote for it
+func CreateWorkOrder(c *gin.Context) {
+	maintenance, ok := loadMaintenance(c)
+	if !ok {
+		return
+	}
+	if !maintenance.IsOpen() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Work orders can only be raised from open maintenance requests"})
+		return
+	}
+
+	var input models.WorkOrderCreateRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid work order data", "details": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	order := models.WorkOrder{
+		MaintenanceID: maintenance.ID,
+		PropertyID:    maintenance.PropertyID,
+		CreatedByID:   userID.(uint),
+		Description:   input.Description,
+		Status:        "requested",
+	}
+	if err := db.DB.WithContext(c).Create(&order).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating work order", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Work order created successfully", "work_order": order})
+}
@@ -0,0 +1,101 @@
+package workorder
+
+import (
+	"net/http"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetWorkOrders lists the work orders raised from a maintenance request with
+// their quotes
+func GetWorkOrders(c *gin.Context) {
+	maintenance, ok := loadMaintenance(c)
+	if !ok {
+		return
+	}
+
+	var orders []models.WorkOrder
+	if err := withDetails(db.DB).Where("maintenance_id = ? AND deleted_at IS NULL", maintenance.ID).
+		Order("created_at").Find(&orders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching work orders", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"work_orders": orders})
+}
+
+// GetWorkOrder returns a work order with its quotes, vendor and expense
+func GetWorkOrder(c *gin.Context) {
+	order, ok := loadWorkOrder(c, false)
+	if !ok {
+		return
+	}
+
+	if err := withDetails(db.DB).First(&order, order.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching work order", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"work_order": order})
+}
+
+// withDetails preloads what a work order response shows
+func withDetails(tx *gorm.DB) *gorm.DB {
+	return tx.Preload("Vendor").Preload("Expense").
+		Preload("Quotes", func(db *gorm.DB) *gorm.DB { return db.Order("amount, id") }).
+		Preload("Quotes.Vendor")
+}
+
+// loadMaintenance loads the maintenance request from the ":id" path
+// parameter. Admins and the maintenance team reach every request, landlords
+// those on their own properties. It writes the error response itself and
+// returns false when the request must stop.
+func loadMaintenance(c *gin.Context) (models.Maintenance, bool) {
+	var maintenance models.Maintenance
+	if err := db.DB.Preload("Property").First(&maintenance, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Maintenance not found"})
+		return maintenance, false
+	}
+	if !canReach(c, maintenance.Property.OwnerID, false) {
+		return maintenance, false
+	}
+	return maintenance, true
+}
+
+// loadWorkOrder loads the work order from the ":id" path parameter with the
+// same access rules as loadMaintenance. Approving quotes and booking
+// invoices commit the landlord's money, so when approve is set the
+// maintenance team is refused.
+func loadWorkOrder(c *gin.Context, approve bool) (models.WorkOrder, bool) {
+	var order models.WorkOrder
+	if err := db.DB.Preload("Property").Where("deleted_at IS NULL").First(&order, c.Param("id")).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Work order not found"})
+		return order, false
+	}
+	if !canReach(c, order.Property.OwnerID, approve) {
+		return order, false
+	}
+	return order, true
+}
+
+func canReach(c *gin.Context, ownerID uint, approve bool) bool {
+	userRole, _ := c.Get("user_role")
+	userID, _ := c.Get("user_id")
+	switch userRole {
+	case "admin":
+		return true
+	case "landlord":
+		if ownerID == userID {
+			return true
+		}
+	case "maintenanceTeam":
+		if !approve {
+			return true
+		}
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	return false
+}
@@ -0,0 +1,56 @@
+package workorder
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RecordInvoice books the approved vendor's final invoice as a repairs
+// expense against the property
+func RecordInvoice(c *gin.Context) {
+	order, ok := loadWorkOrder(c, true)
+	if !ok {
+		return
+	}
+
+	var input models.VendorInvoiceRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice data", "details": err.Error()})
+		return
+	}
+	if err := input.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invoice data", "details": err.Error()})
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	var expense *models.Expense
+	err := db.DB.WithContext(c).Transaction(func(tx *gorm.DB) error {
+		var err error
+		expense, err = servicing.InvoiceWorkOrder(tx, &order, input, userID.(uint), time.Now())
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, servicing.ErrWorkOrderNotApproved) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Only approved work orders can be invoiced", "details": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error recording vendor invoice", "details": err.Error()})
+		}
+		return
+	}
+	// Booking the vendor's share may also have reduced the request's own cost expense
+	var expenses []models.Expense
+	db.DB.Where("maintenance_id = ?", order.MaintenanceID).Find(&expenses)
+	billing.InvalidateExpenseCaches(db.DB, append(expenses, *expense)...)
+
+	withDetails(db.DB).First(&order, order.ID)
+	c.JSON(http.StatusCreated, gin.H{"message": "Vendor invoice recorded successfully", "work_order": order})
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/geoo115/property-manager/snapshot"
+)
+
+// redacted replaces the value of sensitive columns in recorded data
+const redacted = "[REDACTED]"
+
+// sensitiveColumns are never written to the audit trail in clear
+var sensitiveColumns = map[string]bool{
+	"password": true,
+}
+
+// ignoredColumns change on every write and are left out of update diffs
+var ignoredColumns = map[string]bool{
+	"updated_at": true,
+}
+
+// Row is a database row keyed by column name
+type Row = snapshot.Row
+
+// Diff returns the columns whose value differs between two snapshots of the
+// same row, as the old and new values of just those columns, plus the sorted
+// column names. Sensitive columns are reported as changed but redacted.
+func Diff(before, after Row) (Row, Row, []string) {
+	oldValues, newValues := Row{}, Row{}
+	var fields []string
+	for column, newValue := range after {
+		if ignoredColumns[column] {
+			continue
+		}
+		oldValue, existed := before[column]
+		if existed && equalValues(oldValue, newValue) {
+			continue
+		}
+		fields = append(fields, column)
+		if sensitiveColumns[column] {
+			oldValues[column], newValues[column] = redacted, redacted
+			continue
+		}
+		oldValues[column], newValues[column] = oldValue, newValue
+	}
+	sort.Strings(fields)
+	return oldValues, newValues, fields
+}
+
+// Redact returns a copy of a full row snapshot safe to store
+func Redact(row Row) Row {
+	clean := make(Row, len(row))
+	for column, value := range row {
+		if sensitiveColumns[column] {
+			value = redacted
+		}
+		clean[column] = value
+	}
+	return clean
+}
+
+// encode marshals a snapshot for AuditLog.OldData and AuditLog.NewData
+func encode(row Row) string {
+	if len(row) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Sprintf("%v", row)
+	}
+	return string(data)
+}
+
+func equalValues(a, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+)
+
+// Change is one field changed by an audited action
+type Change struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// TimelineEntry is one audited action in an entity's history
+type TimelineEntry struct {
+	ID          uint                 `json:"id"`
+	At          time.Time            `json:"at"`
+	Action      string               `json:"action"`
+	User        *models.UserResponse `json:"user"`
+	IPAddress   string               `json:"ip_address"`
+	UserAgent   string               `json:"user_agent"`
+	Description string               `json:"description"`
+	Changes     []Change             `json:"changes"`
+}
+
+// Timeline turns audit entries into a chronological, field-level history.
+// Creations list every field with no old value and deletions every field with
+// no new value. Entries whose data is not JSON keep only their description.
+func Timeline(logs []models.AuditLog) []TimelineEntry {
+	sorted := make([]models.AuditLog, len(logs))
+	copy(sorted, logs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreatedAt.Equal(sorted[j].CreatedAt) {
+			return sorted[i].ID < sorted[j].ID
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	timeline := make([]TimelineEntry, 0, len(sorted))
+	for _, log := range sorted {
+		response := log.ToResponse()
+		timeline = append(timeline, TimelineEntry{
+			ID:          log.ID,
+			At:          log.CreatedAt,
+			Action:      log.Action,
+			User:        response.User,
+			IPAddress:   log.IPAddress,
+			UserAgent:   log.UserAgent,
+			Description: log.Description,
+			Changes:     changes(decode(log.OldData), decode(log.NewData)),
+		})
+	}
+	return timeline
+}
+
+func changes(oldValues, newValues Row) []Change {
+	fields := make(map[string]bool)
+	for field := range oldValues {
+		fields[field] = true
+	}
+	for field := range newValues {
+		fields[field] = true
+	}
+
+	result := make([]Change, 0, len(fields))
+	for field := range fields {
+		result = append(result, Change{Field: field, Old: oldValues[field], New: newValues[field]})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Field < result[j].Field })
+	return result
+}
+
+func decode(data string) Row {
+	if data == "" {
+		return nil
+	}
+	var row Row
+	if err := json.Unmarshal([]byte(data), &row); err != nil {
+		return nil
+	}
+	return row
+}
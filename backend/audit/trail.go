@@ -0,0 +1,117 @@
+// Package audit records a before/after trail of every write to the core
+// tables. Handlers attach the request to their queries with
+// db.DB.WithContext(c) so entries carry the acting user, IP and user agent;
+// writes made without a request (background jobs) are recorded with no user.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/snapshot"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TrackedTables maps the audited tables to the entity type recorded for them
+var TrackedTables = map[string]string{
+	"users":                "user",
+	"properties":           "property",
+	"leases":               "lease",
+	"invoices":             "invoice",
+	"expenses":             "expense",
+	"maintenance_requests": "maintenance",
+}
+
+// Actor is who made a change and from where
+type Actor struct {
+	UserID    *uint
+	IPAddress string
+	UserAgent string
+}
+
+// ActorFrom extracts the actor from a query context. Requests are recognised
+// by the gin context passed to WithContext; anything else is a system actor.
+func ActorFrom(ctx context.Context) Actor {
+	var actor Actor
+	if ctx == nil {
+		return actor
+	}
+	c, ok := ctx.Value(gin.ContextKey).(*gin.Context)
+	if !ok {
+		return actor
+	}
+	if userID, exists := c.Get("user_id"); exists {
+		if id, ok := userID.(uint); ok {
+			actor.UserID = &id
+		}
+	}
+	if c.Request != nil {
+		actor.IPAddress = c.ClientIP()
+		actor.UserAgent = c.Request.UserAgent()
+	}
+	return actor
+}
+
+// Register subscribes the audit trail to writes on the tracked tables.
+// Entries are written in the same transaction as the change, so a change
+// that cannot be audited is rolled back.
+func Register(db *gorm.DB) error {
+	tables := make([]string, 0, len(TrackedTables))
+	for table := range TrackedTables {
+		tables = append(tables, table)
+	}
+	return snapshot.Register(db, snapshot.Subscriber{Name: "audit", Tables: tables, Handle: record})
+}
+
+// record writes the audit entries for one captured change
+func record(tx *gorm.DB, change snapshot.Change) error {
+	entityType := TrackedTables[change.Table]
+
+	var entries []models.AuditLog
+	for _, id := range change.IDs() {
+		switch change.Action {
+		case snapshot.Create:
+			entry := newEntry(tx, "CREATE", entityType, id)
+			entry.NewData = encode(Redact(change.After[id]))
+			entry.Description = fmt.Sprintf("Created %s %d", entityType, id)
+			entries = append(entries, entry)
+		case snapshot.Update:
+			oldValues, newValues, fields := Diff(change.Before[id], change.After[id])
+			if len(fields) == 0 {
+				continue
+			}
+			entry := newEntry(tx, "UPDATE", entityType, id)
+			entry.OldData = encode(oldValues)
+			entry.NewData = encode(newValues)
+			entry.Description = fmt.Sprintf("Updated %s %d: %s", entityType, id, strings.Join(fields, ", "))
+			entries = append(entries, entry)
+		case snapshot.Delete:
+			entry := newEntry(tx, "DELETE", entityType, id)
+			entry.OldData = encode(Redact(change.Before[id]))
+			entry.Description = fmt.Sprintf("Deleted %s %d", entityType, id)
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := snapshot.Session(tx).Create(&entries).Error; err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+func newEntry(tx *gorm.DB, action, entityType string, id uint) models.AuditLog {
+	actor := ActorFrom(tx.Statement.Context)
+	return models.AuditLog{
+		UserID:     actor.UserID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		IPAddress:  actor.IPAddress,
+		UserAgent:  actor.UserAgent,
+	}
+}
@@ -0,0 +1,67 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+)
+
+// InvalidateInvoiceCaches drops the cached invoice lists touched by the given
+// invoices, mirroring the keys used by the accounting handlers
+func InvalidateInvoiceCaches(database *gorm.DB, invoices ...models.Invoice) {
+	if db.RedisClient == nil || len(invoices) == 0 {
+		return
+	}
+
+	keys := map[string]struct{}{"invoices": {}}
+	propertyIDs := make([]uint, 0, len(invoices))
+	for _, invoice := range invoices {
+		keys[fmt.Sprintf("invoice:%d", invoice.ID)] = struct{}{}
+		keys[fmt.Sprintf("tenant_invoices:%d", invoice.TenantID)] = struct{}{}
+		propertyIDs = append(propertyIDs, invoice.PropertyID)
+	}
+
+	var ownerIDs []uint
+	database.Model(&models.Property{}).Where("id IN ?", propertyIDs).Distinct().Pluck("owner_id", &ownerIDs)
+	for _, ownerID := range ownerIDs {
+		keys[fmt.Sprintf("landlord_invoices:%d", ownerID)] = struct{}{}
+	}
+
+	ctx := context.Background()
+	for key := range keys {
+		if err := db.RedisClient.Del(ctx, key).Err(); err != nil {
+			fmt.Printf("Failed to delete Redis key %s: %v\n", key, err)
+		}
+	}
+}
+
+// InvalidateExpenseCaches drops the cached expense lists touched by the given
+// expenses, mirroring the keys used by the accounting handlers
+func InvalidateExpenseCaches(database *gorm.DB, expenses ...models.Expense) {
+	if db.RedisClient == nil || len(expenses) == 0 {
+		return
+	}
+
+	keys := map[string]struct{}{"expenses": {}}
+	propertyIDs := make([]uint, 0, len(expenses))
+	for _, expense := range expenses {
+		keys[fmt.Sprintf("expense:%d", expense.ID)] = struct{}{}
+		propertyIDs = append(propertyIDs, expense.PropertyID)
+	}
+
+	var ownerIDs []uint
+	database.Model(&models.Property{}).Where("id IN ?", propertyIDs).Distinct().Pluck("owner_id", &ownerIDs)
+	for _, ownerID := range ownerIDs {
+		keys[fmt.Sprintf("landlord_expenses:%d", ownerID)] = struct{}{}
+	}
+
+	ctx := context.Background()
+	for key := range keys {
+		if err := db.RedisClient.Del(ctx, key).Err(); err != nil {
+			fmt.Printf("Failed to delete Redis key %s: %v\n", key, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package billing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewInvoiceNumber returns a unique number for a manually raised invoice
+func NewInvoiceNumber() string {
+	return newDocumentNumber("INV")
+}
+
+// NewReceiptNumber returns a unique number for a payment or refund receipt
+func NewReceiptNumber() string {
+	return newDocumentNumber("RCP")
+}
+
+// NewExpenseNumber returns a unique number for an expense
+func NewExpenseNumber() string {
+	return newDocumentNumber("EXP")
+}
+
+func newDocumentNumber(prefix string) string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		// Fall back to the clock if the random source is unavailable
+		return fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+	}
+	return fmt.Sprintf("%s-%s-%s", prefix, time.Now().UTC().Format("20060102"), strings.ToUpper(hex.EncodeToString(suffix)))
+}
+
+// RecurringInvoiceNumber returns the number of the invoice generated from a
+// recurring template for the given period. It is deterministic so that two
+// runs generating the same period collide on the unique invoice number.
+func RecurringInvoiceNumber(templateID uint, period time.Time) string {
+	return fmt.Sprintf("INV-R%d-%s", templateID, period.Format("20060102"))
+}
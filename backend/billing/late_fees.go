@@ -0,0 +1,223 @@
+package billing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lateFeeLockKey is the Postgres advisory lock that serialises late fee runs
+// across replicas
+const lateFeeLockKey int64 = 7301002
+
+// lateFeePaymentTermDays is how long a tenant has to pay a late fee
+const lateFeePaymentTermDays = 7
+
+// LateFeeRun summarises one pass of the late fee job
+type LateFeeRun struct {
+	MarkedOverdue []uint           `json:"marked_overdue"`
+	Fees          []models.Invoice `json:"fees"`
+}
+
+// LateFeeAmount computes the fee a policy charges on an outstanding balance
+func LateFeeAmount(policy models.LateFeePolicy, balance float64) float64 {
+	if !policy.Enabled || balance <= 0 {
+		return 0
+	}
+	fee := policy.FlatFee + balance*policy.Percentage/100
+	if policy.MaxFee > 0 && fee > policy.MaxFee {
+		fee = policy.MaxFee
+	}
+	return roundCurrency(fee)
+}
+
+// LateFeeDue reports whether an invoice is past its due date plus the
+// policy's grace days
+func LateFeeDue(policy models.LateFeePolicy, invoice models.Invoice, asOf time.Time) bool {
+	chargeableFrom := truncateDay(invoice.DueDate).AddDate(0, 0, policy.GraceDays+1)
+	return !truncateDay(asOf).Before(chargeableFrom)
+}
+
+// LateFeeInvoiceNumber returns the deterministic number of the late fee
+// charged on an invoice
+func LateFeeInvoiceNumber(invoiceID uint) string {
+	return fmt.Sprintf("INV-LF%d", invoiceID)
+}
+
+// ApplyLateFees marks pending invoices past their due date as overdue and
+// charges a late fee invoice on each overdue invoice whose landlord has an
+// enabled policy. Every action is recorded in the audit log.
+func ApplyLateFees(database *gorm.DB, asOf time.Time) (*LateFeeRun, error) {
+	run := &LateFeeRun{}
+	today := truncateDay(asOf)
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", lateFeeLockKey).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to acquire late fee lock: %w", err)
+		}
+		if !locked {
+			logger.LogInfo("Late fee run already in progress on another instance", nil)
+			return nil
+		}
+
+		marked, err := markOverdue(tx, today)
+		if err != nil {
+			return err
+		}
+		run.MarkedOverdue = marked
+
+		fees, err := chargeLateFees(tx, today)
+		if err != nil {
+			return err
+		}
+		run.Fees = fees
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	touched := append([]models.Invoice{}, run.Fees...)
+	if len(run.MarkedOverdue) > 0 {
+		var marked []models.Invoice
+		database.Where("id IN ?", run.MarkedOverdue).Find(&marked)
+		touched = append(touched, marked...)
+	}
+	if len(touched) > 0 {
+		InvalidateInvoiceCaches(database, touched...)
+		logger.LogInfo("Late fee run completed", logrus.Fields{
+			"marked_overdue": len(run.MarkedOverdue),
+			"fees_charged":   len(run.Fees),
+			"as_of":          today.Format("2006-01-02"),
+		})
+	}
+
+	return run, nil
+}
+
+func markOverdue(tx *gorm.DB, today time.Time) ([]uint, error) {
+	var ids []uint
+	if err := tx.Model(&models.Invoice{}).
+		Where("payment_status = ? AND due_date < ? AND deleted_at IS NULL", "pending", today).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to find overdue invoices: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Model(&models.Invoice{}).Where("id IN ?", ids).
+		Update("payment_status", "overdue").Error; err != nil {
+		return nil, fmt.Errorf("failed to mark invoices overdue: %w", err)
+	}
+
+	logs := make([]models.AuditLog, 0, len(ids))
+	for _, id := range ids {
+		logs = append(logs, models.AuditLog{
+			Action:      "MARK_OVERDUE",
+			EntityType:  "invoice",
+			EntityID:    id,
+			OldData:     `{"payment_status":"pending"}`,
+			NewData:     `{"payment_status":"overdue"}`,
+			Description: fmt.Sprintf("Invoice %d marked overdue by the late fee job", id),
+		})
+	}
+	if err := tx.Create(&logs).Error; err != nil {
+		return nil, fmt.Errorf("failed to audit overdue invoices: %w", err)
+	}
+
+	return ids, nil
+}
+
+func chargeLateFees(tx *gorm.DB, today time.Time) ([]models.Invoice, error) {
+	var policies []models.LateFeePolicy
+	if err := tx.Where("enabled = ? AND deleted_at IS NULL", true).Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to load late fee policies: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	policyByLandlord := make(map[uint]models.LateFeePolicy, len(policies))
+	landlordIDs := make([]uint, 0, len(policies))
+	for _, policy := range policies {
+		policyByLandlord[policy.LandlordID] = policy
+		landlordIDs = append(landlordIDs, policy.LandlordID)
+	}
+
+	var overdue []models.Invoice
+	if err := tx.Select("invoices.*").Preload("Property").
+		Joins("JOIN properties ON properties.id = invoices.property_id").
+		Where("invoices.payment_status = ? AND invoices.category <> ? AND invoices.deleted_at IS NULL", "overdue", "late_fee").
+		Where("properties.owner_id IN ?", landlordIDs).
+		Where("NOT EXISTS (SELECT 1 FROM invoices fees WHERE fees.late_fee_for_id = invoices.id)").
+		Order("invoices.due_date, invoices.id").
+		Find(&overdue).Error; err != nil {
+		return nil, fmt.Errorf("failed to load overdue invoices: %w", err)
+	}
+
+	var charged []models.Invoice
+	for _, invoice := range overdue {
+		policy := policyByLandlord[invoice.Property.OwnerID]
+		if !LateFeeDue(policy, invoice, today) {
+			continue
+		}
+		amount := LateFeeAmount(policy, outstanding(invoice))
+		if amount <= 0 {
+			continue
+		}
+
+		sourceID := invoice.ID
+		fee := models.Invoice{
+			TenantID:      invoice.TenantID,
+			PropertyID:    invoice.PropertyID,
+			LeaseID:       invoice.LeaseID,
+			UnitID:        invoice.UnitID,
+			CreatedByID:   policy.LandlordID,
+			InvoiceNumber: LateFeeInvoiceNumber(invoice.ID),
+			Amount:        amount,
+			InvoiceDate:   today,
+			Category:      "late_fee",
+			DueDate:       today.AddDate(0, 0, lateFeePaymentTermDays),
+			PaymentStatus: "pending",
+			LateFeeForID:  &sourceID,
+			Notes:         fmt.Sprintf("Late fee for invoice %s", invoice.InvoiceNumber),
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&fee)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to create late fee for invoice %d: %w", invoice.ID, result.Error)
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+		if err := PublishInvoice(tx, models.WebhookInvoiceCreated, fee); err != nil {
+			return nil, err
+		}
+
+		newData, _ := json.Marshal(map[string]interface{}{
+			"late_fee_for_id": invoice.ID,
+			"amount":          amount,
+			"policy_id":       policy.ID,
+		})
+		auditLog := models.AuditLog{
+			Action:      "CHARGE_LATE_FEE",
+			EntityType:  "invoice",
+			EntityID:    fee.ID,
+			NewData:     string(newData),
+			Description: fmt.Sprintf("Late fee of %.2f charged on overdue invoice %s", amount, invoice.InvoiceNumber),
+		}
+		if err := tx.Create(&auditLog).Error; err != nil {
+			return nil, fmt.Errorf("failed to audit late fee: %w", err)
+		}
+
+		charged = append(charged, fee)
+	}
+
+	return charged, nil
+}
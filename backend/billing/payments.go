@@ -0,0 +1,218 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrOverpayment is returned when a payment exceeds what is owed on the
+	// invoice, or on all of the tenant's invoices
+	ErrOverpayment = errors.New("payment exceeds the outstanding balance")
+	// ErrOverRefund is returned when a refund exceeds the net amount paid
+	ErrOverRefund = errors.New("refund exceeds the amount paid")
+	// ErrInvoiceCancelled is returned when money is moved against a cancelled invoice
+	ErrInvoiceCancelled = errors.New("invoice is cancelled")
+)
+
+// PaymentInput holds the details of a payment or refund to record
+type PaymentInput struct {
+	TenantID     uint
+	RecordedByID uint
+	Amount       float64
+	Method       string
+	PaymentDate  time.Time
+	Reference    string
+	Notes        string
+}
+
+// PaymentStatusFor derives an invoice's payment status from its amounts.
+// Cancelled invoices stay cancelled.
+func PaymentStatusFor(invoice models.Invoice, now time.Time) string {
+	if invoice.PaymentStatus == "cancelled" {
+		return "cancelled"
+	}
+	if roundCurrency(invoice.PaidAmount-invoice.RefundedAmount) >= roundCurrency(invoice.Amount) {
+		return "paid"
+	}
+	if invoice.DueDate.Before(now) {
+		return "overdue"
+	}
+	return "pending"
+}
+
+// RecalculateInvoice derives PaidAmount, RefundedAmount and PaymentStatus of
+// an invoice from the payment ledger and stores them
+func RecalculateInvoice(tx *gorm.DB, invoiceID uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := tx.First(&invoice, invoiceID).Error; err != nil {
+		return nil, err
+	}
+
+	var totals []struct {
+		Type  string
+		Total float64
+	}
+	if err := tx.Model(&models.PaymentAllocation{}).
+		Select("payments.type AS type, COALESCE(SUM(payment_allocations.amount), 0) AS total").
+		Joins("JOIN payments ON payments.id = payment_allocations.payment_id").
+		Where("payment_allocations.invoice_id = ? AND payments.deleted_at IS NULL", invoiceID).
+		Group("payments.type").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to total payments: %w", err)
+	}
+
+	previousStatus := invoice.PaymentStatus
+	invoice.PaidAmount = 0
+	invoice.RefundedAmount = 0
+	for _, total := range totals {
+		switch total.Type {
+		case "payment":
+			invoice.PaidAmount = roundCurrency(total.Total)
+		case "refund":
+			invoice.RefundedAmount = roundCurrency(total.Total)
+		}
+	}
+	invoice.PaymentStatus = PaymentStatusFor(invoice, time.Now())
+
+	if err := tx.Model(&invoice).Updates(map[string]interface{}{
+		"paid_amount":     invoice.PaidAmount,
+		"refunded_amount": invoice.RefundedAmount,
+		"payment_status":  invoice.PaymentStatus,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to update invoice totals: %w", err)
+	}
+
+	if invoice.PaymentStatus == "paid" && previousStatus != "paid" {
+		if err := PublishInvoice(tx, models.WebhookInvoicePaid, invoice); err != nil {
+			return nil, err
+		}
+	}
+	return &invoice, nil
+}
+
+// RecordInvoicePayment records a payment against a single invoice
+func RecordInvoicePayment(tx *gorm.DB, invoiceID uint, input PaymentInput) (*models.Payment, error) {
+	var invoice models.Invoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, invoiceID).Error; err != nil {
+		return nil, err
+	}
+	if invoice.PaymentStatus == "cancelled" {
+		return nil, ErrInvoiceCancelled
+	}
+	if roundCurrency(input.Amount) > outstanding(invoice) {
+		return nil, ErrOverpayment
+	}
+
+	input.TenantID = invoice.TenantID
+	payment := newPayment("payment", input)
+	payment.Allocations = []models.PaymentAllocation{{InvoiceID: invoice.ID, Amount: roundCurrency(input.Amount)}}
+	if err := tx.Create(&payment).Error; err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	if _, err := RecalculateInvoice(tx, invoice.ID); err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// RecordTenantPayment records a single payment from a tenant and allocates
+// it across their outstanding invoices, oldest due date first. A payment
+// larger than everything the tenant owes returns ErrOverpayment rather than
+// leaving credit that no invoice would ever draw on.
+func RecordTenantPayment(tx *gorm.DB, input PaymentInput) (*models.Payment, error) {
+	var invoices []models.Invoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ? AND payment_status IN ? AND deleted_at IS NULL", input.TenantID, []string{"pending", "overdue"}).
+		Order("due_date, id").
+		Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("failed to load outstanding invoices: %w", err)
+	}
+
+	remaining := roundCurrency(input.Amount)
+	var allocations []models.PaymentAllocation
+	for _, invoice := range invoices {
+		if remaining <= 0 {
+			break
+		}
+		balance := outstanding(invoice)
+		if balance <= 0 {
+			continue
+		}
+		applied := balance
+		if remaining < applied {
+			applied = remaining
+		}
+		allocations = append(allocations, models.PaymentAllocation{InvoiceID: invoice.ID, Amount: applied})
+		remaining = roundCurrency(remaining - applied)
+	}
+	if remaining > 0 {
+		return nil, ErrOverpayment
+	}
+
+	payment := newPayment("payment", input)
+	payment.Allocations = allocations
+	if err := tx.Create(&payment).Error; err != nil {
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	for _, allocation := range allocations {
+		if _, err := RecalculateInvoice(tx, allocation.InvoiceID); err != nil {
+			return nil, err
+		}
+	}
+	return &payment, nil
+}
+
+// RefundInvoice records a refund of money previously paid against an invoice
+func RefundInvoice(tx *gorm.DB, invoiceID uint, input PaymentInput, refundOfID *uint) (*models.Payment, error) {
+	var invoice models.Invoice
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, invoiceID).Error; err != nil {
+		return nil, err
+	}
+	if roundCurrency(input.Amount) > roundCurrency(invoice.PaidAmount-invoice.RefundedAmount) {
+		return nil, ErrOverRefund
+	}
+
+	input.TenantID = invoice.TenantID
+	refund := newPayment("refund", input)
+	refund.RefundOfID = refundOfID
+	refund.Allocations = []models.PaymentAllocation{{InvoiceID: invoice.ID, Amount: roundCurrency(input.Amount)}}
+	if err := tx.Create(&refund).Error; err != nil {
+		return nil, fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	if _, err := RecalculateInvoice(tx, invoice.ID); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func newPayment(paymentType string, input PaymentInput) models.Payment {
+	paymentDate := input.PaymentDate
+	if paymentDate.IsZero() {
+		paymentDate = time.Now().UTC()
+	}
+	return models.Payment{
+		ReceiptNumber: NewReceiptNumber(),
+		TenantID:      input.TenantID,
+		RecordedByID:  input.RecordedByID,
+		Type:          paymentType,
+		Method:        input.Method,
+		Amount:        roundCurrency(input.Amount),
+		PaymentDate:   paymentDate,
+		Reference:     input.Reference,
+		Notes:         input.Notes,
+	}
+}
+
+// outstanding returns what is still owed on an invoice, net of refunds
+func outstanding(invoice models.Invoice) float64 {
+	return roundCurrency(invoice.Amount - (invoice.PaidAmount - invoice.RefundedAmount))
+}
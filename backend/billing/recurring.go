@@ -0,0 +1,217 @@
+package billing
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// recurringLockKey is the Postgres advisory lock that serialises recurring
+// invoice generation across replicas
+const recurringLockKey int64 = 7301001
+
+// maxCatchUpPeriods bounds how many missed periods a single template can
+// generate in one run, e.g. after a long outage
+const maxCatchUpPeriods = 24
+
+var intervalMonths = map[string]int{
+	"monthly":   1,
+	"quarterly": 3,
+	"yearly":    12,
+}
+
+// RecurringCandidate describes an invoice the recurring engine would generate
+type RecurringCandidate struct {
+	TemplateID    uint      `json:"template_id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	TenantID      uint      `json:"tenant_id"`
+	PropertyID    uint      `json:"property_id"`
+	LeaseID       *uint     `json:"lease_id"`
+	UnitID        *uint     `json:"unit_id"`
+	CreatedByID   uint      `json:"created_by_id"`
+	Category      string    `json:"category"`
+	Amount        float64   `json:"amount"`
+	Interval      string    `json:"recurring_interval"`
+	InvoiceDate   time.Time `json:"invoice_date"`
+	DueDate       time.Time `json:"due_date"`
+}
+
+// Invoice builds the invoice that would be stored for the candidate
+func (rc RecurringCandidate) Invoice() models.Invoice {
+	templateID := rc.TemplateID
+	return models.Invoice{
+		TenantID:          rc.TenantID,
+		PropertyID:        rc.PropertyID,
+		LeaseID:           rc.LeaseID,
+		UnitID:            rc.UnitID,
+		CreatedByID:       rc.CreatedByID,
+		InvoiceNumber:     rc.InvoiceNumber,
+		Amount:            rc.Amount,
+		InvoiceDate:       rc.InvoiceDate,
+		Category:          rc.Category,
+		DueDate:           rc.DueDate,
+		PaymentStatus:     "pending",
+		RecurringParentID: &templateID,
+		Notes:             fmt.Sprintf("Generated from recurring invoice #%d", rc.TemplateID),
+	}
+}
+
+// AddMonths adds calendar months to t, clamping the day to the end of the
+// target month so that e.g. 31 January + 1 month is 28/29 February
+func AddMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// PeriodDate returns the invoice date of the n-th period after anchor
+func PeriodDate(anchor time.Time, interval string, n int) (time.Time, error) {
+	months, ok := intervalMonths[interval]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unsupported recurring interval %q", interval)
+	}
+	return AddMonths(anchor, n*months), nil
+}
+
+// PlanRecurringInvoices returns every invoice that is due to be generated from
+// recurring templates up to and including asOf, without writing anything
+func PlanRecurringInvoices(tx *gorm.DB, asOf time.Time) ([]RecurringCandidate, error) {
+	var templates []models.Invoice
+	if err := tx.Where("recurring = ? AND recurring_interval <> '' AND recurring_parent_id IS NULL", true).
+		Where("payment_status <> ? AND deleted_at IS NULL", "cancelled").
+		Preload("Lease").
+		Order("id").
+		Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to load recurring invoices: %w", err)
+	}
+
+	var candidates []RecurringCandidate
+	for _, template := range templates {
+		planned, err := planTemplate(tx, template, asOf)
+		if err != nil {
+			logger.LogError(err, "Skipping recurring invoice", logrus.Fields{
+				"invoice_id": template.ID,
+			})
+			continue
+		}
+		candidates = append(candidates, planned...)
+	}
+
+	return candidates, nil
+}
+
+func planTemplate(tx *gorm.DB, template models.Invoice, asOf time.Time) ([]RecurringCandidate, error) {
+	latest := template.InvoiceDate
+	var latestChild sql.NullTime
+	if err := tx.Model(&models.Invoice{}).
+		Where("recurring_parent_id = ?", template.ID).
+		Select("MAX(invoice_date)").
+		Scan(&latestChild).Error; err != nil {
+		return nil, fmt.Errorf("failed to find latest generated invoice: %w", err)
+	}
+	if latestChild.Valid && latestChild.Time.After(latest) {
+		latest = latestChild.Time
+	}
+
+	// Invoices tied to a lease stop with the lease
+	var leaseEnd *time.Time
+	if template.Lease != nil {
+		if template.Lease.Status == "terminated" || template.Lease.Status == "expired" {
+			return nil, nil
+		}
+		leaseEnd = &template.Lease.EndDate
+	}
+
+	dueOffset := template.DueDate.Sub(template.InvoiceDate)
+	var candidates []RecurringCandidate
+	for n := 1; len(candidates) < maxCatchUpPeriods; n++ {
+		invoiceDate, err := PeriodDate(template.InvoiceDate, template.RecurringInterval, n)
+		if err != nil {
+			return nil, err
+		}
+		if !invoiceDate.After(latest) {
+			continue
+		}
+		if invoiceDate.After(asOf) || (leaseEnd != nil && !invoiceDate.Before(*leaseEnd)) {
+			break
+		}
+
+		candidates = append(candidates, RecurringCandidate{
+			TemplateID:    template.ID,
+			InvoiceNumber: RecurringInvoiceNumber(template.ID, invoiceDate),
+			TenantID:      template.TenantID,
+			PropertyID:    template.PropertyID,
+			LeaseID:       template.LeaseID,
+			UnitID:        template.UnitID,
+			CreatedByID:   template.CreatedByID,
+			Category:      template.Category,
+			Amount:        template.Amount,
+			Interval:      template.RecurringInterval,
+			InvoiceDate:   invoiceDate,
+			DueDate:       invoiceDate.Add(dueOffset),
+		})
+	}
+
+	return candidates, nil
+}
+
+// GenerateRecurringInvoices creates every invoice planned for asOf. It takes a
+// transaction-scoped advisory lock so only one replica generates at a time,
+// and relies on the deterministic invoice number to skip periods that were
+// already generated, which makes it safe to rerun after a restart.
+func GenerateRecurringInvoices(database *gorm.DB, asOf time.Time) ([]models.Invoice, error) {
+	var created []models.Invoice
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", recurringLockKey).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to acquire recurring invoice lock: %w", err)
+		}
+		if !locked {
+			logger.LogInfo("Recurring invoice generation already running on another instance", nil)
+			return nil
+		}
+
+		candidates, err := PlanRecurringInvoices(tx, asOf)
+		if err != nil {
+			return err
+		}
+
+		for _, candidate := range candidates {
+			invoice := candidate.Invoice()
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&invoice)
+			if result.Error != nil {
+				return fmt.Errorf("failed to create invoice %s: %w", invoice.InvoiceNumber, result.Error)
+			}
+			if result.RowsAffected > 0 {
+				if err := PublishInvoice(tx, models.WebhookInvoiceCreated, invoice); err != nil {
+					return err
+				}
+				created = append(created, invoice)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(created) > 0 {
+		InvalidateInvoiceCaches(database, created...)
+		logger.LogInfo("Recurring invoices generated", logrus.Fields{
+			"count": len(created),
+			"as_of": asOf.Format("2006-01-02"),
+		})
+	}
+
+	return created, nil
+}
@@ -0,0 +1,245 @@
+package billing
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// rentPaymentTermDays is how long a tenant has to pay a rent invoice after
+// the period it covers starts
+const rentPaymentTermDays = 7
+
+// RentPeriod is one calendar-month slice of a lease's rent schedule
+type RentPeriod struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"` // Inclusive last day of the period
+	Amount   float64   `json:"amount"`
+	Prorated bool      `json:"prorated"`
+}
+
+// RentSchedule splits a lease into calendar-month rent periods. The first and
+// last months are prorated by the number of days the lease covers; the lease
+// end date is treated as the tenant's last day.
+func RentSchedule(lease models.Lease) []RentPeriod {
+	start := truncateDay(lease.StartDate)
+	end := truncateDay(lease.EndDate)
+	if !end.After(start) || lease.MonthlyRent <= 0 {
+		return nil
+	}
+
+	var periods []RentPeriod
+	for cursor := start; !cursor.After(end); {
+		monthStart := time.Date(cursor.Year(), cursor.Month(), 1, 0, 0, 0, 0, time.UTC)
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		periodEnd := monthEnd
+		if end.Before(periodEnd) {
+			periodEnd = end
+		}
+
+		daysInMonth := monthEnd.Day()
+		coveredDays := periodEnd.Day() - cursor.Day() + 1
+		period := RentPeriod{
+			Start:  cursor,
+			End:    periodEnd,
+			Amount: lease.MonthlyRent,
+		}
+		if coveredDays < daysInMonth {
+			period.Amount = roundCurrency(lease.MonthlyRent * float64(coveredDays) / float64(daysInMonth))
+			period.Prorated = true
+		}
+		periods = append(periods, period)
+
+		cursor = monthEnd.AddDate(0, 0, 1)
+	}
+
+	return periods
+}
+
+// SyncLeaseSchedule brings a lease's rent and deposit invoices in line with
+// the lease terms. Active leases get any missing rent invoices created, and
+// unpaid future invoices that no longer match the terms are cancelled and
+// regenerated. Terminated or expired leases have their unpaid invoices for
+// periods after the end date cancelled. Paid or part-paid invoices and
+// invoices for periods that have already started are never touched.
+func SyncLeaseSchedule(tx *gorm.DB, lease models.Lease, createdByID uint) ([]models.Invoice, error) {
+	today := truncateDay(time.Now().UTC())
+
+	var existing []models.Invoice
+	if err := tx.Where("lease_id = ? AND category IN ? AND payment_status <> ?", lease.ID, []string{"rent", "deposit"}, "cancelled").
+		Order("invoice_date").
+		Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to load lease invoices: %w", err)
+	}
+
+	if lease.Status == "terminated" || lease.Status == "expired" {
+		cutoff := truncateDay(lease.EndDate)
+		var cancelled []models.Invoice
+		for _, invoice := range existing {
+			if invoice.Category != "rent" || !isUntouched(invoice) || !invoice.InvoiceDate.After(cutoff) {
+				continue
+			}
+			if err := cancelInvoice(tx, &invoice, "Lease ended"); err != nil {
+				return nil, err
+			}
+			cancelled = append(cancelled, invoice)
+		}
+		return cancelled, nil
+	}
+
+	if lease.Status != "active" {
+		return nil, nil
+	}
+
+	byPeriod := make(map[string]models.Invoice)
+	var deposit *models.Invoice
+	var changed []models.Invoice
+	for i := range existing {
+		invoice := existing[i]
+		if invoice.Category == "deposit" {
+			deposit = &existing[i]
+			continue
+		}
+		byPeriod[periodKey(invoice.InvoiceDate)] = invoice
+	}
+
+	// Cancel unpaid future invoices that fall outside the schedule or whose
+	// amount no longer matches it
+	desired := make(map[string]RentPeriod)
+	for _, period := range RentSchedule(lease) {
+		desired[periodKey(period.Start)] = period
+	}
+	for key, invoice := range byPeriod {
+		if invoice.InvoiceDate.Before(today) || !isUntouched(invoice) {
+			continue
+		}
+		period, ok := desired[key]
+		if ok && period.Start.Equal(truncateDay(invoice.InvoiceDate)) && period.Amount == invoice.Amount {
+			continue
+		}
+		if err := cancelInvoice(tx, &invoice, "Superseded by updated lease terms"); err != nil {
+			return nil, err
+		}
+		changed = append(changed, invoice)
+		delete(byPeriod, key)
+	}
+
+	for _, period := range RentSchedule(lease) {
+		if _, ok := byPeriod[periodKey(period.Start)]; ok {
+			continue
+		}
+		periodStart, periodEnd := period.Start, period.End
+		invoice := models.Invoice{
+			TenantID:      lease.TenantID,
+			PropertyID:    lease.PropertyID,
+			LeaseID:       &lease.ID,
+			UnitID:        lease.UnitID,
+			CreatedByID:   createdByID,
+			InvoiceNumber: NewInvoiceNumber(),
+			Amount:        period.Amount,
+			InvoiceDate:   periodStart,
+			DueDate:       periodStart.AddDate(0, 0, rentPaymentTermDays),
+			Category:      "rent",
+			PaymentStatus: "pending",
+			PeriodStart:   &periodStart,
+			PeriodEnd:     &periodEnd,
+			Notes:         fmt.Sprintf("Rent for %s to %s", periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02")),
+		}
+		if period.Prorated {
+			invoice.Notes += " (prorated)"
+		}
+		if err := tx.Create(&invoice).Error; err != nil {
+			return nil, fmt.Errorf("failed to create rent invoice: %w", err)
+		}
+		if err := PublishInvoice(tx, models.WebhookInvoiceCreated, invoice); err != nil {
+			return nil, err
+		}
+		changed = append(changed, invoice)
+	}
+
+	// A renewal carries the deposit held on the lease it renews
+	if lease.SecurityDeposit > 0 && lease.PreviousLeaseID == nil {
+		switch {
+		case deposit == nil:
+			invoiceDate := today
+			if leaseStart := truncateDay(lease.StartDate); leaseStart.Before(invoiceDate) {
+				invoiceDate = leaseStart
+			}
+			invoice := models.Invoice{
+				TenantID:      lease.TenantID,
+				PropertyID:    lease.PropertyID,
+				LeaseID:       &lease.ID,
+				UnitID:        lease.UnitID,
+				CreatedByID:   createdByID,
+				InvoiceNumber: NewInvoiceNumber(),
+				Amount:        lease.SecurityDeposit,
+				InvoiceDate:   invoiceDate,
+				DueDate:       truncateDay(lease.StartDate),
+				Category:      "deposit",
+				PaymentStatus: "pending",
+				Notes:         "Security deposit",
+			}
+			if !invoice.DueDate.After(invoice.InvoiceDate) {
+				invoice.DueDate = invoice.InvoiceDate.AddDate(0, 0, rentPaymentTermDays)
+			}
+			if err := tx.Create(&invoice).Error; err != nil {
+				return nil, fmt.Errorf("failed to create deposit invoice: %w", err)
+			}
+			if err := PublishInvoice(tx, models.WebhookInvoiceCreated, invoice); err != nil {
+				return nil, err
+			}
+			changed = append(changed, invoice)
+		case isUntouched(*deposit) && deposit.Amount != lease.SecurityDeposit:
+			if err := tx.Model(deposit).Update("amount", lease.SecurityDeposit).Error; err != nil {
+				return nil, fmt.Errorf("failed to update deposit invoice: %w", err)
+			}
+			changed = append(changed, *deposit)
+		}
+	}
+
+	if len(changed) > 0 {
+		logger.LogInfo("Lease invoice schedule synchronised", logrus.Fields{
+			"lease_id": lease.ID,
+			"invoices": len(changed),
+		})
+	}
+
+	return changed, nil
+}
+
+// isUntouched reports whether no money has moved against the invoice
+func isUntouched(invoice models.Invoice) bool {
+	return invoice.PaidAmount == 0 && invoice.PaymentStatus != "paid"
+}
+
+func cancelInvoice(tx *gorm.DB, invoice *models.Invoice, reason string) error {
+	notes := reason
+	if invoice.Notes != "" {
+		notes = invoice.Notes + "\n" + reason
+	}
+	if err := tx.Model(invoice).Updates(map[string]interface{}{
+		"payment_status": "cancelled",
+		"notes":          notes,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to cancel invoice %s: %w", invoice.InvoiceNumber, err)
+	}
+	return nil
+}
+
+func periodKey(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
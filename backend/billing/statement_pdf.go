@@ -0,0 +1,145 @@
+package billing
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	statementDateLayout = "02 Jan 2006"
+	statementLineHeight = 6.0
+)
+
+// RenderTenantStatementPDF renders a tenant statement as an A4 PDF
+func RenderTenantStatementPDF(statement TenantStatement) ([]byte, error) {
+	pdf := newStatementPDF("Tenant Statement")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, statementLineHeight, tr(fmt.Sprintf("Tenant: %s %s (%s)",
+		statement.Tenant.FirstName, statement.Tenant.LastName, statement.Tenant.Email)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, statementLineHeight, fmt.Sprintf("Period: %s to %s",
+		statement.From.Format(statementDateLayout), statement.To.Format(statementDateLayout)), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	widths := []float64{25, 42, 63, 20, 20, 20}
+	statementTableHeader(pdf, widths, "Date", "Reference", "Description", "Debit", "Credit", "Balance")
+
+	pdf.SetFont("Helvetica", "I", 9)
+	pdf.CellFormat(widths[0]+widths[1]+widths[2]+widths[3]+widths[4], statementLineHeight, "Opening balance", "1", 0, "L", false, 0, "")
+	pdf.CellFormat(widths[5], statementLineHeight, formatAmount(statement.OpeningBalance), "1", 1, "R", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, line := range statement.Lines {
+		pdf.CellFormat(widths[0], statementLineHeight, line.Date.Format(statementDateLayout), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], statementLineHeight, tr(line.Reference), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], statementLineHeight, tr(line.Description), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], statementLineHeight, formatOptionalAmount(line.Debit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], statementLineHeight, formatOptionalAmount(line.Credit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], statementLineHeight, formatAmount(line.Balance), "1", 1, "R", false, 0, "")
+	}
+
+	pdf.Ln(4)
+	statementTotal(pdf, "Total invoiced", statement.TotalInvoiced)
+	statementTotal(pdf, "Total paid", statement.TotalPaid)
+	pdf.SetFont("Helvetica", "B", 10)
+	statementTotal(pdf, "Closing balance", statement.ClosingBalance)
+
+	return outputStatementPDF(pdf)
+}
+
+// RenderOwnerStatementPDF renders a landlord owner statement as an A4 PDF
+func RenderOwnerStatementPDF(statement OwnerStatement) ([]byte, error) {
+	pdf := newStatementPDF("Owner Statement")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, statementLineHeight, tr(fmt.Sprintf("Landlord: %s %s (%s)",
+		statement.Landlord.FirstName, statement.Landlord.LastName, statement.Landlord.Email)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, statementLineHeight, fmt.Sprintf("Period: %s to %s",
+		statement.From.Format(statementDateLayout), statement.To.Format(statementDateLayout)), "", 1, "L", false, 0, "")
+
+	for _, property := range statement.Properties {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, statementLineHeight+1, tr(fmt.Sprintf("%s - %s", property.Name, property.Address)), "", 1, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "", 9)
+		statementTotal(pdf, "Invoiced", property.Invoiced)
+		statementTotal(pdf, "Income collected", property.Income)
+
+		categories := make([]string, 0, len(property.ExpensesByCategory))
+		for category := range property.ExpensesByCategory {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			statementTotal(pdf, "Expenses - "+category, -property.ExpensesByCategory[category])
+		}
+
+		pdf.SetFont("Helvetica", "B", 9)
+		statementTotal(pdf, "Net", property.Net)
+	}
+
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "", 10)
+	statementTotal(pdf, "Total income", statement.TotalIncome)
+	statementTotal(pdf, "Total expenses", -statement.TotalExpenses)
+	pdf.SetFont("Helvetica", "B", 11)
+	statementTotal(pdf, "Net to owner", statement.NetToOwner)
+
+	return outputStatementPDF(pdf)
+}
+
+func newStatementPDF(title string) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(title, false)
+	pdf.SetCreator("Property Manager", false)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+	return pdf
+}
+
+func statementTableHeader(pdf *gofpdf.Fpdf, widths []float64, headings ...string) {
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for i, heading := range headings {
+		align := "L"
+		if i >= 3 {
+			align = "R"
+		}
+		pdf.CellFormat(widths[i], statementLineHeight+1, heading, "1", 0, align, true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+func statementTotal(pdf *gofpdf.Fpdf, label string, amount float64) {
+	pdf.CellFormat(150, statementLineHeight, label, "", 0, "L", false, 0, "")
+	pdf.CellFormat(30, statementLineHeight, formatAmount(amount), "", 1, "R", false, 0, "")
+}
+
+func outputStatementPDF(pdf *gofpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render statement: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func formatOptionalAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+	return formatAmount(amount)
+}
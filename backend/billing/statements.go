@@ -0,0 +1,221 @@
+package billing
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+)
+
+// StatementLine is one dated movement on a tenant statement. Debits increase
+// what the tenant owes, credits reduce it.
+type StatementLine struct {
+	Date        time.Time `json:"date"`
+	Reference   string    `json:"reference"`
+	Description string    `json:"description"`
+	Debit       float64   `json:"debit"`
+	Credit      float64   `json:"credit"`
+	Balance     float64   `json:"balance"`
+}
+
+// TenantStatement is a tenant's account for a period
+type TenantStatement struct {
+	Tenant         models.UserResponse `json:"tenant"`
+	From           time.Time           `json:"from"`
+	To             time.Time           `json:"to"`
+	OpeningBalance float64             `json:"opening_balance"`
+	TotalInvoiced  float64             `json:"total_invoiced"`
+	TotalPaid      float64             `json:"total_paid"`
+	ClosingBalance float64             `json:"closing_balance"`
+	Lines          []StatementLine     `json:"lines"`
+}
+
+// PropertyStatement is the income and expenses of one property for a period
+type PropertyStatement struct {
+	PropertyID         uint               `json:"property_id"`
+	Name               string             `json:"name"`
+	Address            string             `json:"address"`
+	Invoiced           float64            `json:"invoiced"`
+	Income             float64            `json:"income"`
+	ExpensesByCategory map[string]float64 `json:"expenses_by_category"`
+	TotalExpenses      float64            `json:"total_expenses"`
+	Net                float64            `json:"net"`
+}
+
+// OwnerStatement is a landlord's income minus expenses across their properties
+type OwnerStatement struct {
+	Landlord      models.UserResponse `json:"landlord"`
+	From          time.Time           `json:"from"`
+	To            time.Time           `json:"to"`
+	Properties    []PropertyStatement `json:"properties"`
+	TotalIncome   float64             `json:"total_income"`
+	TotalExpenses float64             `json:"total_expenses"`
+	NetToOwner    float64             `json:"net_to_owner"`
+}
+
+// BuildTenantStatement orders the invoices and ledger entries of a period
+// into statement lines with a running balance starting from opening
+func BuildTenantStatement(opening float64, invoices []models.Invoice, payments []models.Payment) TenantStatement {
+	statement := TenantStatement{OpeningBalance: roundCurrency(opening), Lines: []StatementLine{}}
+
+	for _, invoice := range invoices {
+		statement.Lines = append(statement.Lines, StatementLine{
+			Date:        invoice.InvoiceDate,
+			Reference:   invoice.InvoiceNumber,
+			Description: invoiceDescription(invoice),
+			Debit:       invoice.Amount,
+		})
+		statement.TotalInvoiced += invoice.Amount
+	}
+	for _, payment := range payments {
+		line := StatementLine{Date: payment.PaymentDate, Reference: payment.ReceiptNumber}
+		if payment.IsRefund() {
+			line.Description = "Refund (" + payment.Method + ")"
+			line.Debit = payment.Amount
+			statement.TotalPaid -= payment.Amount
+		} else {
+			line.Description = "Payment (" + payment.Method + ")"
+			line.Credit = payment.Amount
+			statement.TotalPaid += payment.Amount
+		}
+		statement.Lines = append(statement.Lines, line)
+	}
+
+	// Charges come before payments on the same day
+	sort.SliceStable(statement.Lines, func(i, j int) bool {
+		if !statement.Lines[i].Date.Equal(statement.Lines[j].Date) {
+			return statement.Lines[i].Date.Before(statement.Lines[j].Date)
+		}
+		return statement.Lines[i].Debit > 0 && statement.Lines[j].Debit == 0
+	})
+
+	balance := statement.OpeningBalance
+	for i := range statement.Lines {
+		balance = roundCurrency(balance + statement.Lines[i].Debit - statement.Lines[i].Credit)
+		statement.Lines[i].Balance = balance
+	}
+	statement.TotalInvoiced = roundCurrency(statement.TotalInvoiced)
+	statement.TotalPaid = roundCurrency(statement.TotalPaid)
+	statement.ClosingBalance = balance
+	return statement
+}
+
+// TenantStatementFor loads a tenant's invoices and payments and builds their
+// statement for the inclusive period [from, to]
+func TenantStatementFor(database *gorm.DB, tenant models.User, from, to time.Time) (*TenantStatement, error) {
+	from, end := truncateDay(from), truncateDay(to).AddDate(0, 0, 1)
+
+	var invoicedBefore, paidBefore, refundedBefore float64
+	if err := database.Model(&models.Invoice{}).Select("COALESCE(SUM(amount), 0)").
+		Where("tenant_id = ? AND invoice_date < ? AND payment_status <> ? AND deleted_at IS NULL", tenant.ID, from, "cancelled").
+		Scan(&invoicedBefore).Error; err != nil {
+		return nil, fmt.Errorf("failed to total earlier invoices: %w", err)
+	}
+	for paymentType, total := range map[string]*float64{"payment": &paidBefore, "refund": &refundedBefore} {
+		if err := database.Model(&models.Payment{}).Select("COALESCE(SUM(amount), 0)").
+			Where("tenant_id = ? AND type = ? AND payment_date < ? AND deleted_at IS NULL", tenant.ID, paymentType, from).
+			Scan(total).Error; err != nil {
+			return nil, fmt.Errorf("failed to total earlier payments: %w", err)
+		}
+	}
+
+	var invoices []models.Invoice
+	if err := database.Where("tenant_id = ? AND invoice_date >= ? AND invoice_date < ? AND payment_status <> ? AND deleted_at IS NULL",
+		tenant.ID, from, end, "cancelled").
+		Order("invoice_date, id").Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("failed to load invoices: %w", err)
+	}
+
+	var payments []models.Payment
+	if err := database.Where("tenant_id = ? AND payment_date >= ? AND payment_date < ? AND deleted_at IS NULL", tenant.ID, from, end).
+		Order("payment_date, id").Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to load payments: %w", err)
+	}
+
+	statement := BuildTenantStatement(invoicedBefore-paidBefore+refundedBefore, invoices, payments)
+	statement.Tenant = tenant.ToResponse()
+	statement.From = from
+	statement.To = truncateDay(to)
+	return &statement, nil
+}
+
+// OwnerStatementFor builds a landlord's statement for the inclusive period
+// [from, to]. Income is what was collected in the period, net of refunds.
+func OwnerStatementFor(database *gorm.DB, landlord models.User, from, to time.Time) (*OwnerStatement, error) {
+	from, end := truncateDay(from), truncateDay(to).AddDate(0, 0, 1)
+	statement := &OwnerStatement{
+		Landlord:   landlord.ToResponse(),
+		From:       from,
+		To:         truncateDay(to),
+		Properties: []PropertyStatement{},
+	}
+
+	var properties []models.Property
+	if err := database.Where("owner_id = ?", landlord.ID).Order("id").Find(&properties).Error; err != nil {
+		return nil, fmt.Errorf("failed to load properties: %w", err)
+	}
+
+	for _, property := range properties {
+		line := PropertyStatement{
+			PropertyID:         property.ID,
+			Name:               property.Name,
+			Address:            property.Address,
+			ExpensesByCategory: map[string]float64{},
+		}
+
+		if err := database.Model(&models.Invoice{}).Select("COALESCE(SUM(amount), 0)").
+			Where("property_id = ? AND invoice_date >= ? AND invoice_date < ? AND payment_status <> ? AND deleted_at IS NULL",
+				property.ID, from, end, "cancelled").
+			Scan(&line.Invoiced).Error; err != nil {
+			return nil, fmt.Errorf("failed to total invoices: %w", err)
+		}
+
+		if err := database.Model(&models.PaymentAllocation{}).
+			Select("COALESCE(SUM(CASE WHEN payments.type = 'refund' THEN -payment_allocations.amount ELSE payment_allocations.amount END), 0)").
+			Joins("JOIN payments ON payments.id = payment_allocations.payment_id").
+			Joins("JOIN invoices ON invoices.id = payment_allocations.invoice_id").
+			Where("invoices.property_id = ? AND payments.payment_date >= ? AND payments.payment_date < ? AND payments.deleted_at IS NULL",
+				property.ID, from, end).
+			Scan(&line.Income).Error; err != nil {
+			return nil, fmt.Errorf("failed to total income: %w", err)
+		}
+
+		var expenses []struct {
+			Category string
+			Total    float64
+		}
+		if err := database.Model(&models.Expense{}).Select("category, COALESCE(SUM(amount), 0) AS total").
+			Where("property_id = ? AND expense_date >= ? AND expense_date < ? AND deleted_at IS NULL", property.ID, from, end).
+			Group("category").Scan(&expenses).Error; err != nil {
+			return nil, fmt.Errorf("failed to total expenses: %w", err)
+		}
+		for _, expense := range expenses {
+			line.ExpensesByCategory[expense.Category] = roundCurrency(expense.Total)
+			line.TotalExpenses += expense.Total
+		}
+
+		line.Invoiced = roundCurrency(line.Invoiced)
+		line.Income = roundCurrency(line.Income)
+		line.TotalExpenses = roundCurrency(line.TotalExpenses)
+		line.Net = roundCurrency(line.Income - line.TotalExpenses)
+
+		statement.Properties = append(statement.Properties, line)
+		statement.TotalIncome += line.Income
+		statement.TotalExpenses += line.TotalExpenses
+	}
+
+	statement.TotalIncome = roundCurrency(statement.TotalIncome)
+	statement.TotalExpenses = roundCurrency(statement.TotalExpenses)
+	statement.NetToOwner = roundCurrency(statement.TotalIncome - statement.TotalExpenses)
+	return statement, nil
+}
+
+func invoiceDescription(invoice models.Invoice) string {
+	description := "Invoice - " + invoice.Category
+	if invoice.PeriodStart != nil && invoice.PeriodEnd != nil {
+		description += fmt.Sprintf(" (%s to %s)", invoice.PeriodStart.Format("02 Jan 2006"), invoice.PeriodEnd.Format("02 Jan 2006"))
+	}
+	return description
+}
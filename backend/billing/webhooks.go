@@ -0,0 +1,13 @@
+package billing
+
+import (
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/webhooks"
+	"gorm.io/gorm"
+)
+
+// PublishInvoice queues an invoice event for the webhook endpoints subscribed
+// to it
+func PublishInvoice(tx *gorm.DB, eventType string, invoice models.Invoice) error {
+	return webhooks.Publish(tx, eventType, invoice.PropertyID, webhooks.InvoiceData(invoice))
+}
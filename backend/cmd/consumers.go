@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/events"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+	"gorm.io/gorm"
+)
+
+// registerConsumers wires the handlers of the domain events read from Kafka
+func registerConsumers() {
+	// Let the maintenance team and the landlord know about new requests
+	events.Handle(events.MaintenanceCreated, func(ctx context.Context, event *events.Envelope) error {
+		var data events.MaintenanceV1
+		if err := event.Decode(&data); err != nil {
+			return err
+		}
+
+		var maintenance models.Maintenance
+		if err := db.DB.WithContext(ctx).First(&maintenance, data.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Deleted since; there is no one left to tell
+				return nil
+			}
+			return err
+		}
+		return servicing.NotifyMaintenance(db.DB.WithContext(ctx), models.EventMaintenanceCreated, &maintenance)
+	})
+}
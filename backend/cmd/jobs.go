@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/events"
+	"github.com/geoo115/property-manager/leasing"
+	"github.com/geoo115/property-manager/notifications"
+	"github.com/geoo115/property-manager/scheduler"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/geoo115/property-manager/webhooks"
+)
+
+// registerJobs wires the background jobs run by the scheduler
+func registerJobs(s *scheduler.Scheduler, cfg *config.Config) {
+	s.Register(scheduler.Job{
+		Name:     "recurring-invoices",
+		Interval: cfg.Scheduler.RecurringInvoiceInterval,
+		Run: func(ctx context.Context) error {
+			_, err := billing.GenerateRecurringInvoices(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+
+	s.Register(scheduler.Job{
+		Name:     "late-fees",
+		Interval: cfg.Scheduler.LateFeeInterval,
+		Run: func(ctx context.Context) error {
+			_, err := billing.ApplyLateFees(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+
+	s.Register(scheduler.Job{
+		Name:     "lease-lifecycle",
+		Interval: cfg.Scheduler.LeaseLifecycleInterval,
+		Run: func(ctx context.Context) error {
+			_, err := leasing.RunLifecycle(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+
+	notify := servicing.EmailNotifier(cfg.Email)
+	s.Register(scheduler.Job{
+		Name:     "maintenance-sla",
+		Interval: cfg.Scheduler.MaintenanceSLAInterval,
+		Run: func(ctx context.Context) error {
+			_, err := servicing.CheckSLAs(db.DB.WithContext(ctx), time.Now().UTC(), notify)
+			return err
+		},
+	})
+
+	s.Register(scheduler.Job{
+		Name:     "notification-delivery",
+		Interval: cfg.Scheduler.NotificationInterval,
+		Run: func(ctx context.Context) error {
+			_, err := notifications.Deliver(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+
+	s.Register(scheduler.Job{
+		Name:     "notification-reminders",
+		Interval: cfg.Scheduler.ReminderInterval,
+		Run: func(ctx context.Context) error {
+			_, err := notifications.QueueReminders(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+
+	s.Register(scheduler.Job{
+		Name:     "webhook-delivery",
+		Interval: cfg.Scheduler.WebhookInterval,
+		Run: func(ctx context.Context) error {
+			_, err := webhooks.Deliver(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+
+	s.Register(scheduler.Job{
+		Name:     "outbox-relay",
+		Interval: cfg.Scheduler.OutboxInterval,
+		Run: func(ctx context.Context) error {
+			_, err := events.Relay(db.DB.WithContext(ctx), time.Now().UTC())
+			return err
+		},
+	})
+}
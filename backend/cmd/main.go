@@ -13,8 +13,15 @@ import (
 	"github.com/geoo115/property-manager/config"
 	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/events"
+	"github.com/geoo115/property-manager/health"
 	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/metrics"
+	"github.com/geoo115/property-manager/notifications"
 	"github.com/geoo115/property-manager/router"
+	"github.com/geoo115/property-manager/scheduler"
+	"github.com/geoo115/property-manager/servicing"
+	"github.com/geoo115/property-manager/storage"
+	"github.com/geoo115/property-manager/webhooks"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -63,6 +70,30 @@ func main() {
 		log.Fatalf("Database initialization failed: %v", err)
 	}
 
+	// Publish domain events for every write to the aggregates in the catalogue
+	if err := events.Register(db.DB); err != nil {
+		logger.LogError(err, "Failed to register domain events", nil)
+		log.Fatalf("Domain event registration failed: %v", err)
+	}
+
+	// Initialize file storage for uploads
+	if err := storage.Init(cfg); err != nil {
+		logger.LogError(err, "Failed to initialize file storage", nil)
+		log.Fatalf("File storage initialization failed: %v", err)
+	}
+
+	// Apply the configured maintenance SLA targets
+	servicing.Init(cfg)
+
+	// Register the notification channels and relay new in-app notifications
+	// to open streams
+	notifications.Init(cfg)
+	inboxCtx, stopInbox := context.WithCancel(context.Background())
+	go notifications.ListenInbox(inboxCtx, db.DB)
+
+	// Apply the webhook delivery settings
+	webhooks.Init(cfg)
+
 	// Initialize Kafka
 	if err := events.InitKafka(cfg); err != nil {
 		logger.LogError(err, "Failed to initialize Kafka", nil)
@@ -71,12 +102,20 @@ func main() {
 
 	// Start Kafka Consumer in a separate goroutine
 	logger.LogInfo("Starting Kafka Consumer", nil)
+	registerConsumers()
 	go func() {
 		if err := events.StartKafkaConsumer(cfg); err != nil {
 			logger.LogError(err, "Kafka consumer failed", nil)
 		}
 	}()
 
+	// Start background jobs
+	jobScheduler := scheduler.New()
+	if cfg.Scheduler.Enabled {
+		registerJobs(jobScheduler, cfg)
+		jobScheduler.Start(context.Background())
+	}
+
 	// Setup Gin router
 	r := gin.New()
 
@@ -107,6 +146,8 @@ func main() {
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
 	}
+	// Notification streams never finish on their own
+	srv.RegisterOnShutdown(notifications.CloseStreams)
 
 	// Start server in a goroutine
 	go func() {
@@ -121,6 +162,21 @@ func main() {
 		}
 	}()
 
+	// Serve Prometheus metrics on their own port
+	var metricsSrv *http.Server
+	if cfg.Monitoring.EnableMetrics {
+		metricsSrv = metrics.NewServer(cfg.Monitoring.MetricsPort)
+		go func() {
+			logger.LogInfo("Metrics server starting", logrus.Fields{
+				"address": metricsSrv.Addr,
+			})
+
+			if err := metricsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.LogError(err, "Metrics server failed", nil)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -128,6 +184,13 @@ func main() {
 
 	logger.LogInfo("Server shutting down...", nil)
 
+	// Fail readiness first and keep serving while probes notice, so load
+	// balancers stop sending new requests before the listener closes
+	logger.LogInfo("Draining before shutdown", logrus.Fields{
+		"drain_delay": cfg.Server.DrainDelay.String(),
+	})
+	health.Drain(context.Background(), cfg.Server.DrainDelay)
+
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -138,6 +201,14 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	if err := metrics.Shutdown(ctx, metricsSrv); err != nil {
+		logger.LogError(err, "Failed to stop metrics server", nil)
+	}
+
+	// Stop background jobs before closing their dependencies
+	jobScheduler.Stop()
+	stopInbox()
+
 	// Close database connection
 	if err := db.Close(); err != nil {
 		logger.LogError(err, "Failed to close database connection", nil)
@@ -0,0 +1,138 @@
+// Command migrate manages the database schema.
+//
+//	go run ./cmd/migrate up              apply all pending migrations
+//	go run ./cmd/migrate down [-steps N] revert the last N migrations (default 1)
+//	go run ./cmd/migrate status          list migrations and whether they are applied
+//	go run ./cmd/migrate create NAME     add an empty up/down pair to db/migrations
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/migrate"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [-steps N] revert the last N migrations (default 1)
+  status          list migrations and whether they are applied
+  create NAME     add an empty up/down pair to db/migrations
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	if err := run(command, args); err != nil {
+		fmt.Fprintf(os.Stderr, "migrate %s: %v\n", command, err)
+		os.Exit(1)
+	}
+}
+
+func run(command string, args []string) error {
+	// create only writes files, so it must work without a database
+	if command == "create" {
+		fs := flag.NewFlagSet("create", flag.ExitOnError)
+		dir := fs.String("dir", db.MigrationsDir, "directory holding the migration files")
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			return fmt.Errorf("expected a single migration name")
+		}
+
+		upPath, downPath, err := migrate.Create(*dir, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		fmt.Println("created", upPath)
+		fmt.Println("created", downPath)
+		return nil
+	}
+
+	migrator, err := connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Println("applied", m)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("database is up to date")
+		}
+		return err
+
+	case "down":
+		fs := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := fs.Int("steps", 1, "number of migrations to revert")
+		fs.Parse(args)
+
+		reverted, err := migrator.Down(ctx, *steps)
+		for _, m := range reverted {
+			fmt.Println("reverted", m)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command")
+	}
+}
+
+func connect() (*migrate.Migrator, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	logger.InitLogger()
+
+	if err := db.Connect(cfg); err != nil {
+		return nil, err
+	}
+	return db.NewMigrator()
+}
+
+func printStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTE")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		note := ""
+		switch {
+		case s.Missing:
+			note = "not shipped with this binary"
+		case s.Modified:
+			note = "modified after being applied"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, appliedAt, note)
+	}
+	w.Flush()
+}
@@ -41,6 +41,24 @@ type Config struct {
 
 	// Monitoring Configuration
 	Monitoring MonitoringConfig
+
+	// Scheduler Configuration
+	Scheduler SchedulerConfig
+
+	// Maintenance SLA Configuration
+	MaintenanceSLA MaintenanceSLAConfig
+
+	// Technician Scheduling Configuration
+	Scheduling SchedulingConfig
+
+	// Notification Configuration
+	Notifications NotificationConfig
+
+	// Outbound Webhook Configuration
+	Webhooks WebhookConfig
+
+	// Event Outbox Configuration
+	Outbox OutboxConfig
 }
 
 type DatabaseConfig struct {
@@ -51,6 +69,8 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	SSLMode  string
+	// MigrateOnStart applies pending schema migrations during db.Init
+	MigrateOnStart bool
 }
 
 type RedisConfig struct {
@@ -69,6 +89,9 @@ type ServerConfig struct {
 	Host    string
 	Port    int
 	GinMode string
+	// DrainDelay is how long readiness fails before the listener closes on
+	// shutdown, so load balancers see it; about twice the probe period
+	DrainDelay time.Duration
 }
 
 type KafkaConfig struct {
@@ -77,11 +100,11 @@ type KafkaConfig struct {
 }
 
 type EmailConfig struct {
-	SMTPHost             string
-	SMTPPort             int
-	SMTPUser             string
-	SMTPPass             string
-	MaintenanceTeamEmail string
+	SMTPHost string
+	SMTPPort int
+	SMTPUser string // Leave empty for relays that need no authentication
+	SMTPPass string
+	From     string // Sender address; defaults to SMTPUser
 }
 
 type RateLimitConfig struct {
@@ -92,6 +115,20 @@ type RateLimitConfig struct {
 type FileUploadConfig struct {
 	MaxFileSize int64
 	UploadPath  string
+	Backend     string        // "local" or "s3"
+	SigningKey  string        // Signs download URLs; required in release mode, else derived from the JWT secret
+	URLExpiry   time.Duration // Lifetime of signed download URLs
+	S3          S3Config
+}
+
+// S3Config points the "s3" upload backend at any S3-compatible service
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
 }
 
 type SecurityConfig struct {
@@ -105,6 +142,57 @@ type MonitoringConfig struct {
 	MetricsPort   int
 }
 
+type SchedulerConfig struct {
+	Enabled                  bool
+	RecurringInvoiceInterval time.Duration
+	LateFeeInterval          time.Duration
+	LeaseLifecycleInterval   time.Duration
+	MaintenanceSLAInterval   time.Duration
+	NotificationInterval     time.Duration // How often queued notifications are delivered
+	ReminderInterval         time.Duration // How often lease expiry and invoice due reminders are queued
+	WebhookInterval          time.Duration // How often queued webhook deliveries are sent
+	OutboxInterval           time.Duration // How often outbox events are relayed to Kafka
+}
+
+// MaintenanceSLAConfig holds the acknowledgement and resolution targets for
+// maintenance requests, keyed by priority
+type MaintenanceSLAConfig struct {
+	Acknowledge map[string]time.Duration
+	Resolve     map[string]time.Duration
+}
+
+// SchedulingConfig holds the settings for booking technician appointments
+type SchedulingConfig struct {
+	Timezone           string        // Time zone working hours are given in
+	DefaultAppointment time.Duration // Length of appointments booked without an end
+}
+
+// NotificationConfig holds the settings for queued notification delivery
+type NotificationConfig struct {
+	MaxAttempts       int           // Deliveries are given up after this many failures
+	RetryBackoff      time.Duration // Wait before the first retry; doubles on each failure
+	LeaseExpiryNotice time.Duration // How long before a lease ends its parties are reminded
+	InvoiceDueNotice  time.Duration // How long before an invoice is due the tenant is reminded
+	WebhookTimeout    time.Duration
+	SMSProvider       string // Only "log" is built in
+}
+
+// WebhookConfig holds the settings for delivering events to registered
+// webhook endpoints
+type WebhookConfig struct {
+	MaxAttempts         int           // A delivery is given up after this many failures
+	RetryBackoff        time.Duration // Wait before the first retry; doubles on each failure
+	Timeout             time.Duration
+	DisableAfter        int  // An endpoint is disabled after this many failed attempts in a row
+	AllowPrivateTargets bool // Allow endpoints on loopback and private addresses
+}
+
+// OutboxConfig controls how events written to the outbox are relayed to Kafka
+type OutboxConfig struct {
+	BatchSize int           // Events relayed per run
+	Retention time.Duration // Relayed events are deleted after this long
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	// Load .env file if it exists
@@ -118,13 +206,14 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		Database: DatabaseConfig{
-			Type:     getEnv("DB_TYPE", "postgres"),
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnvInt("DB_PORT", 5432),
-			Name:     getEnv("DB_NAME", "property_management"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", ""),
-			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+			Type:           getEnv("DB_TYPE", "postgres"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnvInt("DB_PORT", 5432),
+			Name:           getEnv("DB_NAME", "property_management"),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", ""),
+			SSLMode:        getEnv("DB_SSL_MODE", "disable"),
+			MigrateOnStart: getEnvBool("DB_MIGRATE_ON_START", true),
 		},
 		Redis: RedisConfig{
 			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
@@ -137,20 +226,21 @@ func LoadConfig() (*Config, error) {
 			RefreshTokenDuration: getEnvDuration("JWT_REFRESH_TOKEN_DURATION", 24*time.Hour),
 		},
 		Server: ServerConfig{
-			Host:    getEnv("SERVER_HOST", "localhost"),
-			Port:    getEnvInt("SERVER_PORT", 8080),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Host:       getEnv("SERVER_HOST", "localhost"),
+			Port:       getEnvInt("SERVER_PORT", 8080),
+			GinMode:    getEnv("GIN_MODE", "debug"),
+			DrainDelay: getEnvDuration("SHUTDOWN_DRAIN_DELAY", 10*time.Second),
 		},
 		Kafka: KafkaConfig{
 			Broker: getEnv("KAFKA_BROKER", "localhost:9092"),
 			Topic:  getEnv("KAFKA_TOPIC", "property-events"),
 		},
 		Email: EmailConfig{
-			SMTPHost:             getEnv("SMTP_HOST", "smtp.gmail.com"),
-			SMTPPort:             getEnvInt("SMTP_PORT", 587),
-			SMTPUser:             getEnv("SMTP_USER", ""),
-			SMTPPass:             getEnv("SMTP_PASS", ""),
-			MaintenanceTeamEmail: getEnv("MAINTENANCE_TEAM_EMAIL", "maintenance@yourcompany.com"),
+			SMTPHost: getEnv("SMTP_HOST", "smtp.gmail.com"),
+			SMTPPort: getEnvInt("SMTP_PORT", 587),
+			SMTPUser: getEnv("SMTP_USER", ""),
+			SMTPPass: getEnv("SMTP_PASS", ""),
+			From:     getEnv("SMTP_FROM", ""),
 		},
 		RateLimit: RateLimitConfig{
 			Requests: getEnvInt("RATE_LIMIT_REQUESTS", 100),
@@ -159,6 +249,17 @@ func LoadConfig() (*Config, error) {
 		FileUpload: FileUploadConfig{
 			MaxFileSize: getEnvInt64("MAX_FILE_SIZE", 10*1024*1024), // 10MB
 			UploadPath:  getEnv("UPLOAD_PATH", "./uploads"),
+			Backend:     getEnv("UPLOAD_BACKEND", "local"),
+			SigningKey:  getEnv("UPLOAD_SIGNING_KEY", ""),
+			URLExpiry:   getEnvDuration("UPLOAD_URL_EXPIRY", 15*time.Minute),
+			S3: S3Config{
+				Endpoint:  getEnv("S3_ENDPOINT", "localhost:9000"),
+				Bucket:    getEnv("S3_BUCKET", "property-manager"),
+				Region:    getEnv("S3_REGION", "us-east-1"),
+				AccessKey: getEnv("S3_ACCESS_KEY", ""),
+				SecretKey: getEnv("S3_SECRET_KEY", ""),
+				UseSSL:    getEnvBool("S3_USE_SSL", false),
+			},
 		},
 		Security: SecurityConfig{
 			BcryptCost:    getEnvInt("BCRYPT_COST", 12),
@@ -169,6 +270,54 @@ func LoadConfig() (*Config, error) {
 			EnableMetrics: getEnvBool("ENABLE_METRICS", true),
 			MetricsPort:   getEnvInt("METRICS_PORT", 9090),
 		},
+		Scheduler: SchedulerConfig{
+			Enabled:                  getEnvBool("SCHEDULER_ENABLED", true),
+			RecurringInvoiceInterval: getEnvDuration("RECURRING_INVOICE_INTERVAL", time.Hour),
+			LateFeeInterval:          getEnvDuration("LATE_FEE_INTERVAL", 6*time.Hour),
+			LeaseLifecycleInterval:   getEnvDuration("LEASE_LIFECYCLE_INTERVAL", 24*time.Hour),
+			MaintenanceSLAInterval:   getEnvDuration("MAINTENANCE_SLA_INTERVAL", 15*time.Minute),
+			NotificationInterval:     getEnvDuration("NOTIFICATION_INTERVAL", 30*time.Second),
+			ReminderInterval:         getEnvDuration("REMINDER_INTERVAL", time.Hour),
+			WebhookInterval:          getEnvDuration("WEBHOOK_INTERVAL", 30*time.Second),
+			OutboxInterval:           getEnvDuration("OUTBOX_INTERVAL", 5*time.Second),
+		},
+		MaintenanceSLA: MaintenanceSLAConfig{
+			Acknowledge: map[string]time.Duration{
+				"urgent": getEnvDuration("SLA_ACK_URGENT", time.Hour),
+				"high":   getEnvDuration("SLA_ACK_HIGH", 4*time.Hour),
+				"medium": getEnvDuration("SLA_ACK_MEDIUM", 24*time.Hour),
+				"low":    getEnvDuration("SLA_ACK_LOW", 72*time.Hour),
+			},
+			Resolve: map[string]time.Duration{
+				"urgent": getEnvDuration("SLA_RESOLVE_URGENT", 24*time.Hour),
+				"high":   getEnvDuration("SLA_RESOLVE_HIGH", 72*time.Hour),
+				"medium": getEnvDuration("SLA_RESOLVE_MEDIUM", 7*24*time.Hour),
+				"low":    getEnvDuration("SLA_RESOLVE_LOW", 14*24*time.Hour),
+			},
+		},
+		Scheduling: SchedulingConfig{
+			Timezone:           getEnv("SCHEDULE_TIMEZONE", "UTC"),
+			DefaultAppointment: getEnvDuration("DEFAULT_APPOINTMENT_DURATION", 2*time.Hour),
+		},
+		Notifications: NotificationConfig{
+			MaxAttempts:       getEnvInt("NOTIFICATION_MAX_ATTEMPTS", 5),
+			RetryBackoff:      getEnvDuration("NOTIFICATION_RETRY_BACKOFF", time.Minute),
+			LeaseExpiryNotice: getEnvDuration("LEASE_EXPIRY_NOTICE", 30*24*time.Hour),
+			InvoiceDueNotice:  getEnvDuration("INVOICE_DUE_NOTICE", 3*24*time.Hour),
+			WebhookTimeout:    getEnvDuration("NOTIFICATION_WEBHOOK_TIMEOUT", 10*time.Second),
+			SMSProvider:       getEnv("SMS_PROVIDER", "log"),
+		},
+		Webhooks: WebhookConfig{
+			MaxAttempts:         getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+			RetryBackoff:        getEnvDuration("WEBHOOK_RETRY_BACKOFF", time.Minute),
+			Timeout:             getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			DisableAfter:        getEnvInt("WEBHOOK_DISABLE_AFTER", 15),
+			AllowPrivateTargets: getEnvBool("WEBHOOK_ALLOW_PRIVATE_TARGETS", false),
+		},
+		Outbox: OutboxConfig{
+			BatchSize: getEnvInt("OUTBOX_BATCH_SIZE", 100),
+			Retention: getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+		},
 	}
 
 	return config, nil
@@ -1,13 +1,14 @@
 package db
 
 import (
+	"context"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/geoo115/property-manager/audit"
 	"github.com/geoo115/property-manager/config"
 	"github.com/geoo115/property-manager/logger"
-	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/metrics"
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -17,6 +18,41 @@ import (
 var DB *gorm.DB
 
 func Init(cfg *config.Config) error {
+	if err := Connect(cfg); err != nil {
+		return err
+	}
+
+	// Record a before/after trail of writes to the core tables
+	if err := audit.Register(DB); err != nil {
+		return fmt.Errorf("failed to register audit callbacks: %w", err)
+	}
+
+	// Time every statement for the metrics endpoint
+	if err := metrics.RegisterGORM(DB); err != nil {
+		return fmt.Errorf("failed to register query metrics: %w", err)
+	}
+
+	// Bring the schema up to date. Replicas serialise on an advisory lock,
+	// so it is safe for every instance to do this on boot.
+	if cfg.Database.MigrateOnStart {
+		if err := Migrate(context.Background()); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+
+	// Initialize Redis
+	if err := InitRedis(cfg); err != nil {
+		logger.LogError(err, "Failed to initialize Redis, continuing without it", logrus.Fields{
+			"redis_addr": cfg.Redis.Addr,
+		})
+		// Continue without Redis - rate limiting will be gracefully degraded
+	}
+
+	return nil
+}
+
+// Connect opens the PostgreSQL connection pool without touching the schema
+func Connect(cfg *config.Config) error {
 	var err error
 	var dialector gorm.Dialector
 
@@ -77,189 +113,9 @@ func Init(cfg *config.Config) error {
 		"database":      cfg.Database.Name,
 	})
 
-	// Handle pre-migration data fixes
-	if err := handlePreMigrationFixes(); err != nil {
-		return fmt.Errorf("failed to handle pre-migration fixes: %w", err)
-	}
-
-	// Auto-migrate models
-	if err := autoMigrate(); err != nil {
-		return fmt.Errorf("failed to auto-migrate: %w", err)
-	}
-
-	// Handle post-migration data fixes
-	if err := handlePostMigrationFixes(); err != nil {
-		return fmt.Errorf("failed to handle post-migration fixes: %w", err)
-	}
-
-	// Create indexes
-	if err := createIndexes(); err != nil {
-		return fmt.Errorf("failed to create indexes: %w", err)
-	}
-
-	// Initialize Redis
-	if err := InitRedis(cfg); err != nil {
-		logger.LogError(err, "Failed to initialize Redis, continuing without it", logrus.Fields{
-			"redis_addr": cfg.Redis.Addr,
-		})
-		// Continue without Redis - rate limiting will be gracefully degraded
-	}
-
 	return nil
 }
 
-func handlePreMigrationFixes() error {
-	// Fix existing users with null usernames before migration
-	if err := fixExistingUsersBeforeMigration(); err != nil {
-		return fmt.Errorf("failed to fix existing users before migration: %w", err)
-	}
-
-	logger.LogInfo("Pre-migration fixes completed", nil)
-	return nil
-}
-
-func fixExistingUsersBeforeMigration() error {
-	// Check if users table exists
-	tableExists, err := tableExists("users")
-	if err != nil {
-		return fmt.Errorf("failed to check if users table exists: %w", err)
-	}
-
-	if !tableExists {
-		// Table doesn't exist yet, nothing to fix
-		logger.LogInfo("Users table doesn't exist yet, skipping pre-migration fixes", nil)
-		return nil
-	}
-
-	// Check if users table has data
-	var count int64
-	if err := DB.Table("users").Count(&count).Error; err != nil {
-		return fmt.Errorf("failed to count users: %w", err)
-	}
-
-	if count == 0 {
-		// No users to fix
-		logger.LogInfo("No users found, skipping pre-migration fixes", nil)
-		return nil
-	}
-
-	// Check if username column exists
-	columnExists, err := columnExists("users", "username")
-	if err != nil {
-		return fmt.Errorf("failed to check if username column exists: %w", err)
-	}
-
-	if !columnExists {
-		// Username column doesn't exist, add it as nullable first
-		logger.LogInfo("Adding username column as nullable before migration", nil)
-		if err := DB.Exec("ALTER TABLE users ADD COLUMN username TEXT").Error; err != nil {
-			// If column already exists, ignore the error
-			if !strings.Contains(err.Error(), "duplicate column name") {
-				return fmt.Errorf("failed to add username column: %w", err)
-			}
-			logger.LogInfo("Username column already exists, skipping add", nil)
-		}
-	}
-
-	// Update users with NULL or empty usernames
-	// Use PostgreSQL-specific syntax for id conversion
-	updateSQL := `
-		UPDATE users 
-		SET username = COALESCE(NULLIF(username, ''), 'user_' || id::text)
-		WHERE username IS NULL OR username = ''
-	`
-
-	result := DB.Exec(updateSQL)
-	if result.Error != nil {
-		return fmt.Errorf("failed to update users with missing usernames: %w", result.Error)
-	}
-
-	if result.RowsAffected > 0 {
-		logger.LogInfo("Fixed existing users with missing usernames in pre-migration", logrus.Fields{
-			"rows_affected": result.RowsAffected,
-		})
-	}
-
-	return nil
-}
-
-func handlePostMigrationFixes() error {
-	// Additional post-migration fixes can be added here
-	// For example, data validation, cleanup, etc.
-
-	logger.LogInfo("Post-migration fixes completed", nil)
-	return nil
-}
-
-func autoMigrate() error {
-	models := []interface{}{
-		&models.User{},
-		&models.Property{},
-		&models.Unit{},
-		&models.Lease{},
-		&models.Maintenance{},
-		&models.Invoice{},
-		&models.Expense{},
-		&models.AuditLog{},
-	}
-
-	for _, model := range models {
-		if err := DB.AutoMigrate(model); err != nil {
-			return fmt.Errorf("failed to migrate %T: %w", model, err)
-		}
-	}
-
-	logger.LogInfo("Database auto-migration completed", nil)
-	return nil
-}
-
-func createIndexes() error {
-	// Create additional indexes for better query performance
-	indexes := []string{
-		"CREATE INDEX IF NOT EXISTS idx_users_email_active ON users(email, is_active);",
-		"CREATE INDEX IF NOT EXISTS idx_users_role_active ON users(role, is_active);",
-		"CREATE INDEX IF NOT EXISTS idx_properties_owner_available ON properties(owner_id, available);",
-		"CREATE INDEX IF NOT EXISTS idx_properties_city_available ON properties(city, available);",
-		"CREATE INDEX IF NOT EXISTS idx_leases_dates ON leases(start_date, end_date);",
-		"CREATE INDEX IF NOT EXISTS idx_leases_status ON leases(status);",
-		"CREATE INDEX IF NOT EXISTS idx_maintenance_status_priority ON maintenance_requests(status, priority);",
-		"CREATE INDEX IF NOT EXISTS idx_maintenance_dates ON maintenance_requests(requested_at, scheduled_at);",
-		"CREATE INDEX IF NOT EXISTS idx_invoices_status_due ON invoices(payment_status, due_date);",
-		"CREATE INDEX IF NOT EXISTS idx_invoices_dates ON invoices(invoice_date, due_date);",
-		"CREATE INDEX IF NOT EXISTS idx_expenses_date_category ON expenses(expense_date, category);",
-		"CREATE INDEX IF NOT EXISTS idx_audit_logs_user_action ON audit_logs(user_id, action);",
-		"CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);",
-	}
-
-	for _, indexSQL := range indexes {
-		if err := DB.Exec(indexSQL).Error; err != nil {
-			logger.LogWarning("Failed to create index", logrus.Fields{
-				"sql":   indexSQL,
-				"error": err.Error(),
-			})
-			// Continue with other indexes even if one fails
-		}
-	}
-
-	logger.LogInfo("Database indexes created", nil)
-	return nil
-}
-
-// Helper functions for database operations
-func tableExists(tableName string) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = ?)`
-	err := DB.Raw(query, tableName).Scan(&exists).Error
-	return exists, err
-}
-
-func columnExists(tableName, columnName string) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?)`
-	err := DB.Raw(query, tableName, columnName).Scan(&exists).Error
-	return exists, err
-}
-
 // GetDB returns the database instance
 func GetDB() *gorm.DB {
 	return DB
@@ -267,6 +123,11 @@ func GetDB() *gorm.DB {
 
 // HealthCheck checks the database connection
 func HealthCheck() error {
+	return HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext pings the database, giving up when ctx is done
+func HealthCheckContext(ctx context.Context) error {
 	if DB == nil {
 		return fmt.Errorf("database connection is nil")
 	}
@@ -276,7 +137,7 @@ func HealthCheck() error {
 		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	if err := sqlDB.Ping(); err != nil {
+	if err := sqlDB.PingContext(ctx); err != nil {
 		return fmt.Errorf("failed to ping database: %w", err)
 	}
 
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/migrate"
+	"github.com/sirupsen/logrus"
+)
+
+// MigrationsDir is where `migrate create` writes new files, relative to the
+// backend directory
+const MigrationsDir = "db/migrations"
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// NewMigrator returns a migrator for the migrations embedded in the binary
+func NewMigrator() (*migrate.Migrator, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	files, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := migrate.Load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+	return migrate.New(sqlDB, migrations), nil
+}
+
+// Migrate applies every pending migration
+func Migrate(ctx context.Context) error {
+	migrator, err := NewMigrator()
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.LogInfo("Database migrations completed", logrus.Fields{
+		"applied": len(applied),
+	})
+	return nil
+}
@@ -0,0 +1 @@
+-- The backfilled usernames are kept: they cannot be told apart from real ones.
@@ -0,0 +1,15 @@
+-- Databases created before usernames existed have users without one. Add the
+-- column if needed and backfill user_<id> so it can be made NOT NULL UNIQUE.
+-- Fresh databases have no users table yet, so there is nothing to do.
+DO $$
+BEGIN
+    IF to_regclass('users') IS NULL THEN
+        RETURN;
+    END IF;
+
+    ALTER TABLE users ADD COLUMN IF NOT EXISTS username TEXT;
+
+    UPDATE users
+    SET username = 'user_' || id::text
+    WHERE username IS NULL OR username = '';
+END $$;
@@ -0,0 +1,16 @@
+-- Drops every table in the baseline schema. All data is lost.
+
+DROP TABLE IF EXISTS "audit_logs" CASCADE;
+DROP TABLE IF EXISTS "attachments" CASCADE;
+DROP TABLE IF EXISTS "deposit_deductions" CASCADE;
+DROP TABLE IF EXISTS "deposits" CASCADE;
+DROP TABLE IF EXISTS "expenses" CASCADE;
+DROP TABLE IF EXISTS "late_fee_policies" CASCADE;
+DROP TABLE IF EXISTS "payment_allocations" CASCADE;
+DROP TABLE IF EXISTS "payments" CASCADE;
+DROP TABLE IF EXISTS "invoices" CASCADE;
+DROP TABLE IF EXISTS "maintenance_requests" CASCADE;
+DROP TABLE IF EXISTS "leases" CASCADE;
+DROP TABLE IF EXISTS "units" CASCADE;
+DROP TABLE IF EXISTS "properties" CASCADE;
+DROP TABLE IF EXISTS "users" CASCADE;
@@ -0,0 +1,388 @@
+-- Baseline schema matching the models as of the last AutoMigrate release.
+-- Every statement is guarded with IF NOT EXISTS so databases created by
+-- AutoMigrate adopt this migration without changes.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "username" text NOT NULL,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "password" text NOT NULL,
+    "email" text NOT NULL,
+    "role" text NOT NULL,
+    "phone" text NOT NULL,
+    "avatar" text,
+    "is_active" boolean DEFAULT true,
+    "last_login" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_username" UNIQUE ("username"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email"),
+    CONSTRAINT "uni_users_phone" UNIQUE ("phone"),
+    CONSTRAINT "chk_users_role" CHECK (role IN ('admin','tenant','landlord','maintenanceTeam'))
+);
+CREATE INDEX IF NOT EXISTS "idx_users_role" ON "users" ("role");
+CREATE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+CREATE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "properties" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "description" text,
+    "bedrooms" bigint NOT NULL,
+    "bathrooms" bigint NOT NULL,
+    "price" decimal NOT NULL,
+    "square_feet" bigint,
+    "address" text NOT NULL,
+    "city" text NOT NULL,
+    "state" text,
+    "post_code" text,
+    "country" text DEFAULT 'UK',
+    "property_type" text DEFAULT 'apartment',
+    "owner_id" bigint NOT NULL,
+    "available" boolean DEFAULT true,
+    "tenant_id" bigint,
+    "images" text,
+    "amenities" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_properties_tenant" FOREIGN KEY ("tenant_id") REFERENCES "users"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_users_owned_properties" FOREIGN KEY ("owner_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_properties_deleted_at" ON "properties" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_properties_tenant_id" ON "properties" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_properties_owner_id" ON "properties" ("owner_id");
+CREATE INDEX IF NOT EXISTS "idx_properties_post_code" ON "properties" ("post_code");
+CREATE INDEX IF NOT EXISTS "idx_properties_state" ON "properties" ("state");
+CREATE INDEX IF NOT EXISTS "idx_properties_city" ON "properties" ("city");
+CREATE INDEX IF NOT EXISTS "idx_properties_name" ON "properties" ("name");
+
+CREATE TABLE IF NOT EXISTS "units" (
+    "id" bigserial,
+    "property_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "price" decimal NOT NULL,
+    "available" boolean DEFAULT true,
+    "tenant_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_units_tenant" FOREIGN KEY ("tenant_id") REFERENCES "users"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_properties_units" FOREIGN KEY ("property_id") REFERENCES "properties"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_units_deleted_at" ON "units" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_units_tenant_id" ON "units" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_units_property_id" ON "units" ("property_id");
+
+CREATE TABLE IF NOT EXISTS "leases" (
+    "id" bigserial,
+    "tenant_id" bigint NOT NULL,
+    "property_id" bigint NOT NULL,
+    "unit_id" bigint,
+    "start_date" timestamp,
+    "end_date" timestamp,
+    "monthly_rent" decimal NOT NULL,
+    "security_deposit" decimal NOT NULL,
+    "status" text DEFAULT 'active',
+    "lease_type" text DEFAULT 'fixed',
+    "renewal_terms" text,
+    "special_terms" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "notice_period_days" bigint DEFAULT 30,
+    "termination_notice_date" timestamptz,
+    "termination_reason" text,
+    "previous_lease_id" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_leases_unit" FOREIGN KEY ("unit_id") REFERENCES "units"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_leases_previous_lease" FOREIGN KEY ("previous_lease_id") REFERENCES "leases"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_properties_leases" FOREIGN KEY ("property_id") REFERENCES "properties"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_users_leases" FOREIGN KEY ("tenant_id") REFERENCES "users"("id"),
+    CONSTRAINT "chk_leases_status" CHECK (status IN ('active','expired','terminated','pending')),
+    CONSTRAINT "chk_leases_lease_type" CHECK (lease_type IN ('fixed','periodic','short_term'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_leases_previous_lease_id" ON "leases" ("previous_lease_id");
+CREATE INDEX IF NOT EXISTS "idx_leases_deleted_at" ON "leases" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_leases_unit_id" ON "leases" ("unit_id");
+CREATE INDEX IF NOT EXISTS "idx_leases_property_id" ON "leases" ("property_id");
+CREATE INDEX IF NOT EXISTS "idx_leases_tenant_id" ON "leases" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "maintenance_requests" (
+    "id" bigserial,
+    "requested_by_id" bigint NOT NULL,
+    "property_id" bigint NOT NULL,
+    "lease_id" bigint,
+    "unit_id" bigint,
+    "assigned_to_id" bigint,
+    "title" text NOT NULL,
+    "description" text NOT NULL,
+    "status" text DEFAULT 'pending',
+    "priority" text DEFAULT 'medium',
+    "category" text DEFAULT 'general',
+    "estimated_cost" decimal DEFAULT 0,
+    "actual_cost" decimal DEFAULT 0,
+    "requested_at" timestamptz,
+    "scheduled_at" timestamptz,
+    "completed_at" timestamptz,
+    "notes" text,
+    "images" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_maintenance_requests_unit" FOREIGN KEY ("unit_id") REFERENCES "units"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_maintenance_requests_assigned_to" FOREIGN KEY ("assigned_to_id") REFERENCES "users"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_leases_maintenance_requests" FOREIGN KEY ("lease_id") REFERENCES "leases"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_properties_maintenance_requests" FOREIGN KEY ("property_id") REFERENCES "properties"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_users_maintenance_requests" FOREIGN KEY ("requested_by_id") REFERENCES "users"("id"),
+    CONSTRAINT "chk_maintenance_requests_priority" CHECK (priority IN ('low','medium','high','urgent')),
+    CONSTRAINT "chk_maintenance_requests_status" CHECK (status IN ('pending','in_progress','completed','cancelled')),
+    CONSTRAINT "chk_maintenance_requests_category" CHECK (category IN ('plumbing','electrical','heating','appliances','general','emergency'))
+);
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_deleted_at" ON "maintenance_requests" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_assigned_to_id" ON "maintenance_requests" ("assigned_to_id");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_unit_id" ON "maintenance_requests" ("unit_id");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_lease_id" ON "maintenance_requests" ("lease_id");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_property_id" ON "maintenance_requests" ("property_id");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_requested_by_id" ON "maintenance_requests" ("requested_by_id");
+
+CREATE TABLE IF NOT EXISTS "invoices" (
+    "id" bigserial,
+    "tenant_id" bigint NOT NULL,
+    "property_id" bigint NOT NULL,
+    "lease_id" bigint,
+    "unit_id" bigint,
+    "created_by_id" bigint NOT NULL,
+    "invoice_number" text NOT NULL,
+    "amount" decimal NOT NULL,
+    "paid_amount" decimal DEFAULT 0,
+    "invoice_date" timestamptz NOT NULL,
+    "category" text NOT NULL,
+    "due_date" timestamptz NOT NULL,
+    "period_start" timestamptz,
+    "period_end" timestamptz,
+    "payment_status" text DEFAULT 'pending',
+    "refunded_amount" decimal DEFAULT 0,
+    "recurring_interval" text,
+    "recurring" boolean DEFAULT false,
+    "recurring_parent_id" bigint,
+    "late_fee_for_id" bigint,
+    "payment_method" text,
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invoices_unit" FOREIGN KEY ("unit_id") REFERENCES "units"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_leases_invoices" FOREIGN KEY ("lease_id") REFERENCES "leases"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_properties_invoices" FOREIGN KEY ("property_id") REFERENCES "properties"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_users_invoices" FOREIGN KEY ("tenant_id") REFERENCES "users"("id"),
+    CONSTRAINT "fk_users_created_invoices" FOREIGN KEY ("created_by_id") REFERENCES "users"("id"),
+    CONSTRAINT "uni_invoices_invoice_number" UNIQUE ("invoice_number"),
+    CONSTRAINT "chk_invoices_category" CHECK (category IN ('rent','utilities','late_fee','deposit','maintenance','other')),
+    CONSTRAINT "chk_invoices_payment_status" CHECK (payment_status IN ('paid','pending','overdue','cancelled')),
+    CONSTRAINT "chk_invoices_recurring_interval" CHECK (recurring_interval IN ('','monthly','quarterly','yearly')),
+    CONSTRAINT "chk_invoices_payment_method" CHECK (payment_method IN ('','cash','bank_transfer','card','cheque'))
+);
+CREATE INDEX IF NOT EXISTS "idx_invoices_created_by_id" ON "invoices" ("created_by_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_unit_id" ON "invoices" ("unit_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_lease_id" ON "invoices" ("lease_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_property_id" ON "invoices" ("property_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_tenant_id" ON "invoices" ("tenant_id");
+CREATE INDEX IF NOT EXISTS "idx_invoices_deleted_at" ON "invoices" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoices_late_fee_for_id" ON "invoices" ("late_fee_for_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invoices_recurring_period" ON "invoices" ("invoice_date","recurring_parent_id");
+
+CREATE TABLE IF NOT EXISTS "payments" (
+    "id" bigserial,
+    "receipt_number" text NOT NULL,
+    "tenant_id" bigint NOT NULL,
+    "recorded_by_id" bigint NOT NULL,
+    "type" text NOT NULL DEFAULT 'payment',
+    "method" text NOT NULL,
+    "amount" decimal NOT NULL,
+    "unallocated_amount" decimal DEFAULT 0,
+    "payment_date" timestamptz NOT NULL,
+    "reference" text,
+    "refund_of_id" bigint,
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_payments_tenant" FOREIGN KEY ("tenant_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_payments_recorded_by" FOREIGN KEY ("recorded_by_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_payments_refund_of" FOREIGN KEY ("refund_of_id") REFERENCES "payments"("id") ON DELETE SET NULL,
+    CONSTRAINT "uni_payments_receipt_number" UNIQUE ("receipt_number"),
+    CONSTRAINT "chk_payments_method" CHECK (method IN ('cash','bank_transfer','card','cheque')),
+    CONSTRAINT "chk_payments_type" CHECK (type IN ('payment','refund'))
+);
+CREATE INDEX IF NOT EXISTS "idx_payments_deleted_at" ON "payments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_payments_refund_of_id" ON "payments" ("refund_of_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_payment_date" ON "payments" ("payment_date");
+CREATE INDEX IF NOT EXISTS "idx_payments_recorded_by_id" ON "payments" ("recorded_by_id");
+CREATE INDEX IF NOT EXISTS "idx_payments_tenant_id" ON "payments" ("tenant_id");
+
+CREATE TABLE IF NOT EXISTS "payment_allocations" (
+    "id" bigserial,
+    "payment_id" bigint NOT NULL,
+    "invoice_id" bigint NOT NULL,
+    "amount" decimal NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_payment_allocations_invoice" FOREIGN KEY ("invoice_id") REFERENCES "invoices"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_payments_allocations" FOREIGN KEY ("payment_id") REFERENCES "payments"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_payment_allocations_invoice_id" ON "payment_allocations" ("invoice_id");
+CREATE INDEX IF NOT EXISTS "idx_payment_allocations_payment_id" ON "payment_allocations" ("payment_id");
+
+CREATE TABLE IF NOT EXISTS "late_fee_policies" (
+    "id" bigserial,
+    "landlord_id" bigint NOT NULL,
+    "enabled" boolean NOT NULL,
+    "flat_fee" decimal DEFAULT 0,
+    "percentage" decimal DEFAULT 0,
+    "grace_days" bigint DEFAULT 0,
+    "max_fee" decimal DEFAULT 0,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_late_fee_policies_landlord" FOREIGN KEY ("landlord_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_late_fee_policies_percentage" CHECK (percentage >= 0 AND percentage <= 100),
+    CONSTRAINT "chk_late_fee_policies_flat_fee" CHECK (flat_fee >= 0),
+    CONSTRAINT "chk_late_fee_policies_grace_days" CHECK (grace_days >= 0),
+    CONSTRAINT "chk_late_fee_policies_max_fee" CHECK (max_fee >= 0)
+);
+CREATE INDEX IF NOT EXISTS "idx_late_fee_policies_deleted_at" ON "late_fee_policies" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_late_fee_policies_landlord_id" ON "late_fee_policies" ("landlord_id");
+
+CREATE TABLE IF NOT EXISTS "expenses" (
+    "id" bigserial,
+    "property_id" bigint NOT NULL,
+    "created_by_id" bigint NOT NULL,
+    "expense_number" text NOT NULL,
+    "description" text NOT NULL,
+    "category" text NOT NULL,
+    "amount" decimal NOT NULL,
+    "expense_date" timestamptz NOT NULL,
+    "vendor_name" text,
+    "vendor_email" text,
+    "vendor_phone" text,
+    "payment_method" text,
+    "receipt_url" text,
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_properties_expenses" FOREIGN KEY ("property_id") REFERENCES "properties"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_users_expenses" FOREIGN KEY ("created_by_id") REFERENCES "users"("id"),
+    CONSTRAINT "uni_expenses_expense_number" UNIQUE ("expense_number"),
+    CONSTRAINT "chk_expenses_payment_method" CHECK (payment_method IN ('','cash','bank_transfer','card','cheque')),
+    CONSTRAINT "chk_expenses_category" CHECK (category IN ('maintenance','utilities','taxes','insurance','repairs','supplies','other'))
+);
+CREATE INDEX IF NOT EXISTS "idx_expenses_deleted_at" ON "expenses" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_expenses_created_by_id" ON "expenses" ("created_by_id");
+CREATE INDEX IF NOT EXISTS "idx_expenses_property_id" ON "expenses" ("property_id");
+
+CREATE TABLE IF NOT EXISTS "deposits" (
+    "id" bigserial,
+    "lease_id" bigint NOT NULL,
+    "tenant_id" bigint NOT NULL,
+    "amount" decimal NOT NULL,
+    "received_amount" decimal DEFAULT 0,
+    "received_at" timestamptz,
+    "protection_scheme" text,
+    "protection_reference" text,
+    "protected_at" timestamptz,
+    "status" text DEFAULT 'awaiting',
+    "returned_amount" decimal DEFAULT 0,
+    "returned_at" timestamptz,
+    "return_method" text,
+    "return_reference" text,
+    "dispute_reason" text,
+    "disputed_at" timestamptz,
+    "dispute_resolution" text,
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_deposits_lease" FOREIGN KEY ("lease_id") REFERENCES "leases"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_deposits_tenant" FOREIGN KEY ("tenant_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_deposits_status" CHECK (status IN ('awaiting','held','settlement_proposed','disputed','returned')),
+    CONSTRAINT "chk_deposits_return_method" CHECK (return_method IN ('','cash','bank_transfer','card','cheque'))
+);
+CREATE INDEX IF NOT EXISTS "idx_deposits_deleted_at" ON "deposits" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_deposits_tenant_id" ON "deposits" ("tenant_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_deposits_lease_id" ON "deposits" ("lease_id");
+
+CREATE TABLE IF NOT EXISTS "deposit_deductions" (
+    "id" bigserial,
+    "deposit_id" bigint NOT NULL,
+    "category" text NOT NULL,
+    "description" text NOT NULL,
+    "amount" decimal NOT NULL,
+    "maintenance_id" bigint,
+    "expense_id" bigint,
+    "created_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_deposit_deductions_maintenance" FOREIGN KEY ("maintenance_id") REFERENCES "maintenance_requests"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_deposit_deductions_expense" FOREIGN KEY ("expense_id") REFERENCES "expenses"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_deposit_deductions_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_deposits_deductions" FOREIGN KEY ("deposit_id") REFERENCES "deposits"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_deposit_deductions_category" CHECK (category IN ('damage','cleaning','rent_arrears','missing_items','other'))
+);
+CREATE INDEX IF NOT EXISTS "idx_deposit_deductions_expense_id" ON "deposit_deductions" ("expense_id");
+CREATE INDEX IF NOT EXISTS "idx_deposit_deductions_maintenance_id" ON "deposit_deductions" ("maintenance_id");
+CREATE INDEX IF NOT EXISTS "idx_deposit_deductions_deposit_id" ON "deposit_deductions" ("deposit_id");
+
+CREATE TABLE IF NOT EXISTS "attachments" (
+    "id" bigserial,
+    "entity_type" text NOT NULL,
+    "entity_id" bigint NOT NULL,
+    "storage_key" text NOT NULL,
+    "file_name" text NOT NULL,
+    "content_type" text NOT NULL,
+    "size" bigint NOT NULL,
+    "uploaded_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_attachments_uploaded_by" FOREIGN KEY ("uploaded_by_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "uni_attachments_storage_key" UNIQUE ("storage_key"),
+    CONSTRAINT "chk_attachments_entity_type" CHECK (entity_type IN ('property','lease','maintenance','expense'))
+);
+CREATE INDEX IF NOT EXISTS "idx_attachments_uploaded_by_id" ON "attachments" ("uploaded_by_id");
+CREATE INDEX IF NOT EXISTS "idx_attachments_entity" ON "attachments" ("entity_type","entity_id");
+CREATE INDEX IF NOT EXISTS "idx_attachments_deleted_at" ON "attachments" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "user_id" bigint,
+    "action" text NOT NULL,
+    "entity_type" text NOT NULL,
+    "entity_id" bigint NOT NULL,
+    "old_data" text,
+    "new_data" text,
+    "ip_address" text,
+    "user_agent" text,
+    "description" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_audit_logs" FOREIGN KEY ("user_id") REFERENCES "users"("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_entity_id" ON "audit_logs" ("entity_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_entity_type" ON "audit_logs" ("entity_type");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_action" ON "audit_logs" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_logs_user_id" ON "audit_logs" ("user_id");
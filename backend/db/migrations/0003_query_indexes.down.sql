@@ -0,0 +1,15 @@
+ALTER TABLE leases DROP CONSTRAINT IF EXISTS leases_no_overlap;
+
+DROP INDEX IF EXISTS idx_audit_logs_entity;
+DROP INDEX IF EXISTS idx_audit_logs_user_action;
+DROP INDEX IF EXISTS idx_expenses_date_category;
+DROP INDEX IF EXISTS idx_invoices_dates;
+DROP INDEX IF EXISTS idx_invoices_status_due;
+DROP INDEX IF EXISTS idx_maintenance_dates;
+DROP INDEX IF EXISTS idx_maintenance_status_priority;
+DROP INDEX IF EXISTS idx_leases_status;
+DROP INDEX IF EXISTS idx_leases_dates;
+DROP INDEX IF EXISTS idx_properties_city_available;
+DROP INDEX IF EXISTS idx_properties_owner_available;
+DROP INDEX IF EXISTS idx_users_role_active;
+DROP INDEX IF EXISTS idx_users_email_active;
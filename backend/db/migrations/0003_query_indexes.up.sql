@@ -0,0 +1,50 @@
+-- Composite indexes for the common list and dashboard queries
+CREATE INDEX IF NOT EXISTS idx_users_email_active ON users(email, is_active);
+CREATE INDEX IF NOT EXISTS idx_users_role_active ON users(role, is_active);
+CREATE INDEX IF NOT EXISTS idx_properties_owner_available ON properties(owner_id, available);
+CREATE INDEX IF NOT EXISTS idx_properties_city_available ON properties(city, available);
+CREATE INDEX IF NOT EXISTS idx_leases_dates ON leases(start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_leases_status ON leases(status);
+CREATE INDEX IF NOT EXISTS idx_maintenance_status_priority ON maintenance_requests(status, priority);
+CREATE INDEX IF NOT EXISTS idx_maintenance_dates ON maintenance_requests(requested_at, scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_invoices_status_due ON invoices(payment_status, due_date);
+CREATE INDEX IF NOT EXISTS idx_invoices_dates ON invoices(invoice_date, due_date);
+CREATE INDEX IF NOT EXISTS idx_expenses_date_category ON expenses(expense_date, category);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_action ON audit_logs(user_id, action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
+
+-- Backstop for lease overlap checks: no two active or pending leases on the
+-- same unit share a day, and a lease without a unit (the whole property)
+-- shares no day with any other lease on the property. int8range(NULL, NULL)
+-- is unbounded, so it overlaps every unit's single-value range. btree_gist
+-- needs a privileged role; without it, or while existing leases still overlap,
+-- the transactional check in the lease handlers applies on its own.
+DO $$
+DECLARE
+    overlap RECORD;
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS btree_gist;
+    ALTER TABLE leases ADD CONSTRAINT leases_no_overlap EXCLUDE USING gist (
+        property_id WITH =,
+        int8range(unit_id, unit_id, '[]') WITH &&,
+        tsrange(start_date, end_date, '[]') WITH &&
+    ) WHERE (status IN ('active', 'pending') AND deleted_at IS NULL);
+EXCEPTION
+    WHEN duplicate_object OR duplicate_table THEN NULL;
+    WHEN insufficient_privilege OR undefined_file THEN
+        RAISE NOTICE 'skipping leases_no_overlap: %', SQLERRM;
+    WHEN exclusion_violation THEN
+        FOR overlap IN
+            SELECT a.id AS lease_id, b.id AS other_id, a.property_id
+            FROM leases a
+            JOIN leases b ON b.property_id = a.property_id AND b.id > a.id
+            WHERE a.status IN ('active', 'pending') AND a.deleted_at IS NULL
+              AND b.status IN ('active', 'pending') AND b.deleted_at IS NULL
+              AND (a.unit_id IS NULL OR b.unit_id IS NULL OR a.unit_id = b.unit_id)
+              AND tsrange(a.start_date, a.end_date, '[]') && tsrange(b.start_date, b.end_date, '[]')
+            ORDER BY a.id, b.id
+        LOOP
+            RAISE NOTICE 'lease % overlaps lease % on property %', overlap.lease_id, overlap.other_id, overlap.property_id;
+        END LOOP;
+        RAISE NOTICE 'skipping leases_no_overlap until the overlapping leases above are resolved';
+END $$;
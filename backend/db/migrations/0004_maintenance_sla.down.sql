@@ -0,0 +1,14 @@
+UPDATE maintenance_requests SET status = 'pending' WHERE status = 'assigned';
+
+ALTER TABLE maintenance_requests DROP CONSTRAINT IF EXISTS "chk_maintenance_requests_status";
+ALTER TABLE maintenance_requests ADD CONSTRAINT "chk_maintenance_requests_status"
+    CHECK (status IN ('pending','in_progress','completed','cancelled'));
+
+DROP INDEX IF EXISTS "idx_maintenance_requests_resolve_due_at";
+
+ALTER TABLE maintenance_requests
+    DROP COLUMN IF EXISTS escalated_at,
+    DROP COLUMN IF EXISTS escalation_level,
+    DROP COLUMN IF EXISTS resolve_due_at,
+    DROP COLUMN IF EXISTS acknowledge_due_at,
+    DROP COLUMN IF EXISTS acknowledged_at;
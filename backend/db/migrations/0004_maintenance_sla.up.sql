@@ -0,0 +1,18 @@
+-- Maintenance workflow: an explicit 'assigned' status and SLA tracking
+ALTER TABLE maintenance_requests
+    ADD COLUMN IF NOT EXISTS acknowledged_at timestamptz,
+    ADD COLUMN IF NOT EXISTS acknowledge_due_at timestamptz,
+    ADD COLUMN IF NOT EXISTS resolve_due_at timestamptz,
+    ADD COLUMN IF NOT EXISTS escalation_level bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS escalated_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_resolve_due_at" ON "maintenance_requests" ("resolve_due_at");
+
+ALTER TABLE maintenance_requests DROP CONSTRAINT IF EXISTS "chk_maintenance_requests_status";
+ALTER TABLE maintenance_requests ADD CONSTRAINT "chk_maintenance_requests_status"
+    CHECK (status IN ('pending','assigned','in_progress','completed','cancelled'));
+
+-- Pending requests that already have a technician are assigned
+UPDATE maintenance_requests
+SET status = 'assigned'
+WHERE status = 'pending' AND assigned_to_id IS NOT NULL;
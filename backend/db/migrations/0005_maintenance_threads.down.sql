@@ -0,0 +1,9 @@
+-- Files attached to comments stay in the file store and must be cleaned up by hand
+DELETE FROM attachments WHERE entity_type = 'maintenance_comment';
+ALTER TABLE attachments DROP CONSTRAINT IF EXISTS "chk_attachments_entity_type";
+ALTER TABLE attachments ADD CONSTRAINT "chk_attachments_entity_type"
+    CHECK (entity_type IN ('property','lease','maintenance','expense'));
+
+DROP TABLE IF EXISTS "maintenance_status_changes";
+DROP FUNCTION IF EXISTS maintenance_status_changes_immutable();
+DROP TABLE IF EXISTS "maintenance_comments";
@@ -0,0 +1,65 @@
+-- Comment threads and status history on maintenance requests
+CREATE TABLE IF NOT EXISTS "maintenance_comments" (
+    "id" bigserial,
+    "maintenance_id" bigint NOT NULL,
+    "author_id" bigint NOT NULL,
+    "body" text NOT NULL,
+    "visibility" text NOT NULL DEFAULT 'public',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_maintenance_comments_author" FOREIGN KEY ("author_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_maintenance_comments_maintenance" FOREIGN KEY ("maintenance_id") REFERENCES "maintenance_requests"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_maintenance_comments_visibility" CHECK (visibility IN ('public','internal'))
+);
+CREATE INDEX IF NOT EXISTS "idx_maintenance_comments_deleted_at" ON "maintenance_comments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_comments_author_id" ON "maintenance_comments" ("author_id");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_comments_maintenance_id" ON "maintenance_comments" ("maintenance_id");
+
+CREATE TABLE IF NOT EXISTS "maintenance_status_changes" (
+    "id" bigserial,
+    "maintenance_id" bigint NOT NULL,
+    "from_status" text,
+    "to_status" text NOT NULL,
+    "changed_by_id" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_maintenance_status_changes_maintenance" FOREIGN KEY ("maintenance_id") REFERENCES "maintenance_requests"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_maintenance_status_changes_changed_by" FOREIGN KEY ("changed_by_id") REFERENCES "users"("id") ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS "idx_maintenance_status_changes_changed_by_id" ON "maintenance_status_changes" ("changed_by_id");
+CREATE INDEX IF NOT EXISTS "idx_maintenance_status_changes_maintenance_id" ON "maintenance_status_changes" ("maintenance_id");
+
+-- History entries are append-only. The only update allowed is the foreign key
+-- clearing changed_by_id when that user is deleted.
+CREATE OR REPLACE FUNCTION maintenance_status_changes_immutable() RETURNS trigger AS $$
+BEGIN
+    IF NEW.maintenance_id IS DISTINCT FROM OLD.maintenance_id
+        OR NEW.from_status IS DISTINCT FROM OLD.from_status
+        OR NEW.to_status IS DISTINCT FROM OLD.to_status
+        OR NEW.created_at IS DISTINCT FROM OLD.created_at
+        OR (NEW.changed_by_id IS NOT NULL AND NEW.changed_by_id IS DISTINCT FROM OLD.changed_by_id) THEN
+        RAISE EXCEPTION 'maintenance status history is immutable';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS maintenance_status_changes_immutable ON maintenance_status_changes;
+CREATE TRIGGER maintenance_status_changes_immutable
+    BEFORE UPDATE ON maintenance_status_changes
+    FOR EACH ROW EXECUTE FUNCTION maintenance_status_changes_immutable();
+
+-- Files can be attached to individual comments
+ALTER TABLE attachments DROP CONSTRAINT IF EXISTS "chk_attachments_entity_type";
+ALTER TABLE attachments ADD CONSTRAINT "chk_attachments_entity_type"
+    CHECK (entity_type IN ('property','lease','maintenance','maintenance_comment','expense'));
+
+-- Existing requests start their history at their current status
+INSERT INTO maintenance_status_changes (maintenance_id, from_status, to_status, created_at)
+SELECT id, '', status, COALESCE(requested_at, created_at)
+FROM maintenance_requests
+WHERE NOT EXISTS (
+    SELECT 1 FROM maintenance_status_changes h WHERE h.maintenance_id = maintenance_requests.id
+);
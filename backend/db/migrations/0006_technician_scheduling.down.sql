@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_maintenance_assignee_schedule;
+
+ALTER TABLE maintenance_requests DROP COLUMN IF EXISTS scheduled_end_at;
+
+DROP TABLE IF EXISTS "technician_skills";
+DROP TABLE IF EXISTS "technician_time_off";
+DROP TABLE IF EXISTS "technician_working_hours";
@@ -0,0 +1,44 @@
+-- Technician scheduling: skills, weekly working hours, time off and
+-- appointment end times
+CREATE TABLE IF NOT EXISTS "technician_working_hours" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "weekday" bigint NOT NULL,
+    "start_time" varchar(5) NOT NULL,
+    "end_time" varchar(5) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_technician_working_hours_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_technician_working_hours_weekday" CHECK (weekday BETWEEN 0 AND 6)
+);
+CREATE INDEX IF NOT EXISTS "idx_technician_working_hours_user_id" ON "technician_working_hours" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "technician_time_off" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "reason" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_technician_time_off_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_technician_time_off_user_id" ON "technician_time_off" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "technician_skills" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "category" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_technician_skills_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_technician_skills_category" CHECK (category IN ('plumbing','electrical','heating','appliances','general','emergency'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_technician_skills_user_category" ON "technician_skills" ("user_id","category");
+
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS scheduled_end_at timestamptz;
+
+-- Conflict checks look up a technician's appointments by start time
+CREATE INDEX IF NOT EXISTS idx_maintenance_assignee_schedule ON maintenance_requests(assigned_to_id, scheduled_at);
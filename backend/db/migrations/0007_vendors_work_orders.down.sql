@@ -0,0 +1,8 @@
+-- Expenses keep their free-text vendor details
+DROP INDEX IF EXISTS "idx_expenses_vendor_id";
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS "fk_expenses_vendor";
+ALTER TABLE expenses DROP COLUMN IF EXISTS vendor_id;
+
+DROP TABLE IF EXISTS "work_order_quotes";
+DROP TABLE IF EXISTS "work_orders";
+DROP TABLE IF EXISTS "vendors";
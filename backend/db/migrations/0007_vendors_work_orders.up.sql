@@ -0,0 +1,95 @@
+-- Vendor directory, work orders raised from maintenance requests, their
+-- quotes, and the link from expenses to the vendor they were paid to
+CREATE TABLE IF NOT EXISTS "vendors" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "contact_name" text,
+    "email" text,
+    "phone" text,
+    "trades" text,
+    "insurance_expires_at" timestamptz,
+    "hourly_rate" decimal DEFAULT 0,
+    "callout_fee" decimal DEFAULT 0,
+    "rating" decimal DEFAULT 0,
+    "is_active" boolean DEFAULT true,
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_vendors_rating" CHECK (rating BETWEEN 0 AND 5)
+);
+CREATE INDEX IF NOT EXISTS "idx_vendors_deleted_at" ON "vendors" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_vendors_name" ON "vendors" ("name");
+
+CREATE TABLE IF NOT EXISTS "work_orders" (
+    "id" bigserial,
+    "maintenance_id" bigint NOT NULL,
+    "property_id" bigint NOT NULL,
+    "vendor_id" bigint,
+    "created_by_id" bigint NOT NULL,
+    "description" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'requested',
+    "approved_quote_id" bigint,
+    "approved_by_id" bigint,
+    "approved_at" timestamptz,
+    "expense_id" bigint,
+    "invoiced_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_work_orders_maintenance" FOREIGN KEY ("maintenance_id") REFERENCES "maintenance_requests"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_work_orders_property" FOREIGN KEY ("property_id") REFERENCES "properties"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_work_orders_vendor" FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id") ON DELETE SET NULL,
+    CONSTRAINT "fk_work_orders_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_work_orders_expense" FOREIGN KEY ("expense_id") REFERENCES "expenses"("id") ON DELETE SET NULL,
+    CONSTRAINT "chk_work_orders_status" CHECK (status IN ('requested','approved','invoiced','cancelled'))
+);
+CREATE INDEX IF NOT EXISTS "idx_work_orders_expense_id" ON "work_orders" ("expense_id");
+CREATE INDEX IF NOT EXISTS "idx_work_orders_created_by_id" ON "work_orders" ("created_by_id");
+CREATE INDEX IF NOT EXISTS "idx_work_orders_vendor_id" ON "work_orders" ("vendor_id");
+CREATE INDEX IF NOT EXISTS "idx_work_orders_property_id" ON "work_orders" ("property_id");
+CREATE INDEX IF NOT EXISTS "idx_work_orders_maintenance_id" ON "work_orders" ("maintenance_id");
+CREATE INDEX IF NOT EXISTS "idx_work_orders_deleted_at" ON "work_orders" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "work_order_quotes" (
+    "id" bigserial,
+    "work_order_id" bigint NOT NULL,
+    "vendor_id" bigint NOT NULL,
+    "amount" decimal NOT NULL,
+    "description" text,
+    "valid_until" timestamptz,
+    "status" text NOT NULL DEFAULT 'submitted',
+    "created_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_work_order_quotes_vendor" FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_work_order_quotes_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_work_orders_quotes" FOREIGN KEY ("work_order_id") REFERENCES "work_orders"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_work_order_quotes_status" CHECK (status IN ('submitted','accepted','rejected'))
+);
+CREATE INDEX IF NOT EXISTS "idx_work_order_quotes_vendor_id" ON "work_order_quotes" ("vendor_id");
+CREATE INDEX IF NOT EXISTS "idx_work_order_quotes_work_order_id" ON "work_order_quotes" ("work_order_id");
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS vendor_id bigint;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS "fk_expenses_vendor";
+ALTER TABLE expenses ADD CONSTRAINT "fk_expenses_vendor"
+    FOREIGN KEY ("vendor_id") REFERENCES "vendors"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS "idx_expenses_vendor_id" ON "expenses" ("vendor_id");
+
+-- Seed the directory from the free-text vendors on existing expenses, one
+-- vendor per name, keeping the most recent contact details
+INSERT INTO vendors (name, email, phone, trades, is_active, created_at, updated_at)
+SELECT DISTINCT ON (lower(trim(vendor_name)))
+    trim(vendor_name), vendor_email, vendor_phone, '[]', true, NOW(), NOW()
+FROM expenses
+WHERE trim(COALESCE(vendor_name, '')) <> ''
+ORDER BY lower(trim(vendor_name)), expense_date DESC;
+
+UPDATE expenses e
+SET vendor_id = v.id
+FROM vendors v
+WHERE e.vendor_id IS NULL
+  AND lower(trim(e.vendor_name)) = lower(v.name);
@@ -0,0 +1,16 @@
+-- Posted maintenance costs only exist because of the up migration; recharge
+-- invoices are kept as ordinary maintenance invoices
+DELETE FROM expenses WHERE source = 'maintenance';
+
+DROP INDEX IF EXISTS "idx_maintenance_requests_recharge_invoice_id";
+ALTER TABLE maintenance_requests DROP CONSTRAINT IF EXISTS "fk_maintenance_requests_recharge_invoice";
+ALTER TABLE maintenance_requests DROP COLUMN IF EXISTS recharge_invoice_id;
+ALTER TABLE maintenance_requests DROP COLUMN IF EXISTS recharge_amount;
+ALTER TABLE maintenance_requests DROP COLUMN IF EXISTS recharge_tenant;
+
+DROP INDEX IF EXISTS "idx_expenses_maintenance_posting";
+DROP INDEX IF EXISTS "idx_expenses_maintenance_id";
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS "chk_expenses_source";
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS "fk_expenses_maintenance";
+ALTER TABLE expenses DROP COLUMN IF EXISTS source;
+ALTER TABLE expenses DROP COLUMN IF EXISTS maintenance_id;
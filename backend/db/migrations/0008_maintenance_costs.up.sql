@@ -0,0 +1,52 @@
+-- Link expenses to the maintenance request they paid for, and let completed
+-- requests recharge the tenant through an invoice
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS maintenance_id bigint;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS source text NOT NULL DEFAULT 'manual';
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS "fk_expenses_maintenance";
+ALTER TABLE expenses ADD CONSTRAINT "fk_expenses_maintenance"
+    FOREIGN KEY ("maintenance_id") REFERENCES "maintenance_requests"("id") ON DELETE SET NULL;
+ALTER TABLE expenses DROP CONSTRAINT IF EXISTS "chk_expenses_source";
+ALTER TABLE expenses ADD CONSTRAINT "chk_expenses_source"
+    CHECK (source IN ('manual','maintenance','work_order'));
+CREATE INDEX IF NOT EXISTS "idx_expenses_maintenance_id" ON "expenses" ("maintenance_id");
+
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS recharge_tenant boolean DEFAULT false;
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS recharge_amount decimal DEFAULT 0;
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS recharge_invoice_id bigint;
+ALTER TABLE maintenance_requests DROP CONSTRAINT IF EXISTS "fk_maintenance_requests_recharge_invoice";
+ALTER TABLE maintenance_requests ADD CONSTRAINT "fk_maintenance_requests_recharge_invoice"
+    FOREIGN KEY ("recharge_invoice_id") REFERENCES "invoices"("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS "idx_maintenance_requests_recharge_invoice_id" ON "maintenance_requests" ("recharge_invoice_id");
+
+-- Vendor invoices already booked through work orders
+UPDATE expenses e
+SET source = 'work_order', maintenance_id = wo.maintenance_id
+FROM work_orders wo
+WHERE wo.expense_id = e.id;
+
+-- Post the cost of requests completed before expenses were posted
+-- automatically, less what their vendors already invoiced
+INSERT INTO expenses (property_id, created_by_id, expense_number, description, category, amount,
+                      expense_date, maintenance_id, source, created_at, updated_at)
+SELECT m.property_id,
+       COALESCE(m.assigned_to_id, m.requested_by_id),
+       'EXP-M' || m.id,
+       'Maintenance #' || m.id || ': ' || m.title,
+       CASE WHEN m.category = 'general' THEN 'maintenance' ELSE 'repairs' END,
+       ROUND((m.actual_cost - COALESCE(v.billed, 0))::numeric, 2),
+       COALESCE(m.completed_at, m.updated_at, NOW()),
+       m.id, 'maintenance', NOW(), NOW()
+FROM maintenance_requests m
+LEFT JOIN (
+    SELECT maintenance_id, SUM(amount) AS billed
+    FROM expenses
+    WHERE source = 'work_order'
+    GROUP BY maintenance_id
+) v ON v.maintenance_id = m.id
+WHERE m.status = 'completed'
+  AND m.deleted_at IS NULL
+  AND m.actual_cost - COALESCE(v.billed, 0) > 0;
+
+-- A request has at most one posted cost expense
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_expenses_maintenance_posting"
+    ON "expenses" ("maintenance_id") WHERE source = 'maintenance';
@@ -0,0 +1,97 @@
+-- Notification templates, per-user channel preferences and the queue of
+-- notifications, which doubles as the delivery log
+CREATE TABLE IF NOT EXISTS "notification_templates" (
+    "id" bigserial,
+    "event_type" text NOT NULL,
+    "channel" text NOT NULL,
+    "subject" text,
+    "body" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_notification_templates_channel" CHECK (channel IN ('email','in_app','sms','webhook'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_templates_event_channel" ON "notification_templates" ("event_type","channel");
+
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "event_type" text NOT NULL,
+    "channel" text NOT NULL,
+    "enabled" boolean NOT NULL DEFAULT false,
+    "target" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_notification_preferences_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_notification_preferences_channel" CHECK (channel IN ('email','in_app','sms','webhook'))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_preferences_user_event_channel" ON "notification_preferences" ("user_id","event_type","channel");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "event_type" text NOT NULL,
+    "channel" text NOT NULL,
+    "recipient" text,
+    "subject" text,
+    "body" text NOT NULL,
+    "data" text,
+    "reference" text,
+    "status" text NOT NULL DEFAULT 'queued',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_error" text,
+    "sent_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_notifications_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_notifications_status" CHECK (status IN ('queued','sent','failed')),
+    CONSTRAINT "chk_notifications_channel" CHECK (channel IN ('email','in_app','sms','webhook'))
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_due" ON "notifications" ("status","next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_notifications_event_type" ON "notifications" ("event_type");
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+-- A reminder about the same record goes to each user once per channel
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notifications_reference"
+    ON "notifications" ("user_id","channel","reference") WHERE reference <> '';
+
+-- Default wording; admins can edit these through the API. Webhooks send the
+-- in-app text alongside the event data.
+INSERT INTO notification_templates (event_type, channel, subject, body, created_at, updated_at) VALUES
+('maintenance.created', 'email', 'New maintenance request #{{.MaintenanceID}}',
+ E'Hi {{.RecipientName}},\n\nA new maintenance request has been raised at {{.PropertyName}}:\n\n{{.Title}}\n{{.Description}}\n\nPriority: {{.Priority}}\nCategory: {{.Category}}\n\nPlease review and take appropriate action.', NOW(), NOW()),
+('maintenance.created', 'in_app', 'New maintenance request',
+ '#{{.MaintenanceID}} {{.Title}} at {{.PropertyName}} ({{.Priority}} priority)', NOW(), NOW()),
+('maintenance.created', 'sms', '',
+ 'New {{.Priority}} maintenance request #{{.MaintenanceID}} at {{.PropertyName}}: {{.Title}}', NOW(), NOW()),
+
+('maintenance.assigned', 'email', 'Maintenance request #{{.MaintenanceID}} has been assigned',
+ E'Hi {{.RecipientName}},\n\nMaintenance request #{{.MaintenanceID}} "{{.Title}}" at {{.PropertyName}} has been assigned to a technician.{{if .ScheduledAt}}\n\nThe visit is booked for {{.ScheduledAt}}.{{end}}', NOW(), NOW()),
+('maintenance.assigned', 'in_app', 'Maintenance request assigned',
+ '#{{.MaintenanceID}} {{.Title}} has been assigned{{if .ScheduledAt}} for {{.ScheduledAt}}{{end}}', NOW(), NOW()),
+('maintenance.assigned', 'sms', '',
+ 'Maintenance request #{{.MaintenanceID}} at {{.PropertyName}} has been assigned{{if .ScheduledAt}} for {{.ScheduledAt}}{{end}}.', NOW(), NOW()),
+
+('maintenance.completed', 'email', 'Maintenance request #{{.MaintenanceID}} is complete',
+ E'Hi {{.RecipientName}},\n\nThe work on maintenance request #{{.MaintenanceID}} "{{.Title}}" at {{.PropertyName}} has been completed.', NOW(), NOW()),
+('maintenance.completed', 'in_app', 'Maintenance request completed',
+ '#{{.MaintenanceID}} {{.Title}} at {{.PropertyName}} has been completed', NOW(), NOW()),
+('maintenance.completed', 'sms', '',
+ 'Maintenance request #{{.MaintenanceID}} at {{.PropertyName}} has been completed.', NOW(), NOW()),
+
+('lease.expiring', 'email', 'Your lease at {{.PropertyName}} ends on {{.EndDate}}',
+ E'Hi {{.RecipientName}},\n\nThe lease at {{.PropertyName}}, {{.Address}} ends on {{.EndDate}}, in {{.DaysLeft}} days.\n\nIf it should continue, please arrange a renewal before then.', NOW(), NOW()),
+('lease.expiring', 'in_app', 'Lease ending soon',
+ 'The lease at {{.PropertyName}} ends on {{.EndDate}} ({{.DaysLeft}} days)', NOW(), NOW()),
+('lease.expiring', 'sms', '',
+ 'The lease at {{.PropertyName}} ends on {{.EndDate}}. Please arrange a renewal if it should continue.', NOW(), NOW()),
+
+('invoice.due', 'email', 'Invoice {{.InvoiceNumber}} is due on {{.DueDate}}',
+ E'Hi {{.RecipientName}},\n\nInvoice {{.InvoiceNumber}} for {{.PropertyName}} is due on {{.DueDate}}.\n\nAmount: {{.Amount}}\nStill to pay: {{.Balance}}', NOW(), NOW()),
+('invoice.due', 'in_app', 'Invoice due soon',
+ 'Invoice {{.InvoiceNumber}} ({{.Balance}} to pay) is due on {{.DueDate}}', NOW(), NOW()),
+('invoice.due', 'sms', '',
+ 'Invoice {{.InvoiceNumber}} for {{.PropertyName}} is due on {{.DueDate}}: {{.Balance}} to pay.', NOW(), NOW())
+ON CONFLICT DO NOTHING;
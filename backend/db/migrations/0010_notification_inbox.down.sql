@@ -0,0 +1,8 @@
+DELETE FROM "notification_templates" WHERE "event_type" = 'maintenance.status_changed';
+DELETE FROM "notification_preferences" WHERE "event_type" = 'maintenance.status_changed';
+DROP TRIGGER IF EXISTS "notifications_inbox_push" ON "notifications";
+DROP FUNCTION IF EXISTS notify_notification_inbox();
+DROP INDEX IF EXISTS "idx_notifications_unread";
+DROP INDEX IF EXISTS "idx_notifications_inbox";
+ALTER TABLE "notifications" DROP COLUMN IF EXISTS "archived_at";
+ALTER TABLE "notifications" DROP COLUMN IF EXISTS "read_at";
@@ -0,0 +1,28 @@
+-- Read and archived state for the in-app inbox, and a Postgres notification
+-- on every new in-app row so open streams can push it once it commits
+ALTER TABLE "notifications" ADD COLUMN IF NOT EXISTS "read_at" timestamptz;
+ALTER TABLE "notifications" ADD COLUMN IF NOT EXISTS "archived_at" timestamptz;
+
+-- In-app notifications have nothing to deliver; storing them sends them
+UPDATE "notifications" SET "status" = 'sent', "sent_at" = COALESCE("sent_at", "created_at")
+WHERE "channel" = 'in_app' AND "status" = 'queued';
+
+CREATE INDEX IF NOT EXISTS "idx_notifications_inbox" ON "notifications" ("user_id", "created_at" DESC) WHERE "channel" = 'in_app';
+CREATE INDEX IF NOT EXISTS "idx_notifications_unread" ON "notifications" ("user_id")
+WHERE "channel" = 'in_app' AND "read_at" IS NULL AND "archived_at" IS NULL;
+
+CREATE OR REPLACE FUNCTION notify_notification_inbox() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('notification_inbox', json_build_object('id', NEW.id, 'user_id', NEW.user_id)::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "notifications_inbox_push" ON "notifications";
+CREATE TRIGGER "notifications_inbox_push" AFTER INSERT ON "notifications"
+FOR EACH ROW WHEN (NEW.channel = 'in_app') EXECUTE FUNCTION notify_notification_inbox();
+
+INSERT INTO notification_templates (event_type, channel, subject, body, created_at, updated_at) VALUES
+('maintenance.status_changed', 'in_app', 'Maintenance request updated',
+ '#{{.MaintenanceID}} {{.Title}} at {{.PropertyName}} moved from {{.PreviousStatus}} to {{.Status}}', NOW(), NOW())
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,43 @@
+-- Webhook endpoints registered by admins and landlords, and the queue of
+-- deliveries to them, which doubles as each endpoint's delivery log
+CREATE TABLE IF NOT EXISTS "webhook_endpoints" (
+    "id" bigserial,
+    "owner_id" bigint NOT NULL,
+    "url" text NOT NULL,
+    "description" text,
+    "secret" text NOT NULL,
+    "event_types" text NOT NULL,
+    "is_active" boolean NOT NULL DEFAULT true,
+    "consecutive_failures" bigint NOT NULL DEFAULT 0,
+    "disabled_at" timestamptz,
+    "disabled_reason" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhook_endpoints_owner" FOREIGN KEY ("owner_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_endpoints_owner_id" ON "webhook_endpoints" ("owner_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" bigserial,
+    "endpoint_id" bigint NOT NULL,
+    "event_id" text NOT NULL,
+    "event_type" text NOT NULL,
+    "payload" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'queued',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "response_status" bigint,
+    "response_body" text,
+    "last_error" text,
+    "duration_ms" bigint,
+    "delivered_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_webhook_deliveries_endpoint" FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints"("id") ON DELETE CASCADE,
+    CONSTRAINT "chk_webhook_deliveries_status" CHECK (status IN ('queued','sent','failed'))
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_due" ON "webhook_deliveries" ("status","next_attempt_at");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_event_id" ON "webhook_deliveries" ("event_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_endpoint_id" ON "webhook_deliveries" ("endpoint_id");
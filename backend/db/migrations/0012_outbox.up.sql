@@ -0,0 +1,18 @@
+-- Kafka events written with the change that caused them and relayed by a
+-- background job
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+    "id" bigserial,
+    "topic" text NOT NULL,
+    "key" text,
+    "payload" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'pending',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_error" text,
+    "sent_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "chk_outbox_events_status" CHECK (status IN ('pending','sent'))
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_sent_at" ON "outbox_events" ("sent_at");
+-- The relay reads pending events in ID order; relayed ones are left out
+CREATE INDEX IF NOT EXISTS "idx_outbox_events_pending" ON "outbox_events" ("id") WHERE status = 'pending';
@@ -0,0 +1,3 @@
+-- Domain events carry their type alongside the envelope, so it can be sent
+-- as a Kafka header without decoding the payload
+ALTER TABLE "outbox_events" ADD COLUMN IF NOT EXISTS "event_type" text;
@@ -6,6 +6,7 @@ import (
 
 	"github.com/geoo115/property-manager/config"
 	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/metrics"
 	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 )
@@ -19,6 +20,7 @@ func InitRedis(cfg *config.Config) error {
 		Password: cfg.Redis.Password,
 		DB:       cfg.Redis.DB,
 	})
+	RedisClient.AddHook(metrics.RedisHook{})
 
 	_, err := RedisClient.Ping(Ctx).Result()
 	if err != nil {
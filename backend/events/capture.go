@@ -0,0 +1,94 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/geoo115/property-manager/snapshot"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// payloadSchemas caches the parsed payload types
+var payloadSchemas sync.Map
+
+// Register subscribes the domain events to writes on the aggregates in the
+// catalogue. Like the audit trail they run in the transaction of the change,
+// off the same snapshot, so an event is written to the outbox exactly when
+// its change commits. Updates that leave the payload as it was publish
+// nothing.
+func Register(db *gorm.DB) error {
+	tables := make([]string, 0, len(Aggregates))
+	for _, a := range Aggregates {
+		tables = append(tables, a.Table)
+	}
+	return snapshot.Register(db, snapshot.Subscriber{Name: "events", Tables: tables, Handle: publishChange})
+}
+
+// eventActions names the event published for each kind of change
+var eventActions = map[string]string{
+	snapshot.Create: "created",
+	snapshot.Update: "updated",
+	snapshot.Delete: "deleted",
+}
+
+// publishChange publishes an event per row of a captured change, in ID order
+func publishChange(tx *gorm.DB, change snapshot.Change) error {
+	aggregate, ok := aggregateForTable(change.Table)
+	if !ok {
+		return nil
+	}
+	eventType := aggregate.Name + "." + eventActions[change.Action]
+
+	for _, id := range change.IDs() {
+		row := change.After[id]
+		if change.Action == snapshot.Delete {
+			row = change.Before[id]
+		}
+		data, err := payload(tx, aggregate, row)
+		if err != nil {
+			return err
+		}
+		if change.Action == snapshot.Update {
+			before, err := payload(tx, aggregate, change.Before[id])
+			if err != nil {
+				return err
+			}
+			if samePayload(before, data) {
+				continue
+			}
+		}
+		if err := Publish(snapshot.Session(tx), eventType, id, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// payload reads a captured row into the aggregate's payload type
+func payload(tx *gorm.DB, aggregate Aggregate, row snapshot.Row) (interface{}, error) {
+	value := reflect.New(aggregate.DataType())
+	s, err := schema.Parse(value.Interface(), &payloadSchemas, tx.NamingStrategy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s payload: %w", aggregate.Name, err)
+	}
+	for _, field := range s.Fields {
+		column, ok := row[field.DBName]
+		if field.DBName == "" || !ok {
+			continue
+		}
+		if err := field.Set(tx.Statement.Context, value.Elem(), column); err != nil {
+			return nil, fmt.Errorf("failed to read %s.%s: %w", aggregate.Table, field.DBName, err)
+		}
+	}
+	return value.Elem().Interface(), nil
+}
+
+func samePayload(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
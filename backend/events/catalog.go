@@ -0,0 +1,112 @@
+package events
+
+import (
+	"embed"
+	"fmt"
+	"reflect"
+)
+
+// Domain event types. Each aggregate has created, updated and deleted events
+// whose data is the aggregate's current payload version.
+const (
+	LeaseCreated       = "lease.created"
+	LeaseUpdated       = "lease.updated"
+	LeaseDeleted       = "lease.deleted"
+	InvoiceCreated     = "invoice.created"
+	InvoiceUpdated     = "invoice.updated"
+	InvoiceDeleted     = "invoice.deleted"
+	PaymentCreated     = "payment.created"
+	PaymentUpdated     = "payment.updated"
+	PaymentDeleted     = "payment.deleted"
+	PropertyCreated    = "property.created"
+	PropertyUpdated    = "property.updated"
+	PropertyDeleted    = "property.deleted"
+	UserCreated        = "user.created"
+	UserUpdated        = "user.updated"
+	UserDeleted        = "user.deleted"
+	MaintenanceCreated = "maintenance.created"
+	MaintenanceUpdated = "maintenance.updated"
+	MaintenanceDeleted = "maintenance.deleted"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Aggregate is an entity whose changes are published as domain events
+type Aggregate struct {
+	Name    string // Prefix of its event types, e.g. "lease"
+	Table   string
+	Version int // Payload version its events are published with
+	data    reflect.Type
+}
+
+// Aggregates lists every aggregate in the catalogue
+var Aggregates = []Aggregate{
+	{Name: "lease", Table: "leases", Version: 1, data: reflect.TypeOf(LeaseV1{})},
+	{Name: "invoice", Table: "invoices", Version: 1, data: reflect.TypeOf(InvoiceV1{})},
+	{Name: "payment", Table: "payments", Version: 1, data: reflect.TypeOf(PaymentV1{})},
+	{Name: "property", Table: "properties", Version: 1, data: reflect.TypeOf(PropertyV1{})},
+	{Name: "user", Table: "users", Version: 1, data: reflect.TypeOf(UserV1{})},
+	{Name: "maintenance", Table: "maintenance_requests", Version: 1, data: reflect.TypeOf(MaintenanceV1{})},
+}
+
+// Actions are the changes published for every aggregate
+var Actions = []string{"created", "updated", "deleted"}
+
+// EventType describes one entry of the catalogue
+type EventType struct {
+	Type      string `json:"type"`
+	Aggregate string `json:"aggregate"`
+	Action    string `json:"action"`
+	Version   int    `json:"version"`
+}
+
+// Catalog lists every event type published, with its current version
+func Catalog() []EventType {
+	catalog := make([]EventType, 0, len(Aggregates)*len(Actions))
+	for _, a := range Aggregates {
+		for _, action := range Actions {
+			catalog = append(catalog, EventType{
+				Type:      a.Name + "." + action,
+				Aggregate: a.Name,
+				Action:    action,
+				Version:   a.Version,
+			})
+		}
+	}
+	return catalog
+}
+
+// Lookup returns the catalogue entry of an event type
+func Lookup(eventType string) (EventType, bool) {
+	for _, e := range Catalog() {
+		if e.Type == eventType {
+			return e, true
+		}
+	}
+	return EventType{}, false
+}
+
+// aggregateForTable returns the aggregate stored in a table
+func aggregateForTable(table string) (Aggregate, bool) {
+	for _, a := range Aggregates {
+		if a.Table == table {
+			return a, true
+		}
+	}
+	return Aggregate{}, false
+}
+
+// Schema returns the JSON schema of an aggregate's payload version
+func Schema(aggregate string, version int) ([]byte, error) {
+	schema, err := schemaFS.ReadFile(fmt.Sprintf("schemas/%s.v%d.json", aggregate, version))
+	if err != nil {
+		return nil, fmt.Errorf("no schema for %s version %d", aggregate, version)
+	}
+	return schema, nil
+}
+
+// DataType returns the Go type of an aggregate's current payload
+func (a Aggregate) DataType() reflect.Type {
+	return a.data
+}
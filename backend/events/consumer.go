@@ -3,23 +3,39 @@ package events
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"net/smtp"
+	"sync"
 
 	"github.com/geoo115/property-manager/config"
-	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/logger"
-	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/metrics"
 	"github.com/segmentio/kafka-go"
 	"github.com/sirupsen/logrus"
 )
 
-// StartKafkaConsumer runs the Kafka consumer to process maintenance events
+// Handler processes one domain event read from Kafka. Delivery is at least
+// once, so handlers must tolerate seeing an event twice.
+type Handler func(ctx context.Context, event *Envelope) error
+
+var (
+	handlersMu sync.RWMutex
+	handlers   = map[string]Handler{}
+)
+
+// Handle registers the handler for an event type. Events without a handler
+// are skipped.
+func Handle(eventType string, handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	handlers[eventType] = handler
+}
+
+// StartKafkaConsumer runs the Kafka consumer that hands domain events to
+// their handlers
 func StartKafkaConsumer(cfg *config.Config) error {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  []string{cfg.Kafka.Broker},
-		Topic:    "maintenance-requests",
-		GroupID:  "maintenance-group",
+		Topic:    cfg.Kafka.Topic,
+		GroupID:  "property-manager",
 		MaxBytes: 10e6,
 	})
 
@@ -29,7 +45,9 @@ func StartKafkaConsumer(cfg *config.Config) error {
 		}
 	}()
 
-	logger.LogInfo("Kafka Consumer started - Listening for maintenance requests", nil)
+	logger.LogInfo("Kafka Consumer started - Listening for domain events", logrus.Fields{
+		"topic": cfg.Kafka.Topic,
+	})
 
 	for {
 		msg, err := reader.ReadMessage(context.Background())
@@ -37,102 +55,44 @@ func StartKafkaConsumer(cfg *config.Config) error {
 			logger.LogError(err, "Error reading Kafka message", nil)
 			continue
 		}
+		// The high water mark is the offset of the next message to be written
+		metrics.KafkaConsumerLag.WithLabelValues(msg.Topic).Set(float64(msg.HighWaterMark - msg.Offset - 1))
 
-		var maintenance models.Maintenance
-		if err := json.Unmarshal(msg.Value, &maintenance); err != nil {
-			logger.LogError(err, "Failed to parse maintenance request", logrus.Fields{
+		var event Envelope
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			metrics.KafkaConsumed.WithLabelValues(msg.Topic, "error").Inc()
+			logger.LogError(err, "Failed to parse domain event", logrus.Fields{
 				"message": string(msg.Value),
 			})
 			continue
 		}
 
-		if err := processMaintenanceEvent(maintenance, cfg); err != nil {
-			logger.LogError(err, "Failed to process maintenance event", logrus.Fields{
-				"maintenance_id": maintenance.ID,
+		if err := dispatch(context.Background(), &event); err != nil {
+			metrics.KafkaConsumed.WithLabelValues(msg.Topic, "error").Inc()
+			logger.LogError(err, "Failed to process domain event", logrus.Fields{
+				"event_id":   event.ID,
+				"event_type": event.Type,
+				"subject":    event.Subject,
 			})
 		} else {
-			logger.LogInfo("Successfully processed maintenance event", logrus.Fields{
-				"maintenance_id": maintenance.ID,
-			})
+			metrics.KafkaConsumed.WithLabelValues(msg.Topic, "success").Inc()
 		}
 	}
 }
 
-// processMaintenanceEvent processes a maintenance request event
-func processMaintenanceEvent(maintenance models.Maintenance, cfg *config.Config) error {
-	// Log the event in audit logs
-	if err := logAuditEvent(maintenance); err != nil {
-		logger.LogError(err, "Failed to log audit event", logrus.Fields{
-			"maintenance_id": maintenance.ID,
-		})
-	}
-
-	// Send notification to maintenance team
-	if err := notifyMaintenanceTeam(maintenance, cfg); err != nil {
-		logger.LogError(err, "Failed to notify maintenance team", logrus.Fields{
-			"maintenance_id": maintenance.ID,
-		})
-	}
-
-	return nil
-}
-
-// logAuditEvent logs the maintenance event to audit logs
-func logAuditEvent(maintenance models.Maintenance) error {
-	auditLog := models.AuditLog{
-		UserID:      maintenance.RequestedByID,
-		Action:      "CREATE",
-		EntityType:  "maintenance",
-		EntityID:    maintenance.ID,
-		NewData:     fmt.Sprintf("Maintenance request created: %s", maintenance.Description),
-		Description: fmt.Sprintf("New maintenance request created for property %d", maintenance.PropertyID),
-	}
-
-	if err := db.DB.Create(&auditLog).Error; err != nil {
-		return fmt.Errorf("failed to log to audit: %v", err)
-	}
-
-	return nil
-}
-
-// notifyMaintenanceTeam sends an email notification to the maintenance team
-func notifyMaintenanceTeam(maintenance models.Maintenance, cfg *config.Config) error {
-	// Check if email configuration is available
-	if cfg.Email.SMTPHost == "" || cfg.Email.SMTPUser == "" ||
-		cfg.Email.SMTPPass == "" || cfg.Email.MaintenanceTeamEmail == "" {
-		return fmt.Errorf("missing SMTP configuration")
+// dispatch hands an event to its handler, with the event's correlation ID
+// carried on to the events the handler's writes publish
+func dispatch(ctx context.Context, event *Envelope) error {
+	handlersMu.RLock()
+	handler, ok := handlers[event.Type]
+	handlersMu.RUnlock()
+	if !ok {
+		return nil
 	}
 
-	auth := smtp.PlainAuth("", cfg.Email.SMTPUser, cfg.Email.SMTPPass, cfg.Email.SMTPHost)
-	subject := fmt.Sprintf("New Maintenance Request #%d", maintenance.ID)
-	body := fmt.Sprintf(
-		"A new maintenance request has been created:\n\n"+
-			"ID: %d\n"+
-			"Property ID: %d\n"+
-			"Title: %s\n"+
-			"Description: %s\n"+
-			"Priority: %s\n"+
-			"Category: %s\n"+
-			"Requested At: %s\n"+
-			"Status: %s\n\n"+
-			"Please review and take appropriate action.",
-		maintenance.ID, maintenance.PropertyID, maintenance.Title, maintenance.Description,
-		maintenance.Priority, maintenance.Category,
-		maintenance.RequestedAt.Format("2006-01-02 15:04:05"), maintenance.Status,
-	)
-
-	message := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s", cfg.Email.MaintenanceTeamEmail, subject, body)
-	addr := fmt.Sprintf("%s:%d", cfg.Email.SMTPHost, cfg.Email.SMTPPort)
-
-	err := smtp.SendMail(addr, auth, cfg.Email.SMTPUser, []string{cfg.Email.MaintenanceTeamEmail}, []byte(message))
-	if err != nil {
-		return fmt.Errorf("failed to send email: %v", err)
+	correlationID := event.CorrelationID
+	if correlationID == "" {
+		correlationID = event.ID
 	}
-
-	logger.LogInfo("Email notification sent to maintenance team", logrus.Fields{
-		"maintenance_id": maintenance.ID,
-		"to_email":       cfg.Email.MaintenanceTeamEmail,
-	})
-
-	return nil
+	return handler(WithCorrelationID(ctx, correlationID), event)
 }
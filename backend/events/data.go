@@ -0,0 +1,112 @@
+package events
+
+import "time"
+
+// Version 1 payloads of the domain events. They are read straight from the
+// aggregate's table, so they carry the stored columns only: no preloaded
+// relations and nothing secret. Each is described by a JSON schema in
+// schemas/; a change that is not backwards compatible needs a new version.
+
+// LeaseV1 is the data of lease events, version 1
+type LeaseV1 struct {
+	ID                    uint       `json:"id"`
+	TenantID              uint       `json:"tenant_id"`
+	PropertyID            uint       `json:"property_id"`
+	UnitID                *uint      `json:"unit_id"`
+	PreviousLeaseID       *uint      `json:"previous_lease_id"`
+	Status                string     `json:"status"`
+	LeaseType             string     `json:"lease_type"`
+	StartDate             time.Time  `json:"start_date"`
+	EndDate               time.Time  `json:"end_date"`
+	MonthlyRent           float64    `json:"monthly_rent"`
+	SecurityDeposit       float64    `json:"security_deposit"`
+	NoticePeriodDays      int        `json:"notice_period_days"`
+	TerminationNoticeDate *time.Time `json:"termination_notice_date"`
+	TerminationReason     string     `json:"termination_reason"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+// InvoiceV1 is the data of invoice events, version 1
+type InvoiceV1 struct {
+	ID                uint       `json:"id"`
+	InvoiceNumber     string     `json:"invoice_number"`
+	TenantID          uint       `json:"tenant_id"`
+	PropertyID        uint       `json:"property_id"`
+	LeaseID           *uint      `json:"lease_id"`
+	UnitID            *uint      `json:"unit_id"`
+	Category          string     `json:"category"`
+	Amount            float64    `json:"amount"`
+	PaidAmount        float64    `json:"paid_amount"`
+	RefundedAmount    float64    `json:"refunded_amount"`
+	InvoiceDate       time.Time  `json:"invoice_date"`
+	DueDate           time.Time  `json:"due_date"`
+	PeriodStart       *time.Time `json:"period_start"`
+	PeriodEnd         *time.Time `json:"period_end"`
+	PaymentStatus     string     `json:"payment_status"`
+	RecurringParentID *uint      `json:"recurring_parent_id"`
+	LateFeeForID      *uint      `json:"late_fee_for_id"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// PaymentV1 is the data of payment events, version 1
+type PaymentV1 struct {
+	ID                uint      `json:"id"`
+	ReceiptNumber     string    `json:"receipt_number"`
+	TenantID          uint      `json:"tenant_id"`
+	Type              string    `json:"type"`
+	Method            string    `json:"method"`
+	Amount            float64   `json:"amount"`
+	UnallocatedAmount float64   `json:"unallocated_amount"`
+	PaymentDate       time.Time `json:"payment_date"`
+	RefundOfID        *uint     `json:"refund_of_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// PropertyV1 is the data of property events, version 1
+type PropertyV1 struct {
+	ID           uint      `json:"id"`
+	OwnerID      uint      `json:"owner_id"`
+	Name         string    `json:"name"`
+	PropertyType string    `json:"property_type"`
+	Address      string    `json:"address"`
+	City         string    `json:"city"`
+	State        string    `json:"state"`
+	PostCode     string    `json:"post_code"`
+	Country      string    `json:"country"`
+	Bedrooms     uint      `json:"bedrooms"`
+	Bathrooms    uint      `json:"bathrooms"`
+	Price        float64   `json:"price"`
+	Available    bool      `json:"available"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UserV1 is the data of user events, version 1
+type UserV1 struct {
+	ID        uint      `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MaintenanceV1 is the data of maintenance events, version 1
+type MaintenanceV1 struct {
+	ID            uint       `json:"id"`
+	PropertyID    uint       `json:"property_id"`
+	UnitID        *uint      `json:"unit_id"`
+	LeaseID       *uint      `json:"lease_id"`
+	RequestedByID uint       `json:"requested_by_id"`
+	AssignedToID  *uint      `json:"assigned_to_id"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"`
+	Priority      string     `json:"priority"`
+	Category      string     `json:"category"`
+	RequestedAt   time.Time  `json:"requested_at"`
+	ScheduledAt   *time.Time `json:"scheduled_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
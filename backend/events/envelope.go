@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// SpecVersion is the CloudEvents version the envelope follows
+	SpecVersion = "1.0"
+	// Source identifies this service as the producer of its events
+	Source = "/property-manager"
+	// ContentType is sent with every event published to Kafka
+	ContentType = "application/cloudevents+json"
+)
+
+// correlationKey carries a correlation ID in a context without a request
+type correlationKey struct{}
+
+// Envelope wraps every domain event, following the CloudEvents structured
+// format. Version is the version of Data's schema, so consumers can decode
+// each version they support. Subject is "<aggregate>/<id>" and doubles as
+// the Kafka key.
+type Envelope struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Version         int             `json:"version"`
+	Source          string          `json:"source"`
+	Subject         string          `json:"subject"`
+	Time            time.Time       `json:"time"`
+	CorrelationID   string          `json:"correlationid,omitempty"` // Request that caused the event
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// NewEnvelope wraps data as an event about an aggregate
+func NewEnvelope(eventType string, aggregateID uint, data interface{}, correlationID string, now time.Time) (*Envelope, error) {
+	entry, ok := Lookup(eventType)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	return &Envelope{
+		SpecVersion:     SpecVersion,
+		ID:              hex.EncodeToString(b),
+		Type:            eventType,
+		Version:         entry.Version,
+		Source:          Source,
+		Subject:         Subject(entry.Aggregate, aggregateID),
+		Time:            now.UTC(),
+		CorrelationID:   correlationID,
+		DataContentType: "application/json",
+		Data:            raw,
+	}, nil
+}
+
+// Subject names an aggregate instance, e.g. "lease/12"
+func Subject(aggregate string, id uint) string {
+	return fmt.Sprintf("%s/%d", aggregate, id)
+}
+
+// Decode unmarshals the event's data into v
+func (e *Envelope) Decode(v interface{}) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s v%d data: %w", e.Type, e.Version, err)
+	}
+	return nil
+}
+
+// WithCorrelationID returns a context whose writes publish events with the
+// given correlation ID, e.g. while handling an event that carried one
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, correlationID)
+}
+
+// CorrelationID returns the correlation ID of a query context: one set with
+// WithCorrelationID, or else the ID of the request the gin context belongs to
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(correlationKey{}).(string); ok {
+		return id
+	}
+	if c, ok := ctx.Value(gin.ContextKey).(*gin.Context); ok {
+		return c.GetString("request_id")
+	}
+	return ""
+}
@@ -1,38 +1,40 @@
 package events
 
 import (
-	"context"
-	"log"
+	"time"
 
 	"github.com/geoo115/property-manager/config"
 	"github.com/segmentio/kafka-go"
 )
 
+// KafkaWriter is the producer shared by everything publishing to Kafka. It
+// has no topic of its own; each message names one. Keyed messages are
+// hashed to a partition so messages with the same key stay in order.
 var KafkaWriter *kafka.Writer
 
+// defaultTopic receives the domain events; InitKafka replaces it
+var defaultTopic = "property-events"
+
+// outboxSettings holds the configured relay behaviour; InitKafka replaces it
+var outboxSettings = config.OutboxConfig{
+	BatchSize: 100,
+	Retention: 7 * 24 * time.Hour,
+}
+
 func InitKafka(cfg *config.Config) error {
 	KafkaWriter = &kafka.Writer{
-		Addr:     kafka.TCP(cfg.Kafka.Broker),
-		Topic:    cfg.Kafka.Topic,
-		Balancer: &kafka.LeastBytes{},
+		Addr:         kafka.TCP(cfg.Kafka.Broker),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: 10 * time.Millisecond,
+		WriteTimeout: 10 * time.Second,
+		MaxAttempts:  3,
 	}
+	defaultTopic = cfg.Kafka.Topic
+	outboxSettings = cfg.Outbox
 	return nil
 }
 
-func PublishEvent(message string) {
-	if KafkaWriter == nil {
-		log.Println("Kafka writer not initialized")
-		return
-	}
-
-	err := KafkaWriter.WriteMessages(context.Background(),
-		kafka.Message{Value: []byte(message)},
-	)
-	if err != nil {
-		log.Printf("Failed to publish Kafka message: %v", err)
-	}
-}
-
 func CloseKafka() {
 	if KafkaWriter != nil {
 		KafkaWriter.Close()
@@ -0,0 +1,247 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/metrics"
+	"github.com/geoo115/property-manager/models"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// outboxLockKey is the Postgres advisory lock that serialises outbox relay
+// runs across replicas, so events are published in the order they were
+// written
+const outboxLockKey int64 = 7301005
+
+// ErrKafkaNotInitialized is returned by Relay before InitKafka has run
+var ErrKafkaNotInitialized = errors.New("kafka writer not initialized")
+
+// RelayRun summarises one pass of the outbox relay job
+type RelayRun struct {
+	Sent   []uint `json:"sent"`
+	Failed []uint `json:"failed"` // Left pending for the next run
+	Held   []uint `json:"held"`   // Left pending behind a failed event with the same key
+	Purged int64  `json:"purged"` // Relayed events deleted after the retention period
+}
+
+// NewOutboxEvent encodes value as the payload of a pending event
+func NewOutboxEvent(topic, key string, value interface{}) (models.OutboxEvent, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return models.OutboxEvent{}, fmt.Errorf("failed to encode %s event: %w", topic, err)
+	}
+	return models.OutboxEvent{
+		Topic:   topic,
+		Key:     key,
+		Payload: string(payload),
+		Status:  "pending",
+	}, nil
+}
+
+// Enqueue writes an event to the outbox. Call it inside the transaction
+// making the change so the event is published exactly when the change
+// commits.
+func Enqueue(tx *gorm.DB, topic, key string, value interface{}) error {
+	event, err := NewOutboxEvent(topic, key, value)
+	if err != nil {
+		return err
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to write %s event to the outbox: %w", topic, err)
+	}
+	return nil
+}
+
+// Message returns the Kafka message for an outbox event
+func Message(e *models.OutboxEvent) kafka.Message {
+	msg := kafka.Message{Topic: e.Topic, Value: []byte(e.Payload)}
+	if e.Key != "" {
+		msg.Key = []byte(e.Key)
+	}
+	if e.EventType != "" {
+		msg.Headers = []kafka.Header{
+			{Key: "content-type", Value: []byte(ContentType)},
+			{Key: "ce_type", Value: []byte(e.EventType)},
+		}
+	}
+	return msg
+}
+
+// Relayed records the outcome of publishing e and returns "success" or
+// "error". Failed events stay pending and are tried again on the next run.
+func Relayed(e *models.OutboxEvent, err error, now time.Time) string {
+	e.Attempts++
+	if err != nil {
+		e.LastError = err.Error()
+		return "error"
+	}
+	e.Status = "sent"
+	e.SentAt = &now
+	e.LastError = ""
+	return "success"
+}
+
+// Rounds splits pending events into write rounds that hold at most one event
+// per key, so a key's next event is only written once the broker has taken
+// the one before it. Events without a key all go in the first round.
+func Rounds(pending []models.OutboxEvent) [][]int {
+	var rounds [][]int
+	next := make(map[string]int)
+	for i, e := range pending {
+		round := 0
+		if e.Key != "" {
+			round = next[e.Key]
+			next[e.Key] = round + 1
+		}
+		if round == len(rounds) {
+			rounds = append(rounds, nil)
+		}
+		rounds[round] = append(rounds[round], i)
+	}
+	return rounds
+}
+
+// Relay publishes pending outbox events to Kafka in the order they were
+// written, one batch per run, and marks them sent. Events the broker rejects
+// stay pending, and so do the later events with the same key, which are not
+// written at all, so delivery is at least once and each key's events arrive
+// in order.
+//
+// Only one replica relays at a time: the run holds a session-scoped advisory
+// lock on a dedicated connection. No transaction stays open while Kafka is
+// written to; each result is recorded on its own, and an event whose result
+// could not be recorded holds back its key like a failed one. The run also
+// deletes relayed events older than the retention period and refreshes the
+// backlog metrics.
+func Relay(database *gorm.DB, now time.Time) (*RelayRun, error) {
+	if KafkaWriter == nil {
+		return nil, ErrKafkaNotInitialized
+	}
+	run := &RelayRun{}
+
+	err := database.Connection(func(conn *gorm.DB) error {
+		var locked bool
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", outboxLockKey).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to acquire outbox relay lock: %w", err)
+		}
+		if !locked {
+			logger.LogInfo("Outbox relay already running on another instance", nil)
+			return nil
+		}
+		defer func() {
+			// Use a fresh context so a cancelled run still releases the lock
+			if err := conn.WithContext(context.Background()).
+				Exec("SELECT pg_advisory_unlock(?)", outboxLockKey).Error; err != nil {
+				logger.LogError(err, "Failed to release outbox relay lock", nil)
+			}
+		}()
+
+		var pending []models.OutboxEvent
+		if err := conn.Where("status = ?", "pending").Order("id").Limit(outboxSettings.BatchSize).
+			Find(&pending).Error; err != nil {
+			return fmt.Errorf("failed to load pending outbox events: %w", err)
+		}
+
+		failedKeys := make(map[string]bool)
+		for _, round := range Rounds(pending) {
+			batch := make([]*models.OutboxEvent, 0, len(round))
+			for _, i := range round {
+				if e := &pending[i]; e.Key != "" && failedKeys[e.Key] {
+					run.Held = append(run.Held, e.ID)
+				} else {
+					batch = append(batch, e)
+				}
+			}
+			if len(batch) == 0 {
+				continue
+			}
+
+			messages := make([]kafka.Message, len(batch))
+			for i, e := range batch {
+				messages[i] = Message(e)
+			}
+			writeErr := KafkaWriter.WriteMessages(conn.Statement.Context, messages...)
+			var writeErrs kafka.WriteErrors
+			perMessage := errors.As(writeErr, &writeErrs) && len(writeErrs) == len(batch)
+
+			for i, e := range batch {
+				err := writeErr
+				if perMessage {
+					err = writeErrs[i]
+				}
+				result := Relayed(e, err, now)
+				metrics.KafkaProduced.WithLabelValues(e.Topic, result).Inc()
+				if recordErr := conn.Model(e).Select("status", "attempts", "last_error", "sent_at").Updates(e).Error; recordErr != nil {
+					logger.LogError(recordErr, "Failed to record outbox event", logrus.Fields{"event_id": e.ID})
+					result = "error"
+				}
+				if result != "success" && e.Key != "" {
+					failedKeys[e.Key] = true
+				}
+
+				if result == "success" {
+					run.Sent = append(run.Sent, e.ID)
+				} else {
+					run.Failed = append(run.Failed, e.ID)
+				}
+			}
+			if writeErr != nil {
+				logger.LogError(writeErr, "Failed to relay outbox events to Kafka", logrus.Fields{
+					"sent":   len(run.Sent),
+					"failed": len(run.Failed),
+				})
+			}
+		}
+
+		purged := conn.Where("status = ? AND sent_at < ?", "sent", now.Add(-outboxSettings.Retention)).
+			Delete(&models.OutboxEvent{})
+		if purged.Error != nil {
+			return fmt.Errorf("failed to purge relayed outbox events: %w", purged.Error)
+		}
+		run.Purged = purged.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := recordBacklog(database, now); err != nil {
+		logger.LogError(err, "Failed to measure outbox backlog", nil)
+	}
+	if len(run.Sent) > 0 || len(run.Failed) > 0 {
+		logger.LogInfo("Outbox relay run completed", logrus.Fields{
+			"sent":   len(run.Sent),
+			"failed": len(run.Failed),
+			"held":   len(run.Held),
+			"purged": run.Purged,
+		})
+	}
+	return run, nil
+}
+
+// recordBacklog sets the outbox backlog metrics from the pending events
+func recordBacklog(database *gorm.DB, now time.Time) error {
+	var backlog struct {
+		Count  int64
+		Oldest *time.Time
+	}
+	if err := database.Model(&models.OutboxEvent{}).Where("status = ?", "pending").
+		Select("COUNT(*) AS count, MIN(created_at) AS oldest").Scan(&backlog).Error; err != nil {
+		return err
+	}
+
+	metrics.OutboxBacklog.Set(float64(backlog.Count))
+	age := 0.0
+	if backlog.Oldest != nil {
+		age = now.Sub(*backlog.Oldest).Seconds()
+	}
+	metrics.OutboxOldestAge.Set(age)
+	return nil
+}
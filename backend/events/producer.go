@@ -2,44 +2,30 @@
 package events
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
-	"log"
-	"os"
+	"time"
 
-	"github.com/geoo115/property-manager/models"
-	"github.com/segmentio/kafka-go"
+	"gorm.io/gorm"
 )
 
-// ProduceMaintenanceRequest sends a maintenance event to Kafka
-func ProduceMaintenanceRequest(maintenance models.Maintenance) error {
-	// Create Kafka writer
-	writer := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  []string{os.Getenv("KAFKA_BROKER")},
-		Topic:    "maintenance-requests",
-		Balancer: &kafka.LeastBytes{},
-	})
-
-	// Convert maintenance request to JSON
-	data, err := json.Marshal(maintenance)
+// Publish writes a domain event about an aggregate to the outbox, wrapped in
+// an envelope and keyed by its subject so each aggregate's events reach
+// consumers in order. Call it inside the transaction making the change; the
+// relay job publishes it once that commits. Creates, updates and deletes of
+// the aggregates in the catalogue are published by the callbacks installed
+// with Register, so this is only needed for events they cannot see.
+func Publish(tx *gorm.DB, eventType string, aggregateID uint, data interface{}) error {
+	envelope, err := NewEnvelope(eventType, aggregateID, data, CorrelationID(tx.Statement.Context), time.Now())
 	if err != nil {
-		log.Printf("❌ Error encoding maintenance request: %v", err)
 		return err
 	}
-
-	// ✅ Log event before producing to Kafka
-	fmt.Printf("📤 Sending Maintenance Request to Kafka: %s\n", string(data))
-
-	// Send message to Kafka
-	err = writer.WriteMessages(context.Background(), kafka.Message{
-		Value: data,
-	})
+	event, err := NewOutboxEvent(defaultTopic, envelope.Subject, envelope)
 	if err != nil {
-		log.Printf("❌ Failed to send Kafka message: %v", err)
 		return err
 	}
-
-	fmt.Println("✅ Maintenance Request Sent Successfully to Kafka")
+	event.EventType = eventType
+	if err := tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to write %s event to the outbox: %w", eventType, err)
+	}
 	return nil
 }
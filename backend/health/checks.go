@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"errors"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/db"
+	"github.com/segmentio/kafka-go"
+)
+
+// DependencyChecks returns the checks for Postgres, Redis and Kafka.
+// Only Postgres is critical: db.Init tolerates a missing Redis and the API
+// keeps serving without Kafka, so those only degrade the service.
+func DependencyChecks(cfg *config.Config) []Check {
+	return []Check{
+		{Name: "postgres", Critical: true, Ping: db.HealthCheckContext},
+		{Name: "redis", Ping: pingRedis},
+		{Name: "kafka", Ping: kafkaPinger(cfg.Kafka.Broker)},
+	}
+}
+
+func pingRedis(ctx context.Context) error {
+	if db.RedisClient == nil {
+		return errors.New("redis client not initialized")
+	}
+	return db.RedisClient.Ping(ctx).Err()
+}
+
+func kafkaPinger(broker string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		// Listing brokers proves the connection speaks Kafka, not just TCP
+		_, err = conn.Brokers()
+		return err
+	}
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var startedAt = time.Now()
+
+// LiveHandler reports that the process is up. It never checks dependencies,
+// so an outage elsewhere cannot get the pod restarted.
+func LiveHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status":  "alive",
+			"service": "property-manager",
+			"version": "1.0.0",
+			"uptime":  time.Since(startedAt).Round(time.Second).String(),
+		})
+	}
+}
+
+// ReadyHandler runs the dependency checks and answers 503 when the service
+// cannot take traffic
+func ReadyHandler(checker *Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := checker.Run(c.Request.Context())
+		status := http.StatusOK
+		if !report.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	}
+}
@@ -0,0 +1,166 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Dependency states reported per check
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Overall service states
+const (
+	StatusHealthy     = "healthy"
+	StatusDegraded    = "degraded"
+	StatusUnavailable = "unavailable"
+	StatusShutdown    = "shutting_down"
+)
+
+// DefaultTimeout bounds a single dependency check
+const DefaultTimeout = 2 * time.Second
+
+// Check probes one dependency. A failing critical check makes the service
+// unavailable; a failing non-critical one only degrades it.
+type Check struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration
+	Ping     func(ctx context.Context) error
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report summarises every check run for a readiness probe
+type Report struct {
+	Status    string            `json:"status"`
+	Ready     bool              `json:"ready"`
+	Checks    map[string]Result `json:"checks"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// Checker runs a fixed set of dependency checks
+type Checker struct {
+	checks []Check
+}
+
+// NewChecker returns a checker for the given dependencies
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{checks: checks}
+}
+
+// Run executes all checks concurrently, each bounded by its own timeout
+func (c *Checker) Run(ctx context.Context) Report {
+	results := make(map[string]Result, len(c.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := runCheck(ctx, check)
+			mu.Lock()
+			results[check.Name] = result
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	status := Summarize(results, ShuttingDown())
+	return Report{
+		Status:    status,
+		Ready:     status == StatusHealthy || status == StatusDegraded,
+		Checks:    results,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+func runCheck(ctx context.Context, check Check) Result {
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Run the ping in its own goroutine so a client that ignores the
+	// context cannot hold the probe past its timeout
+	errCh := make(chan error, 1)
+	start := time.Now()
+	go func() { errCh <- check.Ping(ctx) }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := Result{
+		Status:    StatusUp,
+		Critical:  check.Critical,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Summarize derives the overall status from individual check results
+func Summarize(results map[string]Result, shuttingDown bool) string {
+	if shuttingDown {
+		return StatusShutdown
+	}
+	status := StatusHealthy
+	for _, r := range results {
+		if r.Status == StatusUp {
+			continue
+		}
+		if r.Critical {
+			return StatusUnavailable
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+var shuttingDown atomic.Bool
+
+// MarkShuttingDown makes readiness fail so load balancers stop routing new
+// traffic while in-flight requests drain
+func MarkShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// Drain fails readiness and then waits for delay, or until ctx is done, so
+// probes see the service as not ready before the listener closes
+func Drain(ctx context.Context, delay time.Duration) {
+	MarkShuttingDown()
+	if delay <= 0 {
+		return
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// ShuttingDown reports whether graceful shutdown has started
+func ShuttingDown() bool {
+	return shuttingDown.Load()
+}
@@ -0,0 +1,85 @@
+package leasing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// lifecycleLockKey is the Postgres advisory lock that serialises lease
+// lifecycle runs across replicas
+const lifecycleLockKey int64 = 7301003
+
+// LifecycleRun summarises one pass of the lease lifecycle job
+type LifecycleRun struct {
+	Ended     []uint `json:"ended"`
+	Activated []uint `json:"activated"`
+}
+
+// RunLifecycle ends active leases whose last day is before asOf and then
+// activates pending leases that have started, so a renewal takes over from
+// the lease it renews in the same run
+func RunLifecycle(database *gorm.DB, asOf time.Time) (*LifecycleRun, error) {
+	run := &LifecycleRun{}
+	today := truncateDay(asOf)
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", lifecycleLockKey).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to acquire lease lifecycle lock: %w", err)
+		}
+		if !locked {
+			logger.LogInfo("Lease lifecycle run already in progress on another instance", nil)
+			return nil
+		}
+
+		var ending []models.Lease
+		if err := tx.Preload("Property").
+			Where("status = ? AND end_date < ? AND deleted_at IS NULL", "active", today).
+			Order("end_date, id").Find(&ending).Error; err != nil {
+			return fmt.Errorf("failed to load ending leases: %w", err)
+		}
+		for i := range ending {
+			if err := Expire(tx, &ending[i], ending[i].Property.OwnerID); err != nil {
+				return fmt.Errorf("failed to end lease %d: %w", ending[i].ID, err)
+			}
+			run.Ended = append(run.Ended, ending[i].ID)
+		}
+
+		var starting []models.Lease
+		if err := tx.Preload("Property").
+			Where("status = ? AND start_date < ? AND end_date >= ? AND deleted_at IS NULL", "pending", today.AddDate(0, 0, 1), today).
+			Order("start_date, id").Find(&starting).Error; err != nil {
+			return fmt.Errorf("failed to load starting leases: %w", err)
+		}
+		for i := range starting {
+			if err := Activate(tx, &starting[i], starting[i].Property.OwnerID); err != nil {
+				return fmt.Errorf("failed to activate lease %d: %w", starting[i].ID, err)
+			}
+			run.Activated = append(run.Activated, starting[i].ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(run.Ended) > 0 || len(run.Activated) > 0 {
+		// Lease, property, unit and invoice caches are all affected
+		if db.RedisClient != nil {
+			db.RedisClient.FlushDB(context.Background())
+		}
+		logger.LogInfo("Lease lifecycle run completed", logrus.Fields{
+			"ended":     len(run.Ended),
+			"activated": len(run.Activated),
+			"as_of":     today.Format("2006-01-02"),
+		})
+	}
+	return run, nil
+}
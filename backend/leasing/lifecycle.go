@@ -0,0 +1,331 @@
+package leasing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/webhooks"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrInvalidTransition is returned when a lease cannot move to the requested status
+	ErrInvalidTransition = errors.New("invalid lease status transition")
+	// ErrAlreadyRenewed is returned when a lease already has a successor
+	ErrAlreadyRenewed = errors.New("lease has already been renewed")
+	// ErrUnderNotice is returned when a lease that is being terminated is renewed
+	ErrUnderNotice = errors.New("lease is under notice of termination")
+	// ErrInvalidRenewal is returned when renewal terms do not fit the lease
+	ErrInvalidRenewal = errors.New("invalid renewal terms")
+)
+
+// transitions lists the statuses each lease status may move to. Expired and
+// terminated leases are final.
+var transitions = map[string][]string{
+	"pending": {"active", "terminated"},
+	"active":  {"expired", "terminated"},
+}
+
+// CanTransition reports whether a lease may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Termination describes a request to end a lease early
+type Termination struct {
+	NoticeDate  time.Time
+	EndDate     time.Time // Requested last day; zero means as early as the notice period allows
+	Reason      string
+	WaiveNotice bool
+}
+
+// Renewal describes the terms of a successor lease
+type Renewal struct {
+	EndDate      time.Time
+	MonthlyRent  float64 // Zero keeps the current rent
+	RenewalTerms string
+}
+
+// Transition moves a lease to a new status, applying the side effects of the
+// matching lifecycle step. Termination through Transition is immediate.
+func Transition(tx *gorm.DB, lease *models.Lease, to string, actorID uint) error {
+	if !CanTransition(lease.Status, to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, lease.Status, to)
+	}
+
+	switch to {
+	case "active":
+		return Activate(tx, lease, actorID)
+	case "expired":
+		return Expire(tx, lease, actorID)
+	default:
+		_, err := Terminate(tx, lease, Termination{
+			NoticeDate:  time.Now().UTC(),
+			EndDate:     truncateDay(time.Now().UTC()).AddDate(0, 0, -1),
+			Reason:      "Terminated by status change",
+			WaiveNotice: true,
+		}, actorID)
+		return err
+	}
+}
+
+// Activate starts a pending lease: the unit or property becomes occupied and
+// its rent and deposit invoices are raised
+func Activate(tx *gorm.DB, lease *models.Lease, actorID uint) error {
+	if !CanTransition(lease.Status, "active") {
+		return fmt.Errorf("%w: %s to active", ErrInvalidTransition, lease.Status)
+	}
+
+	lease.Status = "active"
+	if err := tx.Model(lease).Update("status", lease.Status).Error; err != nil {
+		return fmt.Errorf("failed to activate lease: %w", err)
+	}
+	if err := occupy(tx, *lease); err != nil {
+		return err
+	}
+	if _, err := billing.SyncLeaseSchedule(tx, *lease, actorID); err != nil {
+		return err
+	}
+	return publish(tx, models.WebhookLeaseActivated, lease)
+}
+
+// Expire ends an active lease whose end date has passed. A lease that was
+// under notice ends as terminated rather than expired.
+func Expire(tx *gorm.DB, lease *models.Lease, actorID uint) error {
+	if !CanTransition(lease.Status, "expired") {
+		return fmt.Errorf("%w: %s to expired", ErrInvalidTransition, lease.Status)
+	}
+
+	lease.Status = "expired"
+	if lease.UnderNotice() {
+		lease.Status = "terminated"
+	}
+	return end(tx, lease, actorID)
+}
+
+// Terminate ends a lease early. For an active lease the last day is pushed
+// back to the end of the notice period unless the notice is waived; the lease
+// stays active until then and the lifecycle job ends it. Pending leases, and
+// active leases whose last day has already passed, are terminated at once.
+// It returns the effective last day of the lease.
+func Terminate(tx *gorm.DB, lease *models.Lease, termination Termination, actorID uint) (time.Time, error) {
+	if !CanTransition(lease.Status, "terminated") {
+		return time.Time{}, fmt.Errorf("%w: %s to terminated", ErrInvalidTransition, lease.Status)
+	}
+
+	noticeDate := truncateDay(termination.NoticeDate)
+	lease.TerminationNoticeDate = &noticeDate
+	lease.TerminationReason = termination.Reason
+
+	if lease.Status == "pending" {
+		lease.Status = "terminated"
+		if err := tx.Omit(clause.Associations).Save(lease).Error; err != nil {
+			return time.Time{}, fmt.Errorf("failed to terminate lease: %w", err)
+		}
+		return truncateDay(lease.EndDate), publish(tx, models.WebhookLeaseTerminated, lease)
+	}
+
+	lastDay := EffectiveEndDate(*lease, termination)
+	lease.EndDate = lastDay
+
+	// A renewal that has not started yet falls away with the lease it renews
+	if err := tx.Model(&models.Lease{}).
+		Where("previous_lease_id = ? AND status = ?", lease.ID, "pending").
+		Updates(map[string]interface{}{"status": "terminated", "termination_reason": "Previous lease terminated"}).Error; err != nil {
+		return time.Time{}, fmt.Errorf("failed to terminate renewal: %w", err)
+	}
+
+	if lastDay.Before(truncateDay(time.Now().UTC())) {
+		lease.Status = "terminated"
+		if err := end(tx, lease, actorID); err != nil {
+			return time.Time{}, err
+		}
+		return lastDay, publish(tx, models.WebhookLeaseTerminated, lease)
+	}
+
+	if err := tx.Omit(clause.Associations).Save(lease).Error; err != nil {
+		return time.Time{}, fmt.Errorf("failed to record termination notice: %w", err)
+	}
+	if _, err := billing.SyncLeaseSchedule(tx, *lease, actorID); err != nil {
+		return time.Time{}, err
+	}
+	return lastDay, publish(tx, models.WebhookLeaseTerminated, lease)
+}
+
+// EffectiveEndDate works out the last day of a lease being terminated: the
+// requested day, but no earlier than the end of the notice period (unless it
+// is waived) and no later than the current end date
+func EffectiveEndDate(lease models.Lease, termination Termination) time.Time {
+	lastDay := truncateDay(termination.EndDate)
+	if !termination.WaiveNotice {
+		earliest := truncateDay(termination.NoticeDate).AddDate(0, 0, lease.NoticePeriodDays)
+		if lastDay.Before(earliest) {
+			lastDay = earliest
+		}
+	}
+	if start := truncateDay(lease.StartDate); lastDay.Before(start) {
+		lastDay = start
+	}
+	if currentEnd := truncateDay(lease.EndDate); lastDay.After(currentEnd) {
+		lastDay = currentEnd
+	}
+	return lastDay
+}
+
+// Renew creates a pending successor lease that starts the day after the
+// current lease ends. The lifecycle job activates it on its start date.
+func Renew(tx *gorm.DB, lease *models.Lease, renewal Renewal) (*models.Lease, error) {
+	if lease.Status != "active" {
+		return nil, fmt.Errorf("%w: only active leases can be renewed", ErrInvalidTransition)
+	}
+	if lease.UnderNotice() {
+		return nil, ErrUnderNotice
+	}
+
+	var existing int64
+	if err := tx.Model(&models.Lease{}).Where("previous_lease_id = ?", lease.ID).Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to check for renewals: %w", err)
+	}
+	if existing > 0 {
+		return nil, ErrAlreadyRenewed
+	}
+
+	startDate := truncateDay(lease.EndDate).AddDate(0, 0, 1)
+	if !renewal.EndDate.After(startDate) {
+		return nil, fmt.Errorf("%w: renewal must end after %s", ErrInvalidRenewal, startDate.Format("2006-01-02"))
+	}
+
+	previousID := lease.ID
+	successor := models.Lease{
+		TenantID:         lease.TenantID,
+		PropertyID:       lease.PropertyID,
+		UnitID:           lease.UnitID,
+		StartDate:        startDate,
+		EndDate:          renewal.EndDate,
+		MonthlyRent:      lease.MonthlyRent,
+		SecurityDeposit:  lease.SecurityDeposit,
+		Status:           "pending",
+		LeaseType:        lease.LeaseType,
+		RenewalTerms:     renewal.RenewalTerms,
+		SpecialTerms:     lease.SpecialTerms,
+		NoticePeriodDays: lease.NoticePeriodDays,
+		PreviousLeaseID:  &previousID,
+	}
+	if renewal.MonthlyRent > 0 {
+		successor.MonthlyRent = renewal.MonthlyRent
+	}
+	if successor.RenewalTerms == "" {
+		successor.RenewalTerms = lease.RenewalTerms
+	}
+
+	if err := CheckOverlap(tx, successor); err != nil {
+		return nil, err
+	}
+	if err := tx.Create(&successor).Error; err != nil {
+		return nil, fmt.Errorf("failed to create renewal: %w", err)
+	}
+	if err := publish(tx, models.WebhookLeaseRenewed, &successor); err != nil {
+		return nil, err
+	}
+	return &successor, nil
+}
+
+// end stores a lease's final status, frees what it occupied and cancels the
+// invoices for periods after its last day
+func end(tx *gorm.DB, lease *models.Lease, actorID uint) error {
+	if err := tx.Omit(clause.Associations).Save(lease).Error; err != nil {
+		return fmt.Errorf("failed to end lease: %w", err)
+	}
+	if err := release(tx, *lease); err != nil {
+		return err
+	}
+	if _, err := billing.SyncLeaseSchedule(tx, *lease, actorID); err != nil {
+		return err
+	}
+	return publish(tx, models.WebhookLeaseEnded, lease)
+}
+
+// publish queues a lease event for the webhook endpoints subscribed to it
+func publish(tx *gorm.DB, eventType string, lease *models.Lease) error {
+	return webhooks.Publish(tx, eventType, lease.PropertyID, webhooks.LeaseData(*lease))
+}
+
+// UpdateOccupancy keeps the occupied unit or property in step when an active
+// lease is edited: if its unit, property or tenant changed, what it held is
+// freed and what it now points at is let to its tenant
+func UpdateOccupancy(tx *gorm.DB, before, after models.Lease) error {
+	if before.Status != "active" || sameOccupancy(before, after) {
+		return nil
+	}
+	if err := release(tx, before); err != nil {
+		return err
+	}
+	return occupy(tx, after)
+}
+
+// Vacate frees the unit or property held by an active lease that is being
+// deleted
+func Vacate(tx *gorm.DB, lease models.Lease) error {
+	if lease.Status != "active" {
+		return nil
+	}
+	return release(tx, lease)
+}
+
+func sameOccupancy(a, b models.Lease) bool {
+	if a.PropertyID != b.PropertyID || a.TenantID != b.TenantID {
+		return false
+	}
+	if a.UnitID == nil || b.UnitID == nil {
+		return a.UnitID == nil && b.UnitID == nil
+	}
+	return *a.UnitID == *b.UnitID
+}
+
+// occupy marks the unit, or the whole property for single-let leases, as let
+// to the lease's tenant
+func occupy(tx *gorm.DB, lease models.Lease) error {
+	updates := map[string]interface{}{"tenant_id": lease.TenantID, "available": false}
+	if lease.UnitID != nil {
+		if err := tx.Model(&models.Unit{}).Where("id = ?", *lease.UnitID).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to mark unit occupied: %w", err)
+		}
+		return nil
+	}
+	if err := tx.Model(&models.Property{}).Where("id = ?", lease.PropertyID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to mark property occupied: %w", err)
+	}
+	return nil
+}
+
+// release frees the unit or property of an ended lease, unless another lease
+// has taken it over in the meantime
+func release(tx *gorm.DB, lease models.Lease) error {
+	updates := map[string]interface{}{"tenant_id": nil, "available": true}
+	if lease.UnitID != nil {
+		if err := tx.Model(&models.Unit{}).Where("id = ? AND tenant_id = ?", *lease.UnitID, lease.TenantID).
+			Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to release unit: %w", err)
+		}
+		return nil
+	}
+	if err := tx.Model(&models.Property{}).Where("id = ? AND tenant_id = ?", lease.PropertyID, lease.TenantID).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to release property: %w", err)
+	}
+	return nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,134 @@
+package leasing
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// overlapConstraintCode is the Postgres SQLSTATE raised when the leases
+// exclusion constraint rejects an overlapping row
+const overlapConstraintCode = "23P01"
+
+// blockingStatuses are the lease statuses that hold a property or unit
+var blockingStatuses = []string{"active", "pending"}
+
+// OverlapError is returned when a lease would double-let a property or unit
+type OverlapError struct {
+	Conflicts []models.Lease
+}
+
+func (e *OverlapError) Error() string {
+	ids := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		ids = append(ids, fmt.Sprintf("%d", conflict.ID))
+	}
+	return "lease overlaps existing lease(s) " + strings.Join(ids, ", ")
+}
+
+// DateRange is an inclusive range of days
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// CheckOverlap locks the lease's property and returns an *OverlapError if
+// another active or pending lease holds the same unit, or the whole property,
+// on any of the same days. Leases without a unit let the whole property, so
+// they conflict with every lease on it. The property lock serialises
+// concurrent lease writes so two requests cannot both pass the check.
+func CheckOverlap(tx *gorm.DB, lease models.Lease) error {
+	if lease.Status != "" && !isBlocking(lease.Status) {
+		return nil
+	}
+
+	var property models.Property
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&property, lease.PropertyID).Error; err != nil {
+		return fmt.Errorf("failed to lock property: %w", err)
+	}
+
+	query := tx.Where("property_id = ? AND id <> ? AND status IN ? AND deleted_at IS NULL", lease.PropertyID, lease.ID, blockingStatuses).
+		Where("start_date < ? AND end_date >= ?", truncateDay(lease.EndDate).AddDate(0, 0, 1), truncateDay(lease.StartDate))
+	if lease.UnitID != nil {
+		query = query.Where("unit_id IS NULL OR unit_id = ?", *lease.UnitID)
+	}
+
+	var conflicts []models.Lease
+	if err := query.Order("start_date").Find(&conflicts).Error; err != nil {
+		return fmt.Errorf("failed to check for overlapping leases: %w", err)
+	}
+	if len(conflicts) > 0 {
+		return &OverlapError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// IsOverlapViolation reports whether err came from the database exclusion
+// constraint that backs CheckOverlap
+func IsOverlapViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == overlapConstraintCode
+}
+
+// Availability returns the free date ranges of a property, or of one of its
+// units, between from and to inclusive
+func Availability(database *gorm.DB, propertyID uint, unitID *uint, from, to time.Time) ([]DateRange, error) {
+	from, to = truncateDay(from), truncateDay(to)
+
+	query := database.Where("property_id = ? AND status IN ? AND deleted_at IS NULL", propertyID, blockingStatuses).
+		Where("start_date < ? AND end_date >= ?", to.AddDate(0, 0, 1), from)
+	if unitID != nil {
+		query = query.Where("unit_id IS NULL OR unit_id = ?", *unitID)
+	}
+
+	var leases []models.Lease
+	if err := query.Find(&leases).Error; err != nil {
+		return nil, fmt.Errorf("failed to load leases: %w", err)
+	}
+
+	booked := make([]DateRange, 0, len(leases))
+	for _, lease := range leases {
+		booked = append(booked, DateRange{Start: truncateDay(lease.StartDate), End: truncateDay(lease.EndDate)})
+	}
+	return FreeRanges(from, to, booked), nil
+}
+
+// FreeRanges returns the parts of [from, to] not covered by any booked range
+func FreeRanges(from, to time.Time, booked []DateRange) []DateRange {
+	sort.Slice(booked, func(i, j int) bool { return booked[i].Start.Before(booked[j].Start) })
+
+	free := []DateRange{}
+	cursor := from
+	for _, b := range booked {
+		if b.End.Before(cursor) {
+			continue
+		}
+		if b.Start.After(to) {
+			break
+		}
+		if b.Start.After(cursor) {
+			free = append(free, DateRange{Start: cursor, End: b.Start.AddDate(0, 0, -1)})
+		}
+		cursor = b.End.AddDate(0, 0, 1)
+	}
+	if !cursor.After(to) {
+		free = append(free, DateRange{Start: cursor, End: to})
+	}
+	return free
+}
+
+func isBlocking(status string) bool {
+	for _, s := range blockingStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
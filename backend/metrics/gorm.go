@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const queryStartKey = "metrics:start"
+
+// RegisterGORM times every statement run through db
+func RegisterGORM(db *gorm.DB) error {
+	cb := db.Callback()
+	callbacks := []struct {
+		name string
+		err  error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register("metrics:before_create", startTimer)},
+		{"create", cb.Create().After("gorm:create").Register("metrics:after_create", observe("create"))},
+		{"query", cb.Query().Before("gorm:query").Register("metrics:before_query", startTimer)},
+		{"query", cb.Query().After("gorm:query").Register("metrics:after_query", observe("query"))},
+		{"update", cb.Update().Before("gorm:update").Register("metrics:before_update", startTimer)},
+		{"update", cb.Update().After("gorm:update").Register("metrics:after_update", observe("update"))},
+		{"delete", cb.Delete().Before("gorm:delete").Register("metrics:before_delete", startTimer)},
+		{"delete", cb.Delete().After("gorm:delete").Register("metrics:after_delete", observe("delete"))},
+		{"row", cb.Row().Before("gorm:row").Register("metrics:before_row", startTimer)},
+		{"row", cb.Row().After("gorm:row").Register("metrics:after_row", observe("row"))},
+		{"raw", cb.Raw().Before("gorm:raw").Register("metrics:before_raw", startTimer)},
+		{"raw", cb.Raw().After("gorm:raw").Register("metrics:after_raw", observe("raw"))},
+	}
+	for _, c := range callbacks {
+		if c.err != nil {
+			return fmt.Errorf("failed to register %s timer: %w", c.name, c.err)
+		}
+	}
+	return nil
+}
+
+func startTimer(tx *gorm.DB) {
+	tx.InstanceSet(queryStartKey, time.Now())
+}
+
+func observe(operation string) func(*gorm.DB) {
+	return func(tx *gorm.DB) {
+		value, ok := tx.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		table := tx.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// routeGroups maps route prefixes to the group label, most specific first
+var routeGroups = []struct {
+	prefix string
+	group  string
+}{
+	{"/api/v1/admin", "admin"},
+	{"/api/v1/landlord", "landlord"},
+	{"/tenant", "tenant"},
+	{"/maintenanceTeam", "maintenance_team"},
+	{"/health", "health"},
+	{"/api/v1", "public"},
+}
+
+// RouteGroup returns the group label for a matched route path. Unmatched
+// requests are grouped together so scanners cannot blow up label cardinality.
+func RouteGroup(path string) string {
+	if path == "" {
+		return "unmatched"
+	}
+	for _, rg := range routeGroups {
+		if strings.HasPrefix(path, rg.prefix) {
+			return rg.group
+		}
+	}
+	return "other"
+}
+
+// GinMiddleware records the latency and status of every request
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		HTTPRequestDuration.WithLabelValues(
+			RouteGroup(c.FullPath()),
+			c.Request.Method,
+			strconv.Itoa(c.Writer.Status()),
+		).Observe(time.Since(start).Seconds())
+	}
+}
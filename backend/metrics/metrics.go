@@ -0,0 +1,134 @@
+// Package metrics collects Prometheus metrics for HTTP requests, database
+// queries, the Redis cache, Kafka and its outbox, rate limiting, notification
+// delivery and outbound webhooks, and serves them on a separate listener.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "property_manager"
+
+// Registry holds every metric of the service. A dedicated registry keeps
+// tests and repeated initialisation free of duplicate registration panics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route group, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"group", "method", "status"})
+
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database statement latency by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Redis cache lookups by key prefix and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	KafkaProduced = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_messages_produced_total",
+		Help:      "Kafka messages produced by topic and result (success or error).",
+	}, []string{"topic", "result"})
+
+	KafkaConsumed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_messages_consumed_total",
+		Help:      "Kafka messages consumed by topic and result (success or error).",
+	}, []string{"topic", "result"})
+
+	KafkaConsumerLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages the consumer is behind the end of the topic.",
+	}, []string{"topic"})
+
+	OutboxBacklog = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_backlog",
+		Help:      "Outbox events waiting to be relayed to Kafka.",
+	})
+
+	OutboxOldestAge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_oldest_event_age_seconds",
+		Help:      "Age of the oldest outbox event waiting to be relayed, or 0 when none are.",
+	})
+
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected by the rate limiter by route group.",
+	}, []string{"group"})
+
+	NotificationDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notification_deliveries_total",
+		Help:      "Notification delivery attempts by channel and result (sent, retry or failed).",
+	}, []string{"channel", "result"})
+
+	WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by result (sent, retry or failed).",
+	}, []string{"result"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		DBQueryDuration,
+		CacheRequests,
+		KafkaProduced,
+		KafkaConsumed,
+		KafkaConsumerLag,
+		OutboxBacklog,
+		OutboxOldestAge,
+		RateLimitRejections,
+		NotificationDeliveries,
+		WebhookDeliveries,
+	)
+}
+
+// Handler serves the registry in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// NewServer returns the metrics listener for the given port. It is kept off
+// the API port so it can be firewalled separately.
+func NewServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// Shutdown stops a metrics server started with NewServer
+func Shutdown(ctx context.Context, srv *http.Server) error {
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
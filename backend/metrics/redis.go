@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisHook counts cache hits and misses on GET commands. Keys are labelled
+// by their prefix ("properties", "units", ...); rate limiter counters are not
+// a cache and are skipped.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+func (RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	recordCacheLookup(cmd)
+	return nil
+}
+
+func (RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	for _, cmd := range cmds {
+		recordCacheLookup(cmd)
+	}
+	return nil
+}
+
+func recordCacheLookup(cmd redis.Cmder) {
+	if cmd.Name() != "get" {
+		return
+	}
+	args := cmd.Args()
+	if len(args) < 2 {
+		return
+	}
+	key, _ := args[1].(string)
+	cache := CachePrefix(key)
+	if cache == "rate_limit" {
+		return
+	}
+
+	switch err := cmd.Err(); {
+	case err == nil:
+		CacheRequests.WithLabelValues(cache, "hit").Inc()
+	case err == redis.Nil:
+		CacheRequests.WithLabelValues(cache, "miss").Inc()
+	}
+}
+
+// CachePrefix returns the part of a cache key before the first colon
+func CachePrefix(key string) string {
+	if i := strings.Index(key, ":"); i > 0 {
+		return key[:i]
+	}
+	return key
+}
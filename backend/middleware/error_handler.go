@@ -169,7 +169,10 @@ func RequestLogger() gin.HandlerFunc {
 			"user_agent": c.Request.UserAgent(),
 		}
 
-		// Add user info if available
+		// Add request and user info if available
+		if requestID := c.GetString("request_id"); requestID != "" {
+			fields["request_id"] = requestID
+		}
 		if userID, exists := c.Get("user_id"); exists {
 			fields["user_id"] = userID
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/geoo115/property-manager/db"
 	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/metrics"
 	"github.com/geoo115/property-manager/response"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
@@ -77,6 +78,7 @@ func RateLimit(config RateLimitConfig) gin.HandlerFunc {
 			c.Header("X-RateLimit-Remaining", "0")
 			c.Header("Retry-After", fmt.Sprintf("%d", int(config.Window.Seconds())))
 
+			metrics.RateLimitRejections.WithLabelValues(metrics.RouteGroup(c.FullPath())).Inc()
 			response.TooManyRequests(c, "Rate limit exceeded")
 			c.Abort()
 			return
@@ -0,0 +1,269 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// lockKey identifies the Postgres advisory lock held while migrating, so
+// replicas booting together apply each migration exactly once
+const lockKey int64 = 7_265_110_214_017_231_117
+
+const createTableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version BIGINT PRIMARY KEY,
+	name TEXT NOT NULL,
+	checksum TEXT NOT NULL,
+	applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+// Record is a row of schema_migrations
+type Record struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Status describes one migration as the database sees it
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Modified means the shipped SQL no longer matches what was applied
+	Modified bool
+	// Missing means the database has a version this binary does not ship
+	Missing bool
+}
+
+// Pending returns the migrations not yet applied, in version order. It
+// refuses to continue when an applied migration has since been edited.
+func Pending(migrations []Migration, applied map[int64]Record) ([]Migration, error) {
+	var pending []Migration
+	for _, m := range migrations {
+		record, ok := applied[m.Version]
+		if !ok {
+			pending = append(pending, m)
+			continue
+		}
+		if record.Checksum != m.Checksum() {
+			return nil, fmt.Errorf("migration %s was modified after it was applied (checksum mismatch)", m)
+		}
+	}
+	return pending, nil
+}
+
+// Statuses merges the shipped migrations with the applied records
+func Statuses(migrations []Migration, applied map[int64]Record) []Status {
+	shipped := make(map[int64]bool, len(migrations))
+	statuses := make([]Status, 0, len(migrations))
+
+	for _, m := range migrations {
+		shipped[m.Version] = true
+		status := Status{Version: m.Version, Name: m.Name}
+		if record, ok := applied[m.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != m.Checksum()
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, record := range applied {
+		if shipped[record.Version] {
+			continue
+		}
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, Status{
+			Version:   record.Version,
+			Name:      record.Name,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses
+}
+
+// Migrator applies and reverts migrations against a Postgres database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New returns a migrator for the given migrations
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// Up applies every pending migration, each in its own transaction
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := loadRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+		pending, err := Pending(m.migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range pending {
+			start := time.Now()
+			err := runInTx(ctx, conn, migration.Up, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx,
+					"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+					migration.Version, migration.Name, migration.Checksum(),
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s failed: %w", migration, err)
+			}
+
+			logger.LogInfo("Applied migration", logrus.Fields{
+				"migration": migration.String(),
+				"duration":  time.Since(start).String(),
+			})
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the most recently applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+
+	shipped := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		shipped[migration.Version] = migration
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := loadRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+		if steps > len(versions) {
+			steps = len(versions)
+		}
+
+		for _, version := range versions[:steps] {
+			migration, ok := shipped[version]
+			if !ok {
+				return fmt.Errorf("cannot revert %04d_%s: it is not shipped with this binary", version, applied[version].Name)
+			}
+
+			err := runInTx(ctx, conn, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("reverting %s failed: %w", migration, err)
+			}
+
+			logger.LogInfo("Reverted migration", logrus.Fields{
+				"migration": migration.String(),
+			})
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := loadRecords(ctx, conn)
+		if err != nil {
+			return err
+		}
+		statuses = Statuses(m.migrations, applied)
+		return nil
+	})
+	return statuses, err
+}
+
+// withLock runs fn on a single connection holding the advisory lock. The
+// lock is session scoped, so everything must go through that connection.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so a cancelled run still releases the lock
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey); err != nil {
+			logger.LogError(err, "Failed to release migration lock", nil)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+func loadRecords(ctx context.Context, conn *sql.Conn) (map[int64]Record, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	records := make(map[int64]Record)
+	for rows.Next() {
+		var r Record
+		if err := rows.Scan(&r.Version, &r.Name, &r.Checksum, &r.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		records[r.Version] = r
+	}
+	return records, rows.Err()
+}
+
+// runInTx executes a migration body and its bookkeeping atomically
+func runInTx(ctx context.Context, conn *sql.Conn, body string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if !isEmpty(body) {
+		if _, err := tx.ExecContext(ctx, body); err != nil {
+			return err
+		}
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -13,17 +13,22 @@ type Invoice struct {
 	TenantID          uint       `json:"tenant_id" gorm:"not null;index"`
 	PropertyID        uint       `json:"property_id" gorm:"not null;index"`
 	LeaseID           *uint      `json:"lease_id" gorm:"index"`
+	UnitID            *uint      `json:"unit_id" gorm:"index"`
 	CreatedByID       uint       `json:"created_by_id" gorm:"not null;index"`
 	InvoiceNumber     string     `json:"invoice_number" gorm:"unique;not null"`
 	Amount            float64    `json:"amount" gorm:"not null"`
 	PaidAmount        float64    `json:"paid_amount" gorm:"default:0"`
-	InvoiceDate       time.Time  `json:"invoice_date" gorm:"not null"`
+	InvoiceDate       time.Time  `json:"invoice_date" gorm:"not null;uniqueIndex:idx_invoices_recurring_period"`
 	Category          string     `json:"category" gorm:"not null;check:category IN ('rent','utilities','late_fee','deposit','maintenance','other')"`
 	DueDate           time.Time  `json:"due_date" gorm:"not null"`
+	PeriodStart       *time.Time `json:"period_start"` // Billing period covered, e.g. for rent
+	PeriodEnd         *time.Time `json:"period_end"`
 	PaymentStatus     string     `json:"payment_status" gorm:"default:'pending';check:payment_status IN ('paid','pending','overdue','cancelled')"`
 	RefundedAmount    float64    `json:"refunded_amount" gorm:"default:0"`
 	RecurringInterval string     `json:"recurring_interval" gorm:"check:recurring_interval IN ('','monthly','quarterly','yearly')"`
 	Recurring         bool       `json:"recurring" gorm:"default:false"`
+	RecurringParentID *uint      `json:"recurring_parent_id" gorm:"uniqueIndex:idx_invoices_recurring_period"` // Template this invoice was generated from
+	LateFeeForID      *uint      `json:"late_fee_for_id" gorm:"uniqueIndex"`                                   // Overdue invoice a late fee was charged on
 	PaymentMethod     string     `json:"payment_method" gorm:"check:payment_method IN ('','cash','bank_transfer','card','cheque')"`
 	Notes             string     `json:"notes" gorm:"type:text"`
 	CreatedAt         time.Time  `json:"created_at"`
@@ -34,6 +39,7 @@ type Invoice struct {
 	Tenant    User     `json:"tenant" gorm:"foreignKey:TenantID;constraint:OnDelete:CASCADE;"`
 	Property  Property `json:"property" gorm:"foreignKey:PropertyID;constraint:OnDelete:CASCADE;"`
 	Lease     *Lease   `json:"lease,omitempty" gorm:"foreignKey:LeaseID;constraint:OnDelete:SET NULL;"`
+	Unit      *Unit    `json:"unit,omitempty" gorm:"foreignKey:UnitID;constraint:OnDelete:SET NULL;"`
 	CreatedBy User     `json:"created_by" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
 }
 
@@ -50,6 +56,9 @@ type Expense struct {
 	VendorName    string     `json:"vendor_name"`
 	VendorEmail   string     `json:"vendor_email"`
 	VendorPhone   string     `json:"vendor_phone"`
+	VendorID      *uint      `json:"vendor_id" gorm:"index"` // Directory vendor the vendor_* fields were copied from
+	MaintenanceID *uint      `json:"maintenance_id" gorm:"index"`
+	Source        string     `json:"source" gorm:"not null;default:'manual';check:source IN ('manual','maintenance','work_order')"`
 	PaymentMethod string     `json:"payment_method" gorm:"check:payment_method IN ('','cash','bank_transfer','card','cheque')"`
 	ReceiptURL    string     `json:"receipt_url"`
 	Notes         string     `json:"notes" gorm:"type:text"`
@@ -58,8 +67,10 @@ type Expense struct {
 	DeletedAt     *time.Time `json:"deleted_at" gorm:"index"`
 
 	// Relationships
-	Property  Property `json:"property" gorm:"foreignKey:PropertyID;constraint:OnDelete:CASCADE;"`
-	CreatedBy User     `json:"created_by" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
+	Property    Property     `json:"property" gorm:"foreignKey:PropertyID;constraint:OnDelete:CASCADE;"`
+	CreatedBy   User         `json:"created_by" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
+	Vendor      *Vendor      `json:"vendor,omitempty" gorm:"foreignKey:VendorID;constraint:OnDelete:SET NULL;"`
+	Maintenance *Maintenance `json:"-" gorm:"foreignKey:MaintenanceID;constraint:OnDelete:SET NULL;"`
 }
 
 // InvoiceCreateRequest represents invoice creation request
@@ -67,6 +78,7 @@ type InvoiceCreateRequest struct {
 	TenantID          uint      `json:"tenant_id" binding:"required"`
 	PropertyID        uint      `json:"property_id" binding:"required"`
 	LeaseID           *uint     `json:"lease_id"`
+	UnitID            *uint     `json:"unit_id"`
 	Amount            float64   `json:"amount" binding:"required"`
 	InvoiceDate       time.Time `json:"invoice_date" binding:"required"`
 	Category          string    `json:"category" binding:"required"`
@@ -101,15 +113,20 @@ type InvoiceResponse struct {
 	InvoiceDate       time.Time        `json:"invoice_date"`
 	Category          string           `json:"category"`
 	DueDate           time.Time        `json:"due_date"`
+	PeriodStart       *time.Time       `json:"period_start"`
+	PeriodEnd         *time.Time       `json:"period_end"`
 	PaymentStatus     string           `json:"payment_status"`
 	RefundedAmount    float64          `json:"refunded_amount"`
 	RecurringInterval string           `json:"recurring_interval"`
 	Recurring         bool             `json:"recurring"`
+	RecurringParentID *uint            `json:"recurring_parent_id"`
+	LateFeeForID      *uint            `json:"late_fee_for_id"`
 	PaymentMethod     string           `json:"payment_method"`
 	Notes             string           `json:"notes"`
 	Tenant            UserResponse     `json:"tenant"`
 	Property          PropertyResponse `json:"property"`
 	Lease             *LeaseResponse   `json:"lease"`
+	Unit              *UnitResponse    `json:"unit"`
 	CreatedBy         UserResponse     `json:"created_by"`
 	CreatedAt         time.Time        `json:"created_at"`
 	UpdatedAt         time.Time        `json:"updated_at"`
@@ -155,6 +172,9 @@ type ExpenseResponse struct {
 	VendorName    string           `json:"vendor_name"`
 	VendorEmail   string           `json:"vendor_email"`
 	VendorPhone   string           `json:"vendor_phone"`
+	VendorID      *uint            `json:"vendor_id"`
+	MaintenanceID *uint            `json:"maintenance_id"`
+	Source        string           `json:"source"`
 	PaymentMethod string           `json:"payment_method"`
 	ReceiptURL    string           `json:"receipt_url"`
 	Notes         string           `json:"notes"`
@@ -174,10 +194,14 @@ func (i *Invoice) ToResponse() InvoiceResponse {
 		InvoiceDate:       i.InvoiceDate,
 		Category:          i.Category,
 		DueDate:           i.DueDate,
+		PeriodStart:       i.PeriodStart,
+		PeriodEnd:         i.PeriodEnd,
 		PaymentStatus:     i.PaymentStatus,
 		RefundedAmount:    i.RefundedAmount,
 		RecurringInterval: i.RecurringInterval,
 		Recurring:         i.Recurring,
+		RecurringParentID: i.RecurringParentID,
+		LateFeeForID:      i.LateFeeForID,
 		PaymentMethod:     i.PaymentMethod,
 		Notes:             i.Notes,
 		Tenant:            i.Tenant.ToResponse(),
@@ -192,6 +216,11 @@ func (i *Invoice) ToResponse() InvoiceResponse {
 		response.Lease = &leaseResponse
 	}
 
+	if i.Unit != nil {
+		unitResponse := i.Unit.ToResponse()
+		response.Unit = &unitResponse
+	}
+
 	return response
 }
 
@@ -207,6 +236,9 @@ func (e *Expense) ToResponse() ExpenseResponse {
 		VendorName:    e.VendorName,
 		VendorEmail:   e.VendorEmail,
 		VendorPhone:   e.VendorPhone,
+		VendorID:      e.VendorID,
+		MaintenanceID: e.MaintenanceID,
+		Source:        e.Source,
 		PaymentMethod: e.PaymentMethod,
 		ReceiptURL:    e.ReceiptURL,
 		Notes:         e.Notes,
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// Attachment is an uploaded file linked to a property, lease, maintenance
+// request, maintenance comment or expense. The contents live in the configured file store under
+// StorageKey.
+type Attachment struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	EntityType   string     `json:"entity_type" gorm:"not null;index:idx_attachments_entity;check:entity_type IN ('property','lease','maintenance','maintenance_comment','expense')"`
+	EntityID     uint       `json:"entity_id" gorm:"not null;index:idx_attachments_entity"`
+	StorageKey   string     `json:"-" gorm:"not null;unique"`
+	FileName     string     `json:"file_name" gorm:"not null"`
+	ContentType  string     `json:"content_type" gorm:"not null"`
+	Size         int64      `json:"size" gorm:"not null"`
+	UploadedByID uint       `json:"uploaded_by_id" gorm:"not null;index"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	UploadedBy User `json:"uploaded_by" gorm:"foreignKey:UploadedByID;constraint:OnDelete:CASCADE;"`
+}
+
+// AttachmentResponse represents attachment response with a signed download URL
+type AttachmentResponse struct {
+	ID           uint      `json:"id"`
+	EntityType   string    `json:"entity_type"`
+	EntityID     uint      `json:"entity_id"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	UploadedByID uint      `json:"uploaded_by_id"`
+	DownloadURL  string    `json:"download_url"`
+	URLExpiresAt time.Time `json:"url_expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ToResponse converts Attachment to AttachmentResponse
+func (a *Attachment) ToResponse(downloadURL string, expiresAt time.Time) AttachmentResponse {
+	return AttachmentResponse{
+		ID:           a.ID,
+		EntityType:   a.EntityType,
+		EntityID:     a.EntityID,
+		FileName:     a.FileName,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		UploadedByID: a.UploadedByID,
+		DownloadURL:  downloadURL,
+		URLExpiresAt: expiresAt,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// TableName returns the table name for Attachment model
+func (Attachment) TableName() string {
+	return "attachments"
+}
@@ -6,7 +6,7 @@ import (
 
 type AuditLog struct {
 	ID          uint      `json:"id" gorm:"primaryKey"`
-	UserID      uint      `json:"user_id" gorm:"not null;index"`
+	UserID      *uint     `json:"user_id" gorm:"index"` // Nil for actions taken by background jobs
 	Action      string    `json:"action" gorm:"not null;index"`
 	EntityType  string    `json:"entity_type" gorm:"not null;index"`
 	EntityID    uint      `json:"entity_id" gorm:"not null;index"`
@@ -18,27 +18,27 @@ type AuditLog struct {
 	CreatedAt   time.Time `json:"created_at"`
 
 	// Relationships
-	User User `json:"user" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID;constraint:OnDelete:SET NULL;"`
 }
 
 // AuditLogResponse represents audit log response
 type AuditLogResponse struct {
-	ID          uint         `json:"id"`
-	Action      string       `json:"action"`
-	EntityType  string       `json:"entity_type"`
-	EntityID    uint         `json:"entity_id"`
-	OldData     string       `json:"old_data"`
-	NewData     string       `json:"new_data"`
-	IPAddress   string       `json:"ip_address"`
-	UserAgent   string       `json:"user_agent"`
-	Description string       `json:"description"`
-	User        UserResponse `json:"user"`
-	CreatedAt   time.Time    `json:"created_at"`
+	ID          uint          `json:"id"`
+	Action      string        `json:"action"`
+	EntityType  string        `json:"entity_type"`
+	EntityID    uint          `json:"entity_id"`
+	OldData     string        `json:"old_data"`
+	NewData     string        `json:"new_data"`
+	IPAddress   string        `json:"ip_address"`
+	UserAgent   string        `json:"user_agent"`
+	Description string        `json:"description"`
+	User        *UserResponse `json:"user"`
+	CreatedAt   time.Time     `json:"created_at"`
 }
 
 // ToResponse converts AuditLog to AuditLogResponse
 func (a *AuditLog) ToResponse() AuditLogResponse {
-	return AuditLogResponse{
+	response := AuditLogResponse{
 		ID:          a.ID,
 		Action:      a.Action,
 		EntityType:  a.EntityType,
@@ -48,9 +48,13 @@ func (a *AuditLog) ToResponse() AuditLogResponse {
 		IPAddress:   a.IPAddress,
 		UserAgent:   a.UserAgent,
 		Description: a.Description,
-		User:        a.User.ToResponse(),
 		CreatedAt:   a.CreatedAt,
 	}
+	if a.User != nil {
+		user := a.User.ToResponse()
+		response.User = &user
+	}
+	return response
 }
 
 // TableName returns the table name for AuditLog model
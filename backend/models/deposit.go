@@ -0,0 +1,233 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// Deposit tracks the security deposit held for a lease, from receipt and
+// protection through move-out deductions to its return to the tenant
+type Deposit struct {
+	ID                  uint       `json:"id" gorm:"primaryKey"`
+	LeaseID             uint       `json:"lease_id" gorm:"not null;uniqueIndex"`
+	TenantID            uint       `json:"tenant_id" gorm:"not null;index"`
+	Amount              float64    `json:"amount" gorm:"not null"` // Amount due under the lease
+	ReceivedAmount      float64    `json:"received_amount" gorm:"default:0"`
+	ReceivedAt          *time.Time `json:"received_at"`
+	ProtectionScheme    string     `json:"protection_scheme"` // e.g. DPS, TDS, mydeposits
+	ProtectionReference string     `json:"protection_reference"`
+	ProtectedAt         *time.Time `json:"protected_at"`
+	Status              string     `json:"status" gorm:"default:'awaiting';check:status IN ('awaiting','held','settlement_proposed','disputed','returned')"`
+	ReturnedAmount      float64    `json:"returned_amount" gorm:"default:0"`
+	ReturnedAt          *time.Time `json:"returned_at"`
+	ReturnMethod        string     `json:"return_method" gorm:"check:return_method IN ('','cash','bank_transfer','card','cheque')"`
+	ReturnReference     string     `json:"return_reference"`
+	DisputeReason       string     `json:"dispute_reason" gorm:"type:text"`
+	DisputedAt          *time.Time `json:"disputed_at"`
+	DisputeResolution   string     `json:"dispute_resolution" gorm:"type:text"`
+	Notes               string     `json:"notes" gorm:"type:text"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletedAt           *time.Time `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Lease      Lease              `json:"lease" gorm:"foreignKey:LeaseID;constraint:OnDelete:CASCADE;"`
+	Tenant     User               `json:"tenant" gorm:"foreignKey:TenantID;constraint:OnDelete:CASCADE;"`
+	Deductions []DepositDeduction `json:"deductions,omitempty" gorm:"foreignKey:DepositID;constraint:OnDelete:CASCADE;"`
+}
+
+// DepositDeduction is one itemised amount kept back from a deposit
+type DepositDeduction struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	DepositID     uint      `json:"deposit_id" gorm:"not null;index"`
+	Category      string    `json:"category" gorm:"not null;check:category IN ('damage','cleaning','rent_arrears','missing_items','other')"`
+	Description   string    `json:"description" gorm:"not null"`
+	Amount        float64   `json:"amount" gorm:"not null"`
+	MaintenanceID *uint     `json:"maintenance_id" gorm:"index"` // Repair the deduction pays for
+	ExpenseID     *uint     `json:"expense_id" gorm:"index"`     // Cost the deduction recovers
+	CreatedByID   uint      `json:"created_by_id" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relationships
+	Maintenance *Maintenance `json:"maintenance,omitempty" gorm:"foreignKey:MaintenanceID;constraint:OnDelete:SET NULL;"`
+	Expense     *Expense     `json:"expense,omitempty" gorm:"foreignKey:ExpenseID;constraint:OnDelete:SET NULL;"`
+	CreatedBy   User         `json:"created_by" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
+}
+
+// DepositReceiptRequest represents a request to record a received deposit
+type DepositReceiptRequest struct {
+	Amount              float64 `json:"amount"` // Defaults to the lease's security deposit
+	ReceivedAt          string  `json:"received_at"`
+	ProtectionScheme    string  `json:"protection_scheme"`
+	ProtectionReference string  `json:"protection_reference"`
+	ProtectedAt         string  `json:"protected_at"`
+	Notes               string  `json:"notes"`
+}
+
+// DepositDeductionRequest represents a deduction creation request
+type DepositDeductionRequest struct {
+	Category      string  `json:"category" binding:"required"`
+	Description   string  `json:"description" binding:"required"`
+	Amount        float64 `json:"amount" binding:"required"`
+	MaintenanceID *uint   `json:"maintenance_id"`
+	ExpenseID     *uint   `json:"expense_id"`
+}
+
+// DepositSettlementRequest represents the return of a deposit to the tenant
+type DepositSettlementRequest struct {
+	ReturnMethod      string `json:"return_method" binding:"required,oneof=cash bank_transfer card cheque"`
+	ReturnReference   string `json:"return_reference"`
+	ReturnedAt        string `json:"returned_at"`
+	DisputeResolution string `json:"dispute_resolution"`
+}
+
+// DepositDisputeRequest represents a tenant disputing proposed deductions
+type DepositDisputeRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// DepositResponse represents deposit response
+type DepositResponse struct {
+	ID                  uint                       `json:"id"`
+	LeaseID             uint                       `json:"lease_id"`
+	TenantID            uint                       `json:"tenant_id"`
+	Amount              float64                    `json:"amount"`
+	ReceivedAmount      float64                    `json:"received_amount"`
+	ReceivedAt          *time.Time                 `json:"received_at"`
+	ProtectionScheme    string                     `json:"protection_scheme"`
+	ProtectionReference string                     `json:"protection_reference"`
+	ProtectedAt         *time.Time                 `json:"protected_at"`
+	Status              string                     `json:"status"`
+	Deductions          []DepositDeductionResponse `json:"deductions"`
+	TotalDeductions     float64                    `json:"total_deductions"`
+	AmountToReturn      float64                    `json:"amount_to_return"`
+	ReturnedAmount      float64                    `json:"returned_amount"`
+	ReturnedAt          *time.Time                 `json:"returned_at"`
+	ReturnMethod        string                     `json:"return_method"`
+	ReturnReference     string                     `json:"return_reference"`
+	DisputeReason       string                     `json:"dispute_reason"`
+	DisputedAt          *time.Time                 `json:"disputed_at"`
+	DisputeResolution   string                     `json:"dispute_resolution"`
+	Notes               string                     `json:"notes"`
+	CreatedAt           time.Time                  `json:"created_at"`
+	UpdatedAt           time.Time                  `json:"updated_at"`
+}
+
+// DepositDeductionResponse represents a deduction in responses
+type DepositDeductionResponse struct {
+	ID            uint      `json:"id"`
+	Category      string    `json:"category"`
+	Description   string    `json:"description"`
+	Amount        float64   `json:"amount"`
+	MaintenanceID *uint     `json:"maintenance_id"`
+	ExpenseID     *uint     `json:"expense_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ToResponse converts Deposit to DepositResponse
+func (d *Deposit) ToResponse() DepositResponse {
+	response := DepositResponse{
+		ID:                  d.ID,
+		LeaseID:             d.LeaseID,
+		TenantID:            d.TenantID,
+		Amount:              d.Amount,
+		ReceivedAmount:      d.ReceivedAmount,
+		ReceivedAt:          d.ReceivedAt,
+		ProtectionScheme:    d.ProtectionScheme,
+		ProtectionReference: d.ProtectionReference,
+		ProtectedAt:         d.ProtectedAt,
+		Status:              d.Status,
+		Deductions:          make([]DepositDeductionResponse, 0, len(d.Deductions)),
+		TotalDeductions:     d.TotalDeductions(),
+		AmountToReturn:      d.AmountToReturn(),
+		ReturnedAmount:      d.ReturnedAmount,
+		ReturnedAt:          d.ReturnedAt,
+		ReturnMethod:        d.ReturnMethod,
+		ReturnReference:     d.ReturnReference,
+		DisputeReason:       d.DisputeReason,
+		DisputedAt:          d.DisputedAt,
+		DisputeResolution:   d.DisputeResolution,
+		Notes:               d.Notes,
+		CreatedAt:           d.CreatedAt,
+		UpdatedAt:           d.UpdatedAt,
+	}
+
+	for _, deduction := range d.Deductions {
+		response.Deductions = append(response.Deductions, DepositDeductionResponse{
+			ID:            deduction.ID,
+			Category:      deduction.Category,
+			Description:   deduction.Description,
+			Amount:        deduction.Amount,
+			MaintenanceID: deduction.MaintenanceID,
+			ExpenseID:     deduction.ExpenseID,
+			CreatedAt:     deduction.CreatedAt,
+		})
+	}
+
+	return response
+}
+
+// Validate validates deposit deduction request
+func (req *DepositDeductionRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidateRequired(req.Description, "description"),
+		validator.ValidateMaxLength(req.Description, 500, "description"),
+		validator.ValidatePositiveFloat(req.Amount, "amount"),
+	)
+
+	validCategories := []string{"damage", "cleaning", "rent_arrears", "missing_items", "other"}
+	valid := false
+	for _, c := range validCategories {
+		if req.Category == c {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		errors = append(errors, validator.ValidationError{
+			Field:   "category",
+			Message: "must be one of: damage, cleaning, rent_arrears, missing_items, other",
+			Value:   req.Category,
+		})
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// TotalDeductions returns the sum of the itemised deductions
+func (d *Deposit) TotalDeductions() float64 {
+	total := 0.0
+	for _, deduction := range d.Deductions {
+		total += deduction.Amount
+	}
+	return math.Round(total*100) / 100
+}
+
+// AmountToReturn returns what is owed back to the tenant after deductions
+func (d *Deposit) AmountToReturn() float64 {
+	amount := math.Round((d.ReceivedAmount-d.TotalDeductions())*100) / 100
+	if amount < 0 {
+		return 0
+	}
+	return amount
+}
+
+// CanDeduct checks if deductions may still be changed
+func (d *Deposit) CanDeduct() bool {
+	return d.Status == "held" || d.Status == "settlement_proposed" || d.Status == "disputed"
+}
+
+// TableName returns the table name for Deposit model
+func (Deposit) TableName() string {
+	return "deposits"
+}
+
+// TableName returns the table name for DepositDeduction model
+func (DepositDeduction) TableName() string {
+	return "deposit_deductions"
+}
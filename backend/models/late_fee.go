@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// LateFeePolicy configures how a landlord charges for overdue invoices. The
+// fee is FlatFee plus Percentage of the outstanding balance, charged once per
+// invoice after GraceDays, and limited to MaxFee when that is set.
+type LateFeePolicy struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	LandlordID uint       `json:"landlord_id" gorm:"not null;uniqueIndex"`
+	Enabled    bool       `json:"enabled" gorm:"not null"`
+	FlatFee    float64    `json:"flat_fee" gorm:"default:0;check:flat_fee >= 0"`
+	Percentage float64    `json:"percentage" gorm:"default:0;check:percentage >= 0 AND percentage <= 100"`
+	GraceDays  int        `json:"grace_days" gorm:"default:0;check:grace_days >= 0"`
+	MaxFee     float64    `json:"max_fee" gorm:"default:0;check:max_fee >= 0"` // 0 means no cap
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	DeletedAt  *time.Time `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Landlord User `json:"landlord" gorm:"foreignKey:LandlordID;constraint:OnDelete:CASCADE;"`
+}
+
+// LateFeePolicyRequest represents a late fee policy create or update request
+type LateFeePolicyRequest struct {
+	Enabled    *bool   `json:"enabled"`
+	FlatFee    float64 `json:"flat_fee"`
+	Percentage float64 `json:"percentage"`
+	GraceDays  int     `json:"grace_days"`
+	MaxFee     float64 `json:"max_fee"`
+}
+
+// LateFeePolicyResponse represents late fee policy response
+type LateFeePolicyResponse struct {
+	ID         uint      `json:"id"`
+	LandlordID uint      `json:"landlord_id"`
+	Enabled    bool      `json:"enabled"`
+	FlatFee    float64   `json:"flat_fee"`
+	Percentage float64   `json:"percentage"`
+	GraceDays  int       `json:"grace_days"`
+	MaxFee     float64   `json:"max_fee"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToResponse converts LateFeePolicy to LateFeePolicyResponse
+func (p *LateFeePolicy) ToResponse() LateFeePolicyResponse {
+	return LateFeePolicyResponse{
+		ID:         p.ID,
+		LandlordID: p.LandlordID,
+		Enabled:    p.Enabled,
+		FlatFee:    p.FlatFee,
+		Percentage: p.Percentage,
+		GraceDays:  p.GraceDays,
+		MaxFee:     p.MaxFee,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
+
+// Validate validates late fee policy request
+func (req *LateFeePolicyRequest) Validate() error {
+	var errors validator.ValidationErrors
+
+	if req.FlatFee < 0 {
+		errors = append(errors, validator.ValidationError{Field: "flat_fee", Message: "must not be negative", Value: req.FlatFee})
+	}
+	if req.Percentage < 0 || req.Percentage > 100 {
+		errors = append(errors, validator.ValidationError{Field: "percentage", Message: "must be between 0 and 100", Value: req.Percentage})
+	}
+	if req.GraceDays < 0 {
+		errors = append(errors, validator.ValidationError{Field: "grace_days", Message: "must not be negative", Value: req.GraceDays})
+	}
+	if req.MaxFee < 0 {
+		errors = append(errors, validator.ValidationError{Field: "max_fee", Message: "must not be negative", Value: req.MaxFee})
+	}
+	if req.FlatFee == 0 && req.Percentage == 0 {
+		errors = append(errors, validator.ValidationError{Field: "flat_fee", Message: "flat_fee or percentage must be set"})
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// TableName returns the table name for LateFeePolicy model
+func (LateFeePolicy) TableName() string {
+	return "late_fee_policies"
+}
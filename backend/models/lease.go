@@ -10,6 +10,7 @@ type Lease struct {
 	ID              uint       `json:"id" gorm:"primaryKey"`
 	TenantID        uint       `json:"tenant_id" gorm:"not null;index"`
 	PropertyID      uint       `json:"property_id" gorm:"not null;index"`
+	UnitID          *uint      `json:"unit_id" gorm:"index"` // Optional unit within a multi-unit property
 	StartDate       time.Time  `json:"start_date" gorm:"type:timestamp"`
 	EndDate         time.Time  `json:"end_date" gorm:"type:timestamp"`
 	MonthlyRent     float64    `json:"monthly_rent" gorm:"not null"`
@@ -22,9 +23,17 @@ type Lease struct {
 	UpdatedAt       time.Time  `json:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at" gorm:"index"`
 
+	// Lifecycle
+	NoticePeriodDays      int        `json:"notice_period_days" gorm:"default:30"`
+	TerminationNoticeDate *time.Time `json:"termination_notice_date"` // Set once notice of early termination is given
+	TerminationReason     string     `json:"termination_reason" gorm:"type:text"`
+	PreviousLeaseID       *uint      `json:"previous_lease_id" gorm:"uniqueIndex"` // Lease this one renews
+
 	// Relationships
 	Tenant              User          `json:"tenant" gorm:"foreignKey:TenantID;constraint:OnDelete:CASCADE;"`
 	Property            Property      `json:"property" gorm:"foreignKey:PropertyID;constraint:OnDelete:CASCADE;"`
+	Unit                *Unit         `json:"unit,omitempty" gorm:"foreignKey:UnitID;constraint:OnDelete:SET NULL;"`
+	PreviousLease       *Lease        `json:"previous_lease,omitempty" gorm:"foreignKey:PreviousLeaseID;constraint:OnDelete:SET NULL;"`
 	MaintenanceRequests []Maintenance `json:"maintenance_requests,omitempty" gorm:"foreignKey:LeaseID;constraint:OnDelete:CASCADE;"`
 	Invoices            []Invoice     `json:"invoices,omitempty" gorm:"foreignKey:LeaseID;constraint:OnDelete:CASCADE;"`
 }
@@ -33,6 +42,7 @@ type Lease struct {
 type LeaseCreateRequest struct {
 	TenantID        uint      `json:"tenant_id" binding:"required"`
 	PropertyID      uint      `json:"property_id" binding:"required"`
+	UnitID          *uint     `json:"unit_id"`
 	StartDate       time.Time `json:"start_date" binding:"required"`
 	EndDate         time.Time `json:"end_date" binding:"required"`
 	MonthlyRent     float64   `json:"monthly_rent" binding:"required"`
@@ -54,6 +64,21 @@ type LeaseUpdateRequest struct {
 	SpecialTerms    *string    `json:"special_terms"`
 }
 
+// LeaseRenewalRequest represents a request to renew a lease into a successor
+type LeaseRenewalRequest struct {
+	EndDate      string   `json:"end_date" binding:"required"`
+	MonthlyRent  *float64 `json:"monthly_rent" binding:"omitempty,gt=0"`
+	RenewalTerms string   `json:"renewal_terms"`
+}
+
+// LeaseTerminationRequest represents a request to end a lease early
+type LeaseTerminationRequest struct {
+	NoticeDate  string `json:"notice_date"` // Defaults to today
+	EndDate     string `json:"end_date"`    // Requested last day, pushed back to the end of the notice period
+	Reason      string `json:"reason" binding:"required"`
+	WaiveNotice bool   `json:"waive_notice"`
+}
+
 // LeaseResponse represents lease response
 type LeaseResponse struct {
 	ID              uint             `json:"id"`
@@ -67,13 +92,19 @@ type LeaseResponse struct {
 	SpecialTerms    string           `json:"special_terms"`
 	Tenant          UserResponse     `json:"tenant"`
 	Property        PropertyResponse `json:"property"`
+	Unit            *UnitResponse    `json:"unit"`
 	CreatedAt       time.Time        `json:"created_at"`
 	UpdatedAt       time.Time        `json:"updated_at"`
+
+	NoticePeriodDays      int        `json:"notice_period_days"`
+	TerminationNoticeDate *time.Time `json:"termination_notice_date"`
+	TerminationReason     string     `json:"termination_reason"`
+	PreviousLeaseID       *uint      `json:"previous_lease_id"`
 }
 
 // ToResponse converts Lease to LeaseResponse
 func (l *Lease) ToResponse() LeaseResponse {
-	return LeaseResponse{
+	response := LeaseResponse{
 		ID:              l.ID,
 		StartDate:       l.StartDate,
 		EndDate:         l.EndDate,
@@ -87,7 +118,19 @@ func (l *Lease) ToResponse() LeaseResponse {
 		Property:        l.Property.ToResponse(),
 		CreatedAt:       l.CreatedAt,
 		UpdatedAt:       l.UpdatedAt,
+
+		NoticePeriodDays:      l.NoticePeriodDays,
+		TerminationNoticeDate: l.TerminationNoticeDate,
+		TerminationReason:     l.TerminationReason,
+		PreviousLeaseID:       l.PreviousLeaseID,
+	}
+
+	if l.Unit != nil {
+		unitResponse := l.Unit.ToResponse()
+		response.Unit = &unitResponse
 	}
+
+	return response
 }
 
 // Validate validates lease creation request
@@ -147,6 +190,11 @@ func (l *Lease) TotalRent() float64 {
 	return l.MonthlyRent * months
 }
 
+// UnderNotice checks if notice of early termination has been given
+func (l *Lease) UnderNotice() bool {
+	return l.TerminationNoticeDate != nil
+}
+
 // TableName returns the table name for Lease model
 func (Lease) TableName() string {
 	return "leases"
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// MaintenanceComment is a message on a maintenance request's thread.
+// Internal comments are only shown to staff, never to the tenant.
+type MaintenanceComment struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	MaintenanceID uint       `json:"maintenance_id" gorm:"not null;index"`
+	AuthorID      uint       `json:"author_id" gorm:"not null;index"`
+	Body          string     `json:"body" gorm:"type:text;not null"`
+	Visibility    string     `json:"visibility" gorm:"not null;default:'public';check:visibility IN ('public','internal')"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Maintenance Maintenance `json:"-" gorm:"foreignKey:MaintenanceID;constraint:OnDelete:CASCADE;"`
+	Author      User        `json:"author" gorm:"foreignKey:AuthorID;constraint:OnDelete:CASCADE;"`
+}
+
+// MaintenanceCommentRequest represents a new comment
+type MaintenanceCommentRequest struct {
+	Body       string `json:"body" binding:"required"`
+	Visibility string `json:"visibility"`
+}
+
+// Validate validates a new comment
+func (req *MaintenanceCommentRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidateRequired(req.Body, "body"),
+		validator.ValidateMaxLength(req.Body, 5000, "body"),
+	)
+	if req.Visibility != "" && req.Visibility != "public" && req.Visibility != "internal" {
+		errors = append(errors, validator.ValidationError{
+			Field:   "visibility",
+			Message: "must be one of: public, internal",
+			Value:   req.Visibility,
+		})
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// Participant is the public profile of someone on a maintenance thread
+type Participant struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role"`
+}
+
+// ToParticipant returns the user's public profile on a maintenance thread
+func (u *User) ToParticipant() Participant {
+	return Participant{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Role:      u.Role,
+	}
+}
+
+// MaintenanceCommentResponse represents a comment with its files
+type MaintenanceCommentResponse struct {
+	ID            uint                 `json:"id"`
+	MaintenanceID uint                 `json:"maintenance_id"`
+	Author        Participant          `json:"author"`
+	Body          string               `json:"body"`
+	Visibility    string               `json:"visibility"`
+	Attachments   []AttachmentResponse `json:"attachments"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+// ToResponse converts MaintenanceComment to MaintenanceCommentResponse
+func (mc *MaintenanceComment) ToResponse(attachments []AttachmentResponse) MaintenanceCommentResponse {
+	if attachments == nil {
+		attachments = []AttachmentResponse{}
+	}
+	return MaintenanceCommentResponse{
+		ID:            mc.ID,
+		MaintenanceID: mc.MaintenanceID,
+		Author:        mc.Author.ToParticipant(),
+		Body:          mc.Body,
+		Visibility:    mc.Visibility,
+		Attachments:   attachments,
+		CreatedAt:     mc.CreatedAt,
+	}
+}
+
+// IsInternal reports whether only staff may see the comment
+func (mc *MaintenanceComment) IsInternal() bool {
+	return mc.Visibility == "internal"
+}
+
+// TableName returns the table name for MaintenanceComment model
+func (MaintenanceComment) TableName() string {
+	return "maintenance_comments"
+}
+
+// MaintenanceStatusChange is an entry in a request's status history. Entries
+// are only ever appended; the database rejects updates to them.
+type MaintenanceStatusChange struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	MaintenanceID uint      `json:"maintenance_id" gorm:"not null;index"`
+	FromStatus    string    `json:"from_status"` // Empty for the entry recording creation
+	ToStatus      string    `json:"to_status" gorm:"not null"`
+	ChangedByID   *uint     `json:"changed_by_id" gorm:"index"` // Nil when a background job made the change
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relationships
+	Maintenance Maintenance `json:"-" gorm:"foreignKey:MaintenanceID;constraint:OnDelete:CASCADE;"`
+	ChangedBy   *User       `json:"-" gorm:"foreignKey:ChangedByID;constraint:OnDelete:SET NULL;"`
+}
+
+// MaintenanceStatusChangeResponse represents a status history entry
+type MaintenanceStatusChangeResponse struct {
+	ID         uint         `json:"id"`
+	FromStatus string       `json:"from_status"`
+	ToStatus   string       `json:"to_status"`
+	ChangedBy  *Participant `json:"changed_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// ToResponse converts MaintenanceStatusChange to MaintenanceStatusChangeResponse
+func (sc *MaintenanceStatusChange) ToResponse() MaintenanceStatusChangeResponse {
+	response := MaintenanceStatusChangeResponse{
+		ID:         sc.ID,
+		FromStatus: sc.FromStatus,
+		ToStatus:   sc.ToStatus,
+		CreatedAt:  sc.CreatedAt,
+	}
+	if sc.ChangedBy != nil {
+		participant := sc.ChangedBy.ToParticipant()
+		response.ChangedBy = &participant
+	}
+	return response
+}
+
+// TableName returns the table name for MaintenanceStatusChange model
+func (MaintenanceStatusChange) TableName() string {
+	return "maintenance_status_changes"
+}
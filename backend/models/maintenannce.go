@@ -11,10 +11,11 @@ type Maintenance struct {
 	RequestedByID uint       `json:"requested_by_id" gorm:"not null;index"` // User who requested maintenance
 	PropertyID    uint       `json:"property_id" gorm:"not null;index"`
 	LeaseID       *uint      `json:"lease_id" gorm:"index"`       // Optional lease reference
+	UnitID        *uint      `json:"unit_id" gorm:"index"`        // Optional unit reference
 	AssignedToID  *uint      `json:"assigned_to_id" gorm:"index"` // Maintenance team member
 	Title         string     `json:"title" gorm:"not null"`
 	Description   string     `json:"description" gorm:"type:text;not null"`
-	Status        string     `json:"status" gorm:"default:'pending';check:status IN ('pending','in_progress','completed','cancelled')"`
+	Status        string     `json:"status" gorm:"default:'pending';check:status IN ('pending','assigned','in_progress','completed','cancelled')"`
 	Priority      string     `json:"priority" gorm:"default:'medium';check:priority IN ('low','medium','high','urgent')"`
 	Category      string     `json:"category" gorm:"default:'general';check:category IN ('plumbing','electrical','heating','appliances','general','emergency')"`
 	EstimatedCost float64    `json:"estimated_cost" gorm:"default:0"`
@@ -28,17 +29,48 @@ type Maintenance struct {
 	UpdatedAt     time.Time  `json:"updated_at"`
 	DeletedAt     *time.Time `json:"deleted_at" gorm:"index"`
 
+	// SLA tracking: deadlines are fixed when the clock starts so a priority
+	// bump on escalation does not immediately breach the tighter target
+	AcknowledgedAt   *time.Time `json:"acknowledged_at"`
+	AcknowledgeDueAt *time.Time `json:"acknowledge_due_at"`
+	ResolveDueAt     *time.Time `json:"resolve_due_at" gorm:"index"`
+	EscalationLevel  int        `json:"escalation_level" gorm:"default:0"` // 1 after an acknowledgement breach, 2 after a resolution breach
+	EscalatedAt      *time.Time `json:"escalated_at"`
+
+	// ScheduledEndAt ends the technician's appointment that starts at
+	// ScheduledAt; appointments of one technician may not overlap
+	ScheduledEndAt *time.Time `json:"scheduled_end_at"`
+
+	// Tenant recharge: when set, the completed request's cost (or
+	// RechargeAmount when positive) is invoiced to the tenant
+	RechargeTenant    bool    `json:"recharge_tenant" gorm:"default:false"`
+	RechargeAmount    float64 `json:"recharge_amount" gorm:"default:0"`
+	RechargeInvoiceID *uint   `json:"recharge_invoice_id" gorm:"index"`
+
+	// SLA is computed for responses and never stored
+	SLA *MaintenanceSLA `json:"sla,omitempty" gorm:"-"`
+
 	// Relationships
 	RequestedBy User     `json:"requested_by" gorm:"foreignKey:RequestedByID;constraint:OnDelete:CASCADE;"`
 	Property    Property `json:"property" gorm:"foreignKey:PropertyID;constraint:OnDelete:CASCADE;"`
 	Lease       *Lease   `json:"lease,omitempty" gorm:"foreignKey:LeaseID;constraint:OnDelete:SET NULL;"`
+	Unit        *Unit    `json:"unit,omitempty" gorm:"foreignKey:UnitID;constraint:OnDelete:SET NULL;"`
 	AssignedTo  *User    `json:"assigned_to,omitempty" gorm:"foreignKey:AssignedToID;constraint:OnDelete:SET NULL;"`
+
+	RechargeInvoice *Invoice `json:"-" gorm:"foreignKey:RechargeInvoiceID;constraint:OnDelete:SET NULL;"`
+}
+
+// MaintenanceSLA reports whether a request missed its SLA targets
+type MaintenanceSLA struct {
+	AcknowledgementBreached bool `json:"acknowledgement_breached"`
+	ResolutionBreached      bool `json:"resolution_breached"`
 }
 
 // MaintenanceCreateRequest represents maintenance creation request
 type MaintenanceCreateRequest struct {
 	PropertyID    uint       `json:"property_id" binding:"required"`
 	LeaseID       *uint      `json:"lease_id"`
+	UnitID        *uint      `json:"unit_id"`
 	Title         string     `json:"title" binding:"required"`
 	Description   string     `json:"description" binding:"required"`
 	Priority      string     `json:"priority"`
@@ -66,47 +98,68 @@ type MaintenanceUpdateRequest struct {
 
 // MaintenanceResponse represents maintenance response
 type MaintenanceResponse struct {
-	ID            uint             `json:"id"`
-	Title         string           `json:"title"`
-	Description   string           `json:"description"`
-	Status        string           `json:"status"`
-	Priority      string           `json:"priority"`
-	Category      string           `json:"category"`
-	EstimatedCost float64          `json:"estimated_cost"`
-	ActualCost    float64          `json:"actual_cost"`
-	RequestedAt   time.Time        `json:"requested_at"`
-	ScheduledAt   *time.Time       `json:"scheduled_at"`
-	CompletedAt   *time.Time       `json:"completed_at"`
-	Notes         string           `json:"notes"`
-	Images        []string         `json:"images"`
-	RequestedBy   UserResponse     `json:"requested_by"`
-	Property      PropertyResponse `json:"property"`
-	Lease         *LeaseResponse   `json:"lease"`
-	AssignedTo    *UserResponse    `json:"assigned_to"`
-	CreatedAt     time.Time        `json:"created_at"`
-	UpdatedAt     time.Time        `json:"updated_at"`
+	ID               uint             `json:"id"`
+	Title            string           `json:"title"`
+	Description      string           `json:"description"`
+	Status           string           `json:"status"`
+	Priority         string           `json:"priority"`
+	Category         string           `json:"category"`
+	EstimatedCost    float64          `json:"estimated_cost"`
+	ActualCost       float64          `json:"actual_cost"`
+	RequestedAt      time.Time        `json:"requested_at"`
+	ScheduledAt      *time.Time       `json:"scheduled_at"`
+	ScheduledEndAt   *time.Time       `json:"scheduled_end_at"`
+	CompletedAt      *time.Time       `json:"completed_at"`
+	Notes            string           `json:"notes"`
+	Images           []string         `json:"images"`
+	RequestedBy      UserResponse     `json:"requested_by"`
+	Property         PropertyResponse `json:"property"`
+	Lease            *LeaseResponse   `json:"lease"`
+	Unit             *UnitResponse    `json:"unit"`
+	AssignedTo       *UserResponse    `json:"assigned_to"`
+	AcknowledgedAt   *time.Time       `json:"acknowledged_at"`
+	AcknowledgeDueAt *time.Time       `json:"acknowledge_due_at"`
+	ResolveDueAt     *time.Time       `json:"resolve_due_at"`
+	EscalationLevel  int              `json:"escalation_level"`
+	SLA              MaintenanceSLA   `json:"sla"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+
+	RechargeTenant    bool    `json:"recharge_tenant"`
+	RechargeAmount    float64 `json:"recharge_amount"`
+	RechargeInvoiceID *uint   `json:"recharge_invoice_id"`
 }
 
 // ToResponse converts Maintenance to MaintenanceResponse
 func (m *Maintenance) ToResponse() MaintenanceResponse {
 	response := MaintenanceResponse{
-		ID:            m.ID,
-		Title:         m.Title,
-		Description:   m.Description,
-		Status:        m.Status,
-		Priority:      m.Priority,
-		Category:      m.Category,
-		EstimatedCost: m.EstimatedCost,
-		ActualCost:    m.ActualCost,
-		RequestedAt:   m.RequestedAt,
-		ScheduledAt:   m.ScheduledAt,
-		CompletedAt:   m.CompletedAt,
-		Notes:         m.Notes,
-		Images:        m.Images,
-		RequestedBy:   m.RequestedBy.ToResponse(),
-		Property:      m.Property.ToResponse(),
-		CreatedAt:     m.CreatedAt,
-		UpdatedAt:     m.UpdatedAt,
+		ID:               m.ID,
+		Title:            m.Title,
+		Description:      m.Description,
+		Status:           m.Status,
+		Priority:         m.Priority,
+		Category:         m.Category,
+		EstimatedCost:    m.EstimatedCost,
+		ActualCost:       m.ActualCost,
+		RequestedAt:      m.RequestedAt,
+		ScheduledAt:      m.ScheduledAt,
+		ScheduledEndAt:   m.ScheduledEndAt,
+		CompletedAt:      m.CompletedAt,
+		Notes:            m.Notes,
+		Images:           m.Images,
+		RequestedBy:      m.RequestedBy.ToResponse(),
+		Property:         m.Property.ToResponse(),
+		AcknowledgedAt:   m.AcknowledgedAt,
+		AcknowledgeDueAt: m.AcknowledgeDueAt,
+		ResolveDueAt:     m.ResolveDueAt,
+		EscalationLevel:  m.EscalationLevel,
+		SLA:              m.SLAStatus(time.Now()),
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+
+		RechargeTenant:    m.RechargeTenant,
+		RechargeAmount:    m.RechargeAmount,
+		RechargeInvoiceID: m.RechargeInvoiceID,
 	}
 
 	if m.Lease != nil {
@@ -114,6 +167,11 @@ func (m *Maintenance) ToResponse() MaintenanceResponse {
 		response.Lease = &leaseResponse
 	}
 
+	if m.Unit != nil {
+		unitResponse := m.Unit.ToResponse()
+		response.Unit = &unitResponse
+	}
+
 	if m.AssignedTo != nil {
 		assignedToResponse := m.AssignedTo.ToResponse()
 		response.AssignedTo = &assignedToResponse
@@ -181,6 +239,40 @@ func (m *Maintenance) IsCompleted() bool {
 	return m.Status == "completed"
 }
 
+// IsOpen reports whether the request still needs work
+func (m *Maintenance) IsOpen() bool {
+	return m.Status != "completed" && m.Status != "cancelled"
+}
+
+// SLAStatus reports which SLA targets have been missed as of now
+func (m *Maintenance) SLAStatus(now time.Time) MaintenanceSLA {
+	var sla MaintenanceSLA
+
+	if m.AcknowledgeDueAt != nil {
+		if m.AcknowledgedAt != nil {
+			sla.AcknowledgementBreached = m.AcknowledgedAt.After(*m.AcknowledgeDueAt)
+		} else {
+			sla.AcknowledgementBreached = m.IsOpen() && now.After(*m.AcknowledgeDueAt)
+		}
+	}
+
+	if m.ResolveDueAt != nil {
+		if m.CompletedAt != nil {
+			sla.ResolutionBreached = m.CompletedAt.After(*m.ResolveDueAt)
+		} else {
+			sla.ResolutionBreached = m.IsOpen() && now.After(*m.ResolveDueAt)
+		}
+	}
+
+	return sla
+}
+
+// WithSLA fills in the computed SLA breach flags for a response
+func (m *Maintenance) WithSLA(now time.Time) {
+	sla := m.SLAStatus(now)
+	m.SLA = &sla
+}
+
 // IsOverdue checks if maintenance is overdue
 func (m *Maintenance) IsOverdue() bool {
 	if m.ScheduledAt == nil {
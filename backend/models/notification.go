@@ -0,0 +1,205 @@
+package models
+
+import (
+	"text/template"
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// Notification channels
+const (
+	ChannelEmail   = "email"
+	ChannelInApp   = "in_app"
+	ChannelSMS     = "sms"
+	ChannelWebhook = "webhook"
+)
+
+// NotificationChannels lists every channel a notification can be sent on
+var NotificationChannels = []string{ChannelEmail, ChannelInApp, ChannelSMS, ChannelWebhook}
+
+// Notification event types
+const (
+	EventMaintenanceCreated       = "maintenance.created"
+	EventMaintenanceAssigned      = "maintenance.assigned"
+	EventMaintenanceCompleted     = "maintenance.completed"
+	EventMaintenanceStatusChanged = "maintenance.status_changed"
+	EventLeaseExpiring            = "lease.expiring"
+	EventInvoiceDue               = "invoice.due"
+)
+
+// NotificationEvents lists every event users can be notified about
+var NotificationEvents = []string{
+	EventMaintenanceCreated,
+	EventMaintenanceAssigned,
+	EventMaintenanceCompleted,
+	EventMaintenanceStatusChanged,
+	EventLeaseExpiring,
+	EventInvoiceDue,
+}
+
+// IsNotificationChannel reports whether channel is a known channel
+func IsNotificationChannel(channel string) bool {
+	for _, c := range NotificationChannels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotificationEvent reports whether event is a known event type
+func IsNotificationEvent(event string) bool {
+	for _, e := range NotificationEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationTemplate is the text sent for one event on one channel. Subject
+// and Body are Go text/template sources rendered with the event's data.
+type NotificationTemplate struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	EventType string    `json:"event_type" gorm:"not null;uniqueIndex:idx_notification_templates_event_channel"`
+	Channel   string    `json:"channel" gorm:"not null;uniqueIndex:idx_notification_templates_event_channel;check:channel IN ('email','in_app','sms','webhook')"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for NotificationTemplate model
+func (NotificationTemplate) TableName() string {
+	return "notification_templates"
+}
+
+// NotificationPreference records whether a user wants an event on a channel.
+// Without a row the channel's default applies.
+type NotificationPreference struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_notification_preferences_user_event_channel"`
+	EventType string    `json:"event_type" gorm:"not null;uniqueIndex:idx_notification_preferences_user_event_channel"`
+	Channel   string    `json:"channel" gorm:"not null;uniqueIndex:idx_notification_preferences_user_event_channel;check:channel IN ('email','in_app','sms','webhook')"`
+	Enabled   bool      `json:"enabled" gorm:"not null;default:false"`
+	Target    string    `json:"target"` // Webhook URL, or a phone number overriding the user's for SMS
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for NotificationPreference model
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// Notification is one message to one user on one channel. Rows are queued
+// and picked up by the delivery job, which retries failures with a backoff;
+// the table doubles as the delivery log. In-app notifications are stored as
+// sent and make up the user's inbox.
+type Notification struct {
+	ID            uint                   `json:"id" gorm:"primaryKey"`
+	UserID        uint                   `json:"user_id" gorm:"not null;index"`
+	EventType     string                 `json:"event_type" gorm:"not null;index"`
+	Channel       string                 `json:"channel" gorm:"not null;check:channel IN ('email','in_app','sms','webhook')"`
+	Recipient     string                 `json:"recipient"` // Email address, phone number or webhook URL
+	Subject       string                 `json:"subject"`
+	Body          string                 `json:"body" gorm:"type:text;not null"`
+	Data          map[string]interface{} `json:"data" gorm:"type:text;serializer:json"`
+	Reference     string                 `json:"reference"` // Stops reminders being queued twice for the same record
+	Status        string                 `json:"status" gorm:"not null;default:'queued';index:idx_notifications_due,priority:1;check:status IN ('queued','sent','failed')"`
+	Attempts      int                    `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time              `json:"next_attempt_at" gorm:"not null;index:idx_notifications_due,priority:2"`
+	LastError     string                 `json:"last_error" gorm:"type:text"`
+	SentAt        *time.Time             `json:"sent_at"`
+	ReadAt        *time.Time             `json:"read_at"`
+	ArchivedAt    *time.Time             `json:"archived_at"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// NotificationPreferenceRequest sets one event and channel for the user
+type NotificationPreferenceRequest struct {
+	EventType string `json:"event_type" binding:"required"`
+	Channel   string `json:"channel" binding:"required"`
+	Enabled   bool   `json:"enabled"`
+	Target    string `json:"target"`
+}
+
+// NotificationPreferencesRequest changes some of a user's preferences
+type NotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceRequest `json:"preferences" binding:"required,dive"`
+}
+
+// Validate validates notification preferences; webhooks need a URL to call
+func (req *NotificationPreferencesRequest) Validate() error {
+	var errors validator.ValidationErrors
+	for _, pref := range req.Preferences {
+		if !IsNotificationEvent(pref.EventType) {
+			errors = append(errors, validator.ValidationError{
+				Field:   "event_type",
+				Message: "unknown event type",
+				Value:   pref.EventType,
+			})
+		}
+		if !IsNotificationChannel(pref.Channel) {
+			errors = append(errors, validator.ValidationError{
+				Field:   "channel",
+				Message: "must be one of: email, in_app, sms, webhook",
+				Value:   pref.Channel,
+			})
+		}
+		if pref.Channel == ChannelWebhook && pref.Enabled {
+			if err := validator.ValidateURL(pref.Target, "target"); err != nil {
+				errors = append(errors, *err)
+			}
+		}
+		if pref.Channel == ChannelSMS && pref.Target != "" {
+			if err := validator.ValidatePhone(pref.Target, "target"); err != nil {
+				errors = append(errors, *err)
+			}
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// NotificationTemplateRequest updates a stored template
+type NotificationTemplateRequest struct {
+	Subject *string `json:"subject"`
+	Body    *string `json:"body"`
+}
+
+// Validate checks that the subject and body parse as templates
+func (req *NotificationTemplateRequest) Validate() error {
+	var errors validator.ValidationErrors
+	if req.Subject != nil {
+		if _, err := template.New("subject").Parse(*req.Subject); err != nil {
+			errors = append(errors, validator.ValidationError{Field: "subject", Message: err.Error()})
+		}
+	}
+	if req.Body != nil {
+		if *req.Body == "" {
+			errors = append(errors, validator.ValidationError{Field: "body", Message: "is required"})
+		} else if _, err := template.New("body").Parse(*req.Body); err != nil {
+			errors = append(errors, validator.ValidationError{Field: "body", Message: err.Error()})
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
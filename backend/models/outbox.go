@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// OutboxEvent is a Kafka message written in the same transaction as the
+// change it describes. The relay job publishes pending events in ID order
+// and marks them sent, so an event is published only if its change commits
+// and is not lost if the broker is down or the process dies.
+type OutboxEvent struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	Topic     string     `json:"topic" gorm:"not null"`
+	Key       string     `json:"key"`        // Messages with the same key go to the same partition, in order
+	EventType string     `json:"event_type"` // Set for domain events, whose payload is an envelope
+	Payload   string     `json:"payload" gorm:"type:text;not null"`
+	Status    string     `json:"status" gorm:"not null;default:'pending';check:status IN ('pending','sent')"`
+	Attempts  int        `json:"attempts" gorm:"not null;default:0"`
+	LastError string     `json:"last_error" gorm:"type:text"`
+	SentAt    *time.Time `json:"sent_at" gorm:"index"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName returns the table name for OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// Payment is an immutable ledger entry for money received from, or refunded
+// to, a tenant. Invoice.PaidAmount and Invoice.RefundedAmount are derived
+// from the allocations of these entries.
+type Payment struct {
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	ReceiptNumber     string     `json:"receipt_number" gorm:"unique;not null"`
+	TenantID          uint       `json:"tenant_id" gorm:"not null;index"`
+	RecordedByID      uint       `json:"recorded_by_id" gorm:"not null;index"`
+	Type              string     `json:"type" gorm:"not null;default:'payment';check:type IN ('payment','refund')"`
+	Method            string     `json:"method" gorm:"not null;check:method IN ('cash','bank_transfer','card','cheque')"`
+	Amount            float64    `json:"amount" gorm:"not null"`
+	UnallocatedAmount float64    `json:"unallocated_amount" gorm:"default:0"` // Credit left by payments recorded before overpayments were rejected
+	PaymentDate       time.Time  `json:"payment_date" gorm:"not null;index"`
+	Reference         string     `json:"reference"` // Bank reference, cheque number, card transaction ID...
+	RefundOfID        *uint      `json:"refund_of_id" gorm:"index"`
+	Notes             string     `json:"notes" gorm:"type:text"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	DeletedAt         *time.Time `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Tenant      User                `json:"tenant" gorm:"foreignKey:TenantID;constraint:OnDelete:CASCADE;"`
+	RecordedBy  User                `json:"recorded_by" gorm:"foreignKey:RecordedByID;constraint:OnDelete:CASCADE;"`
+	RefundOf    *Payment            `json:"refund_of,omitempty" gorm:"foreignKey:RefundOfID;constraint:OnDelete:SET NULL;"`
+	Allocations []PaymentAllocation `json:"allocations,omitempty" gorm:"foreignKey:PaymentID;constraint:OnDelete:CASCADE;"`
+}
+
+// PaymentAllocation records how much of a payment was applied to an invoice
+type PaymentAllocation struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	PaymentID uint      `json:"payment_id" gorm:"not null;index"`
+	InvoiceID uint      `json:"invoice_id" gorm:"not null;index"`
+	Amount    float64   `json:"amount" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Invoice Invoice `json:"invoice" gorm:"foreignKey:InvoiceID;constraint:OnDelete:CASCADE;"`
+}
+
+// PaymentCreateRequest represents payment creation request
+type PaymentCreateRequest struct {
+	TenantID    uint    `json:"tenant_id"`
+	Amount      float64 `json:"amount" binding:"required"`
+	Method      string  `json:"method" binding:"required"`
+	PaymentDate string  `json:"payment_date"`
+	Reference   string  `json:"reference"`
+	Notes       string  `json:"notes"`
+}
+
+// PaymentResponse represents payment response
+type PaymentResponse struct {
+	ID                uint                        `json:"id"`
+	ReceiptNumber     string                      `json:"receipt_number"`
+	Type              string                      `json:"type"`
+	Method            string                      `json:"method"`
+	Amount            float64                     `json:"amount"`
+	UnallocatedAmount float64                     `json:"unallocated_amount"`
+	PaymentDate       time.Time                   `json:"payment_date"`
+	Reference         string                      `json:"reference"`
+	RefundOfID        *uint                       `json:"refund_of_id"`
+	Notes             string                      `json:"notes"`
+	Tenant            UserResponse                `json:"tenant"`
+	RecordedBy        UserResponse                `json:"recorded_by"`
+	Allocations       []PaymentAllocationResponse `json:"allocations"`
+	CreatedAt         time.Time                   `json:"created_at"`
+}
+
+// PaymentAllocationResponse represents a payment allocation in responses
+type PaymentAllocationResponse struct {
+	InvoiceID     uint    `json:"invoice_id"`
+	InvoiceNumber string  `json:"invoice_number"`
+	Amount        float64 `json:"amount"`
+}
+
+// ToResponse converts Payment to PaymentResponse
+func (p *Payment) ToResponse() PaymentResponse {
+	response := PaymentResponse{
+		ID:                p.ID,
+		ReceiptNumber:     p.ReceiptNumber,
+		Type:              p.Type,
+		Method:            p.Method,
+		Amount:            p.Amount,
+		UnallocatedAmount: p.UnallocatedAmount,
+		PaymentDate:       p.PaymentDate,
+		Reference:         p.Reference,
+		RefundOfID:        p.RefundOfID,
+		Notes:             p.Notes,
+		Tenant:            p.Tenant.ToResponse(),
+		RecordedBy:        p.RecordedBy.ToResponse(),
+		Allocations:       make([]PaymentAllocationResponse, 0, len(p.Allocations)),
+		CreatedAt:         p.CreatedAt,
+	}
+
+	for _, allocation := range p.Allocations {
+		response.Allocations = append(response.Allocations, PaymentAllocationResponse{
+			InvoiceID:     allocation.InvoiceID,
+			InvoiceNumber: allocation.Invoice.InvoiceNumber,
+			Amount:        allocation.Amount,
+		})
+	}
+
+	return response
+}
+
+// Validate validates payment creation request
+func (req *PaymentCreateRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidatePositiveFloat(req.Amount, "amount"),
+		validator.ValidateMaxLength(req.Reference, 100, "reference"),
+	)
+
+	validMethods := []string{"cash", "bank_transfer", "card", "cheque"}
+	valid := false
+	for _, m := range validMethods {
+		if req.Method == m {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		errors = append(errors, validator.ValidationError{
+			Field:   "method",
+			Message: "must be one of: cash, bank_transfer, card, cheque",
+			Value:   req.Method,
+		})
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// IsRefund checks if the ledger entry is a refund
+func (p *Payment) IsRefund() bool {
+	return p.Type == "refund"
+}
+
+// TableName returns the table name for Payment model
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// TableName returns the table name for PaymentAllocation model
+func (PaymentAllocation) TableName() string {
+	return "payment_allocations"
+}
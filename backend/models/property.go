@@ -93,6 +93,36 @@ type PropertyUpdateRequest struct {
 	Amenities    []string `json:"amenities"`
 }
 
+// UnitCreateRequest represents unit creation request
+type UnitCreateRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" binding:"required"`
+	Available   *bool   `json:"available"`
+}
+
+// UnitUpdateRequest represents unit update request
+type UnitUpdateRequest struct {
+	Name        *string  `json:"name"`
+	Description *string  `json:"description"`
+	Price       *float64 `json:"price"`
+	Available   *bool    `json:"available"`
+	TenantID    *uint    `json:"tenant_id"`
+}
+
+// UnitResponse represents unit response
+type UnitResponse struct {
+	ID          uint          `json:"id"`
+	PropertyID  uint          `json:"property_id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Price       float64       `json:"price"`
+	Available   bool          `json:"available"`
+	Tenant      *UserResponse `json:"tenant"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
 // PropertyResponse represents property response
 type PropertyResponse struct {
 	ID           uint          `json:"id"`
@@ -149,6 +179,27 @@ func (p *Property) ToResponse() PropertyResponse {
 	return response
 }
 
+// ToResponse converts Unit to UnitResponse
+func (u *Unit) ToResponse() UnitResponse {
+	response := UnitResponse{
+		ID:          u.ID,
+		PropertyID:  u.PropertyID,
+		Name:        u.Name,
+		Description: u.Description,
+		Price:       u.Price,
+		Available:   u.Available,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+	}
+
+	if u.Tenant != nil {
+		tenantResponse := u.Tenant.ToResponse()
+		response.Tenant = &tenantResponse
+	}
+
+	return response
+}
+
 // Validate validates property creation request
 func (req *PropertyCreateRequest) Validate() error {
 	errors := validator.CollectValidationErrors(
@@ -224,6 +275,50 @@ func (req *PropertyUpdateRequest) Validate() error {
 	return nil
 }
 
+// Validate validates unit creation request
+func (req *UnitCreateRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidateRequired(req.Name, "name"),
+		validator.ValidateMaxLength(req.Name, 100, "name"),
+		validator.ValidatePositiveFloat(req.Price, "price"),
+	)
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// Validate validates unit update request
+func (req *UnitUpdateRequest) Validate() error {
+	var errors validator.ValidationErrors
+
+	if req.Name != nil {
+		if err := validator.ValidateRequired(*req.Name, "name"); err != nil {
+			errors = append(errors, *err)
+		}
+		if err := validator.ValidateMaxLength(*req.Name, 100, "name"); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	if req.Price != nil {
+		if err := validator.ValidatePositiveFloat(*req.Price, "price"); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// IsOccupied checks if unit is occupied
+func (u *Unit) IsOccupied() bool {
+	return u.TenantID != nil
+}
+
 // GetFullAddress returns the full address of the property
 func (p *Property) GetFullAddress() string {
 	return p.Address + ", " + p.City + ", " + p.PostCode
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// MaintenanceCategories are the kinds of work a request can need and a
+// technician can be skilled in
+var MaintenanceCategories = []string{"plumbing", "electrical", "heating", "appliances", "general", "emergency"}
+
+// WorkingHours is one weekly shift of a maintenance team member. Times are
+// wall-clock "HH:MM" in the scheduling time zone.
+type WorkingHours struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	Weekday   int       `json:"weekday" gorm:"not null;check:weekday BETWEEN 0 AND 6"` // 0 is Sunday
+	StartTime string    `json:"start_time" gorm:"type:varchar(5);not null"`
+	EndTime   string    `json:"end_time" gorm:"type:varchar(5);not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+// Minutes returns the shift as minutes after midnight
+func (wh *WorkingHours) Minutes() (start, end int) {
+	start, _ = parseClock(wh.StartTime)
+	end, _ = parseClock(wh.EndTime)
+	return start, end
+}
+
+// TableName returns the table name for WorkingHours model
+func (WorkingHours) TableName() string {
+	return "technician_working_hours"
+}
+
+// TimeOff is a period a maintenance team member cannot be booked
+type TimeOff struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;index"`
+	StartsAt  time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt    time.Time `json:"ends_at" gorm:"not null"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for TimeOff model
+func (TimeOff) TableName() string {
+	return "technician_time_off"
+}
+
+// TechnicianSkill is a maintenance category a team member can be dispatched to
+type TechnicianSkill struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_technician_skills_user_category"`
+	Category  string    `json:"category" gorm:"not null;uniqueIndex:idx_technician_skills_user_category;check:category IN ('plumbing','electrical','heating','appliances','general','emergency')"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for TechnicianSkill model
+func (TechnicianSkill) TableName() string {
+	return "technician_skills"
+}
+
+// WorkingHoursRequest replaces a technician's weekly hours
+type WorkingHoursRequest struct {
+	Hours []ShiftRequest `json:"hours"`
+}
+
+// ShiftRequest is one weekly shift in a WorkingHoursRequest
+type ShiftRequest struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// Validate validates a working hours request. Shifts may not overlap on the
+// same day; a shift cannot run past midnight.
+func (req *WorkingHoursRequest) Validate() error {
+	var errors validator.ValidationErrors
+	for i, shift := range req.Hours {
+		field := fmt.Sprintf("hours[%d]", i)
+		if shift.Weekday < 0 || shift.Weekday > 6 {
+			errors = append(errors, validator.ValidationError{
+				Field:   field + ".weekday",
+				Message: "must be between 0 (Sunday) and 6 (Saturday)",
+				Value:   shift.Weekday,
+			})
+		}
+		start, okStart := parseClock(shift.StartTime)
+		end, okEnd := parseClock(shift.EndTime)
+		if !okStart || !okEnd {
+			errors = append(errors, validator.ValidationError{
+				Field:   field,
+				Message: "start_time and end_time must be HH:MM",
+				Value:   shift.StartTime + "-" + shift.EndTime,
+			})
+			continue
+		}
+		if end <= start {
+			errors = append(errors, validator.ValidationError{
+				Field:   field,
+				Message: "end_time must be after start_time",
+				Value:   shift.StartTime + "-" + shift.EndTime,
+			})
+			continue
+		}
+		for _, other := range req.Hours[:i] {
+			otherStart, ok1 := parseClock(other.StartTime)
+			otherEnd, ok2 := parseClock(other.EndTime)
+			if ok1 && ok2 && other.Weekday == shift.Weekday && start < otherEnd && otherStart < end {
+				errors = append(errors, validator.ValidationError{
+					Field:   field,
+					Message: "overlaps another shift on the same day",
+					Value:   shift.StartTime + "-" + shift.EndTime,
+				})
+				break
+			}
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// TimeOffRequest represents a new period of time off
+type TimeOffRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason"`
+}
+
+// Validate validates a time off request
+func (req *TimeOffRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidateMaxLength(req.Reason, 255, "reason"),
+	)
+	if !req.EndsAt.After(req.StartsAt) {
+		errors = append(errors, validator.ValidationError{
+			Field:   "ends_at",
+			Message: "must be after starts_at",
+			Value:   req.EndsAt,
+		})
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// SkillsRequest replaces a technician's skills
+type SkillsRequest struct {
+	Categories []string `json:"categories"`
+}
+
+// Validate validates a skills request
+func (req *SkillsRequest) Validate() error {
+	var errors validator.ValidationErrors
+	for _, category := range req.Categories {
+		if !IsMaintenanceCategory(category) {
+			errors = append(errors, validator.ValidationError{
+				Field:   "categories",
+				Message: "must be one of: plumbing, electrical, heating, appliances, general, emergency",
+				Value:   category,
+			})
+		}
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// IsMaintenanceCategory reports whether category is a known maintenance category
+func IsMaintenanceCategory(category string) bool {
+	for _, c := range MaintenanceCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses "HH:MM" into minutes after midnight
+func parseClock(value string) (int, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil || len(value) != 5 {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
@@ -0,0 +1,267 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// Vendor is an outside contractor maintenance work can be ordered from
+type Vendor struct {
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	Name               string     `json:"name" gorm:"not null;index"`
+	ContactName        string     `json:"contact_name"`
+	Email              string     `json:"email"`
+	Phone              string     `json:"phone"`
+	Trades             []string   `json:"trades" gorm:"serializer:json"` // Maintenance categories the vendor covers
+	InsuranceExpiresAt *time.Time `json:"insurance_expires_at"`
+	HourlyRate         float64    `json:"hourly_rate" gorm:"default:0"`
+	CalloutFee         float64    `json:"callout_fee" gorm:"default:0"`
+	Rating             float64    `json:"rating" gorm:"default:0;check:rating BETWEEN 0 AND 5"`
+	IsActive           bool       `json:"is_active" gorm:"default:true"`
+	Notes              string     `json:"notes" gorm:"type:text"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	DeletedAt          *time.Time `json:"deleted_at" gorm:"index"`
+}
+
+// IsInsured reports whether the vendor's insurance covers the given time
+func (v *Vendor) IsInsured(at time.Time) bool {
+	return v.InsuranceExpiresAt != nil && v.InsuranceExpiresAt.After(at)
+}
+
+// HasTrade reports whether the vendor covers a maintenance category
+func (v *Vendor) HasTrade(category string) bool {
+	for _, trade := range v.Trades {
+		if trade == category {
+			return true
+		}
+	}
+	return false
+}
+
+// TableName returns the table name for Vendor model
+func (Vendor) TableName() string {
+	return "vendors"
+}
+
+// VendorRequest represents vendor creation and update requests. On update
+// only the fields provided are changed.
+type VendorRequest struct {
+	Name               *string    `json:"name"`
+	ContactName        *string    `json:"contact_name"`
+	Email              *string    `json:"email"`
+	Phone              *string    `json:"phone"`
+	Trades             []string   `json:"trades"`
+	InsuranceExpiresAt *time.Time `json:"insurance_expires_at"`
+	HourlyRate         *float64   `json:"hourly_rate"`
+	CalloutFee         *float64   `json:"callout_fee"`
+	Rating             *float64   `json:"rating"`
+	IsActive           *bool      `json:"is_active"`
+	Notes              *string    `json:"notes"`
+}
+
+// Validate validates a vendor request; creating requires a name
+func (req *VendorRequest) Validate(creating bool) error {
+	var errors validator.ValidationErrors
+	add := func(err *validator.ValidationError) {
+		if err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	if creating || req.Name != nil {
+		name := ""
+		if req.Name != nil {
+			name = *req.Name
+		}
+		add(validator.ValidateRequired(name, "name"))
+		add(validator.ValidateMaxLength(name, 200, "name"))
+	}
+	if req.Email != nil && *req.Email != "" {
+		add(validator.ValidateEmail(*req.Email, "email"))
+	}
+	for _, trade := range req.Trades {
+		if !IsMaintenanceCategory(trade) {
+			add(&validator.ValidationError{
+				Field:   "trades",
+				Message: "must be one of: plumbing, electrical, heating, appliances, general, emergency",
+				Value:   trade,
+			})
+		}
+	}
+	if req.HourlyRate != nil {
+		add(validator.ValidateNonNegativeFloat(*req.HourlyRate, "hourly_rate"))
+	}
+	if req.CalloutFee != nil {
+		add(validator.ValidateNonNegativeFloat(*req.CalloutFee, "callout_fee"))
+	}
+	if req.Rating != nil && (*req.Rating < 0 || *req.Rating > 5) {
+		add(&validator.ValidationError{
+			Field:   "rating",
+			Message: "must be between 0 and 5",
+			Value:   *req.Rating,
+		})
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// Apply copies the provided fields of the request onto the vendor
+func (req *VendorRequest) Apply(v *Vendor) {
+	if req.Name != nil {
+		v.Name = *req.Name
+	}
+	if req.ContactName != nil {
+		v.ContactName = *req.ContactName
+	}
+	if req.Email != nil {
+		v.Email = *req.Email
+	}
+	if req.Phone != nil {
+		v.Phone = *req.Phone
+	}
+	if req.Trades != nil {
+		v.Trades = req.Trades
+	}
+	if req.InsuranceExpiresAt != nil {
+		v.InsuranceExpiresAt = req.InsuranceExpiresAt
+	}
+	if req.HourlyRate != nil {
+		v.HourlyRate = *req.HourlyRate
+	}
+	if req.CalloutFee != nil {
+		v.CalloutFee = *req.CalloutFee
+	}
+	if req.Rating != nil {
+		v.Rating = *req.Rating
+	}
+	if req.IsActive != nil {
+		v.IsActive = *req.IsActive
+	}
+	if req.Notes != nil {
+		v.Notes = *req.Notes
+	}
+}
+
+// WorkOrder is a job raised from a maintenance request to an outside vendor.
+// Quotes are collected while it is requested; approving one assigns its
+// vendor, and the vendor's final invoice is booked as an expense against the
+// property.
+type WorkOrder struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	MaintenanceID   uint       `json:"maintenance_id" gorm:"not null;index"`
+	PropertyID      uint       `json:"property_id" gorm:"not null;index"`
+	VendorID        *uint      `json:"vendor_id" gorm:"index"` // Set when a quote is approved
+	CreatedByID     uint       `json:"created_by_id" gorm:"not null;index"`
+	Description     string     `json:"description" gorm:"type:text;not null"`
+	Status          string     `json:"status" gorm:"not null;default:'requested';check:status IN ('requested','approved','invoiced','cancelled')"`
+	ApprovedQuoteID *uint      `json:"approved_quote_id"`
+	ApprovedByID    *uint      `json:"approved_by_id"`
+	ApprovedAt      *time.Time `json:"approved_at"`
+	ExpenseID       *uint      `json:"expense_id" gorm:"index"` // The vendor's final invoice
+	InvoicedAt      *time.Time `json:"invoiced_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at" gorm:"index"`
+
+	// Relationships
+	Maintenance Maintenance      `json:"-" gorm:"foreignKey:MaintenanceID;constraint:OnDelete:CASCADE;"`
+	Property    Property         `json:"-" gorm:"foreignKey:PropertyID;constraint:OnDelete:CASCADE;"`
+	Vendor      *Vendor          `json:"vendor,omitempty" gorm:"foreignKey:VendorID;constraint:OnDelete:SET NULL;"`
+	CreatedBy   User             `json:"-" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
+	Quotes      []WorkOrderQuote `json:"quotes,omitempty" gorm:"foreignKey:WorkOrderID;constraint:OnDelete:CASCADE;"`
+	Expense     *Expense         `json:"expense,omitempty" gorm:"foreignKey:ExpenseID;constraint:OnDelete:SET NULL;"`
+}
+
+// IsOpen reports whether quotes can still be collected and approved
+func (wo *WorkOrder) IsOpen() bool {
+	return wo.Status == "requested"
+}
+
+// TableName returns the table name for WorkOrder model
+func (WorkOrder) TableName() string {
+	return "work_orders"
+}
+
+// WorkOrderQuote is a vendor's price for a work order
+type WorkOrderQuote struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	WorkOrderID uint       `json:"work_order_id" gorm:"not null;index"`
+	VendorID    uint       `json:"vendor_id" gorm:"not null;index"`
+	Amount      float64    `json:"amount" gorm:"not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	ValidUntil  *time.Time `json:"valid_until"`
+	Status      string     `json:"status" gorm:"not null;default:'submitted';check:status IN ('submitted','accepted','rejected')"`
+	CreatedByID uint       `json:"created_by_id" gorm:"not null"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	WorkOrder WorkOrder `json:"-" gorm:"foreignKey:WorkOrderID;constraint:OnDelete:CASCADE;"`
+	Vendor    Vendor    `json:"vendor" gorm:"foreignKey:VendorID;constraint:OnDelete:CASCADE;"`
+	CreatedBy User      `json:"-" gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for WorkOrderQuote model
+func (WorkOrderQuote) TableName() string {
+	return "work_order_quotes"
+}
+
+// WorkOrderCreateRequest represents a new work order
+type WorkOrderCreateRequest struct {
+	Description string `json:"description" binding:"required"`
+}
+
+// QuoteCreateRequest represents a vendor quote for a work order
+type QuoteCreateRequest struct {
+	VendorID    uint       `json:"vendor_id" binding:"required"`
+	Amount      float64    `json:"amount" binding:"required"`
+	Description string     `json:"description"`
+	ValidUntil  *time.Time `json:"valid_until"`
+}
+
+// Validate validates a quote
+func (req *QuoteCreateRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidatePositiveFloat(req.Amount, "amount"),
+		validator.ValidateMaxLength(req.Description, 2000, "description"),
+	)
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// VendorInvoiceRequest represents a vendor's final invoice for a work order
+type VendorInvoiceRequest struct {
+	Amount        float64   `json:"amount" binding:"required"`
+	InvoiceDate   time.Time `json:"invoice_date" binding:"required"`
+	Reference     string    `json:"reference"` // The vendor's invoice number
+	PaymentMethod string    `json:"payment_method"`
+	Notes         string    `json:"notes"`
+}
+
+// Validate validates a vendor invoice
+func (req *VendorInvoiceRequest) Validate() error {
+	errors := validator.CollectValidationErrors(
+		validator.ValidatePositiveFloat(req.Amount, "amount"),
+		validator.ValidateMaxLength(req.Reference, 100, "reference"),
+	)
+	switch req.PaymentMethod {
+	case "", "cash", "bank_transfer", "card", "cheque":
+	default:
+		errors = append(errors, validator.ValidationError{
+			Field:   "payment_method",
+			Message: "must be one of: cash, bank_transfer, card, cheque",
+			Value:   req.PaymentMethod,
+		})
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/validator"
+)
+
+// Webhook event types
+const (
+	WebhookLeaseCreated       = "lease.created"
+	WebhookLeaseActivated     = "lease.activated"
+	WebhookLeaseRenewed       = "lease.renewed"
+	WebhookLeaseTerminated    = "lease.terminated"
+	WebhookLeaseEnded         = "lease.ended"
+	WebhookInvoiceCreated     = "invoice.created"
+	WebhookInvoicePaid        = "invoice.paid"
+	WebhookMaintenanceCreated = "maintenance.created"
+	WebhookMaintenanceUpdated = "maintenance.updated"
+	// WebhookTest is sent by the test endpoint only; it cannot be subscribed to
+	WebhookTest = "webhook.test"
+	// WebhookAllEvents subscribes an endpoint to every event type
+	WebhookAllEvents = "*"
+)
+
+// WebhookEvents lists every event type an endpoint can subscribe to
+var WebhookEvents = []string{
+	WebhookLeaseCreated,
+	WebhookLeaseActivated,
+	WebhookLeaseRenewed,
+	WebhookLeaseTerminated,
+	WebhookLeaseEnded,
+	WebhookInvoiceCreated,
+	WebhookInvoicePaid,
+	WebhookMaintenanceCreated,
+	WebhookMaintenanceUpdated,
+}
+
+// IsWebhookEvent reports whether event can be subscribed to
+func IsWebhookEvent(event string) bool {
+	if event == WebhookAllEvents {
+		return true
+	}
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEndpoint is a URL registered by an admin or landlord to receive
+// events. Admins' endpoints receive events for every property, landlords'
+// for the properties they own.
+type WebhookEndpoint struct {
+	ID                  uint       `json:"id" gorm:"primaryKey"`
+	OwnerID             uint       `json:"owner_id" gorm:"not null;index"`
+	URL                 string     `json:"url" gorm:"not null"`
+	Description         string     `json:"description"`
+	Secret              string     `json:"-" gorm:"not null"` // Signs payloads; only shown when created or rotated
+	EventTypes          []string   `json:"event_types" gorm:"type:text;not null;serializer:json"`
+	IsActive            bool       `json:"is_active" gorm:"not null;default:true"`
+	ConsecutiveFailures int        `json:"consecutive_failures" gorm:"not null;default:0"`
+	DisabledAt          *time.Time `json:"disabled_at"`
+	DisabledReason      string     `json:"disabled_reason"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+
+	// Relationships
+	Owner User `json:"-" gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for WebhookEndpoint model
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Subscribed reports whether the endpoint wants events of the given type
+func (e *WebhookEndpoint) Subscribed(eventType string) bool {
+	for _, t := range e.EventTypes {
+		if t == eventType || t == WebhookAllEvents {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent to one endpoint. Rows are queued and
+// picked up by the delivery job, which retries failures with a backoff; the
+// table doubles as the endpoint's delivery log.
+type WebhookDelivery struct {
+	ID             uint                   `json:"id" gorm:"primaryKey"`
+	EndpointID     uint                   `json:"endpoint_id" gorm:"not null;index"`
+	EventID        string                 `json:"event_id" gorm:"not null;index"` // The same for every endpoint an event goes to
+	EventType      string                 `json:"event_type" gorm:"not null"`
+	Payload        map[string]interface{} `json:"payload" gorm:"type:text;not null;serializer:json"`
+	Status         string                 `json:"status" gorm:"not null;default:'queued';index:idx_webhook_deliveries_due,priority:1;check:status IN ('queued','sent','failed')"`
+	Attempts       int                    `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time              `json:"next_attempt_at" gorm:"not null;index:idx_webhook_deliveries_due,priority:2"`
+	ResponseStatus int                    `json:"response_status"`
+	ResponseBody   string                 `json:"response_body" gorm:"type:text"` // Truncated
+	LastError      string                 `json:"last_error" gorm:"type:text"`
+	DurationMs     int64                  `json:"duration_ms"`
+	DeliveredAt    *time.Time             `json:"delivered_at"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+
+	// Relationships
+	Endpoint WebhookEndpoint `json:"-" gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE;"`
+}
+
+// TableName returns the table name for WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookEndpointRequest registers a webhook endpoint
+type WebhookEndpointRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	Description string   `json:"description"`
+	EventTypes  []string `json:"event_types" binding:"required"`
+}
+
+// Validate validates a webhook endpoint registration
+func (req *WebhookEndpointRequest) Validate() error {
+	errors := validator.CollectValidationErrors(validator.ValidateURL(req.URL, "url"))
+	errors = append(errors, validateWebhookEvents(req.EventTypes)...)
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// WebhookEndpointUpdateRequest changes a webhook endpoint. Setting is_active
+// to true re-enables an endpoint that was disabled after failing.
+type WebhookEndpointUpdateRequest struct {
+	URL         *string   `json:"url"`
+	Description *string   `json:"description"`
+	EventTypes  *[]string `json:"event_types"`
+	IsActive    *bool     `json:"is_active"`
+}
+
+// Validate validates a webhook endpoint update
+func (req *WebhookEndpointUpdateRequest) Validate() error {
+	var errors validator.ValidationErrors
+	if req.URL != nil {
+		if err := validator.ValidateURL(*req.URL, "url"); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+	if req.EventTypes != nil {
+		errors = append(errors, validateWebhookEvents(*req.EventTypes)...)
+	}
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+func validateWebhookEvents(eventTypes []string) validator.ValidationErrors {
+	var errors validator.ValidationErrors
+	if len(eventTypes) == 0 {
+		errors = append(errors, validator.ValidationError{Field: "event_types", Message: "must list at least one event type"})
+	}
+	for _, eventType := range eventTypes {
+		if !IsWebhookEvent(eventType) {
+			errors = append(errors, validator.ValidationError{
+				Field:   "event_types",
+				Message: "unknown event type",
+				Value:   eventType,
+			})
+		}
+	}
+	return errors
+}
@@ -0,0 +1,126 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Channel delivers a queued notification. Returning an error schedules a retry.
+type Channel interface {
+	Send(ctx context.Context, n *models.Notification) error
+}
+
+var (
+	channelsMu sync.RWMutex
+	channels   = map[string]Channel{}
+)
+
+// Register sets the channel that delivers notifications of the given name,
+// replacing any registered before
+func Register(name string, ch Channel) {
+	channelsMu.Lock()
+	defer channelsMu.Unlock()
+	channels[name] = ch
+}
+
+func channelFor(name string) (Channel, bool) {
+	channelsMu.RLock()
+	defer channelsMu.RUnlock()
+	ch, ok := channels[name]
+	return ch, ok
+}
+
+// InAppChannel delivers in-app notifications. The stored notification is
+// what the user reads, so delivering one only marks it sent.
+type InAppChannel struct{}
+
+// Send does nothing; the notification is already in the user's inbox
+func (InAppChannel) Send(context.Context, *models.Notification) error {
+	return nil
+}
+
+// WebhookChannel posts notifications as JSON to the URL the user registered
+type WebhookChannel struct {
+	Client *http.Client
+}
+
+// webhookPayload is the body posted to a user's webhook
+type webhookPayload struct {
+	ID        uint                   `json:"id"`
+	EventType string                 `json:"event_type"`
+	Subject   string                 `json:"subject"`
+	Body      string                 `json:"body"`
+	Data      map[string]interface{} `json:"data"`
+	CreatedAt string                 `json:"created_at"`
+}
+
+// Send posts the notification and treats any non-2xx response as a failure
+func (ch WebhookChannel) Send(ctx context.Context, n *models.Notification) error {
+	payload, err := json.Marshal(webhookPayload{
+		ID:        n.ID,
+		EventType: n.EventType,
+		Subject:   n.Subject,
+		Body:      n.Body,
+		Data:      n.Data,
+		CreatedAt: n.CreatedAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.Recipient, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := ch.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMSProvider sends a text message through an SMS gateway
+type SMSProvider interface {
+	SendSMS(ctx context.Context, to, body string) error
+}
+
+// SMSChannel delivers notifications as text messages
+type SMSChannel struct {
+	Provider SMSProvider
+}
+
+// Send texts the notification body to its recipient
+func (ch SMSChannel) Send(ctx context.Context, n *models.Notification) error {
+	return ch.Provider.SendSMS(ctx, n.Recipient, n.Body)
+}
+
+// LogSMSProvider stands in for an SMS gateway by logging each message
+type LogSMSProvider struct{}
+
+// SendSMS logs the message instead of sending it
+func (LogSMSProvider) SendSMS(_ context.Context, to, body string) error {
+	logger.LogInfo("SMS notification (not sent, no provider configured)", logrus.Fields{
+		"to":   to,
+		"body": body,
+	})
+	return nil
+}
@@ -0,0 +1,129 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/metrics"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// deliveryBatchSize caps how many notifications one run sends
+	deliveryBatchSize = 100
+	// maxBackoff caps the wait between retries
+	maxBackoff = 6 * time.Hour
+)
+
+// DeliveryRun summarises one pass of the delivery job
+type DeliveryRun struct {
+	Sent    []uint `json:"sent"`
+	Retried []uint `json:"retried"`
+	Failed  []uint `json:"failed"`
+}
+
+// Deliver sends the queued notifications that are due. Failures are retried
+// with a doubling backoff until the configured attempts are used up, when the
+// notification is marked failed. Rows are claimed with SKIP LOCKED so
+// replicas can deliver side by side.
+func Deliver(database *gorm.DB, now time.Time) (*DeliveryRun, error) {
+	run := &DeliveryRun{}
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var due []models.Notification
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", "queued", now).
+			Order("next_attempt_at, id").Limit(deliveryBatchSize).
+			Find(&due).Error; err != nil {
+			return fmt.Errorf("failed to load queued notifications: %w", err)
+		}
+
+		for i := range due {
+			n := &due[i]
+			sendErr := send(tx.Statement.Context, n)
+			result := Attempted(n, sendErr, now)
+			if err := tx.Model(n).Select("status", "attempts", "next_attempt_at", "last_error", "sent_at").
+				Updates(n).Error; err != nil {
+				return fmt.Errorf("failed to record delivery of notification %d: %w", n.ID, err)
+			}
+			metrics.NotificationDeliveries.WithLabelValues(n.Channel, result).Inc()
+
+			switch result {
+			case "sent":
+				run.Sent = append(run.Sent, n.ID)
+			case "retry":
+				run.Retried = append(run.Retried, n.ID)
+			default:
+				run.Failed = append(run.Failed, n.ID)
+				logger.LogWarning("Notification delivery failed permanently", logrus.Fields{
+					"notification_id": n.ID,
+					"channel":         n.Channel,
+					"attempts":        n.Attempts,
+					"error":           n.LastError,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(run.Sent) > 0 || len(run.Retried) > 0 || len(run.Failed) > 0 {
+		logger.LogInfo("Notification delivery run completed", logrus.Fields{
+			"sent":    len(run.Sent),
+			"retried": len(run.Retried),
+			"failed":  len(run.Failed),
+		})
+	}
+	return run, nil
+}
+
+func send(ctx context.Context, n *models.Notification) error {
+	ch, ok := channelFor(n.Channel)
+	if !ok {
+		return fmt.Errorf("no %s channel registered", n.Channel)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return ch.Send(ctx, n)
+}
+
+// Attempted records the outcome of one delivery attempt on n and returns
+// "sent", "retry" or "failed"
+func Attempted(n *models.Notification, err error, now time.Time) string {
+	n.Attempts++
+	if err == nil {
+		n.Status = "sent"
+		n.SentAt = &now
+		n.LastError = ""
+		return "sent"
+	}
+
+	n.LastError = err.Error()
+	if n.Attempts >= settings.MaxAttempts {
+		n.Status = "failed"
+		return "failed"
+	}
+	n.NextAttemptAt = now.Add(Backoff(settings.RetryBackoff, n.Attempts))
+	return "retry"
+}
+
+// Backoff returns how long to wait after the given number of failed attempts:
+// base, then doubling, capped at six hours
+func Backoff(base time.Duration, attempts int) time.Duration {
+	wait := base
+	for i := 1; i < attempts && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
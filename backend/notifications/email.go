@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/models"
+)
+
+// SendEmail sends a plain-text email through the configured SMTP server.
+// Without an SMTP user it sends unauthenticated, for local relays and test
+// servers.
+func SendEmail(cfg config.EmailConfig, to []string, subject, body string) error {
+	from := cfg.From
+	if from == "" {
+		from = cfg.SMTPUser
+	}
+	if cfg.SMTPHost == "" || from == "" {
+		return fmt.Errorf("missing SMTP configuration")
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	var auth smtp.Auth
+	if cfg.SMTPUser != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUser, cfg.SMTPPass, cfg.SMTPHost)
+	}
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", from, strings.Join(to, ", "), subject, body)
+	addr := fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort)
+
+	if err := smtp.SendMail(addr, auth, from, to, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	return nil
+}
+
+// EmailChannel delivers notifications by SMTP
+type EmailChannel struct {
+	Config config.EmailConfig
+}
+
+// Send emails the notification to its recipient
+func (ch EmailChannel) Send(_ context.Context, n *models.Notification) error {
+	return SendEmail(ch.Config, []string{n.Recipient}, n.Subject, n.Body)
+}
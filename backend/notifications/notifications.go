@@ -0,0 +1,201 @@
+// Package notifications queues messages to users about things that happened
+// in the system and delivers them on the channels each user has opted into:
+// email, in-app, SMS and outbound webhooks. Messages are rendered from stored
+// templates when queued and delivered by a background job that retries
+// failures. In-app notifications form each user's inbox and are pushed to
+// their open streams as they arrive.
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// settings holds the configured delivery behaviour; Init replaces it
+var settings = config.NotificationConfig{
+	MaxAttempts:       5,
+	RetryBackoff:      time.Minute,
+	LeaseExpiryNotice: 30 * 24 * time.Hour,
+	InvoiceDueNotice:  3 * 24 * time.Hour,
+}
+
+// defaultChannels are the channels a user receives every event on until they
+// say otherwise. SMS and webhooks are opt-in.
+var defaultChannels = map[string]bool{
+	models.ChannelEmail: true,
+	models.ChannelInApp: true,
+}
+
+// Init applies the notification settings and registers the built-in channels
+func Init(cfg *config.Config) {
+	settings = cfg.Notifications
+
+	Register(models.ChannelEmail, EmailChannel{Config: cfg.Email})
+	Register(models.ChannelInApp, InAppChannel{})
+	Register(models.ChannelWebhook, WebhookChannel{Client: &http.Client{Timeout: cfg.Notifications.WebhookTimeout}})
+
+	var provider SMSProvider = LogSMSProvider{}
+	if cfg.Notifications.SMSProvider != "log" {
+		logger.LogWarning("Unknown SMS provider, logging text messages instead", logrus.Fields{
+			"provider": cfg.Notifications.SMSProvider,
+		})
+	}
+	Register(models.ChannelSMS, SMSChannel{Provider: provider})
+}
+
+// Event is something users are notified about. Data is rendered into the
+// templates and kept on each notification for webhook payloads.
+type Event struct {
+	Type string
+	Data map[string]interface{}
+	// Reference identifies what the event is about, e.g. "invoice:12:2025-06-01".
+	// A user is notified about a reference only once per channel.
+	Reference string
+}
+
+// Notify queues the event for each user on every channel they want it on.
+// Call it inside the transaction making the change so notifications are only
+// queued if it commits. Channels without a template for the event are skipped.
+func Notify(tx *gorm.DB, event Event, userIDs ...uint) error {
+	userIDs = uniqueIDs(userIDs)
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	var users []models.User
+	if err := tx.Where("id IN ? AND is_active = ? AND deleted_at IS NULL", userIDs, true).Find(&users).Error; err != nil {
+		return fmt.Errorf("failed to load recipients: %w", err)
+	}
+	var templates []models.NotificationTemplate
+	if err := tx.Where("event_type = ?", event.Type).Find(&templates).Error; err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+	var prefs []models.NotificationPreference
+	if err := tx.Where("user_id IN ? AND event_type = ?", userIDs, event.Type).Find(&prefs).Error; err != nil {
+		return fmt.Errorf("failed to load preferences: %w", err)
+	}
+
+	templateFor := make(map[string]models.NotificationTemplate, len(templates))
+	for _, t := range templates {
+		templateFor[t.Channel] = t
+	}
+	prefsFor := make(map[uint][]models.NotificationPreference)
+	for _, p := range prefs {
+		prefsFor[p.UserID] = append(prefsFor[p.UserID], p)
+	}
+
+	now := time.Now()
+	var queued []models.Notification
+	for _, user := range users {
+		for _, channel := range models.NotificationChannels {
+			enabled, target := Wants(prefsFor[user.ID], event.Type, channel)
+			if !enabled {
+				continue
+			}
+			tmpl, ok := templateFor[templateChannel(channel)]
+			if !ok {
+				continue
+			}
+			recipient := recipientFor(user, channel, target)
+			if recipient == "" && channel != models.ChannelInApp {
+				continue
+			}
+
+			data := make(map[string]interface{}, len(event.Data)+1)
+			for k, v := range event.Data {
+				data[k] = v
+			}
+			data["RecipientName"] = user.FirstName
+			subject, body, err := Render(tmpl, data)
+			if err != nil {
+				return fmt.Errorf("failed to render %s %s template: %w", event.Type, channel, err)
+			}
+
+			n := models.Notification{
+				UserID:        user.ID,
+				EventType:     event.Type,
+				Channel:       channel,
+				Recipient:     recipient,
+				Subject:       subject,
+				Body:          body,
+				Data:          event.Data,
+				Reference:     event.Reference,
+				Status:        "queued",
+				NextAttemptAt: now,
+			}
+			// Storing an in-app notification puts it in the inbox; there is
+			// nothing left to deliver
+			if channel == models.ChannelInApp {
+				n.Status = "sent"
+				n.SentAt = &now
+			}
+			queued = append(queued, n)
+		}
+	}
+	if len(queued) == 0 {
+		return nil
+	}
+
+	// A reference already notified hits the unique index and is skipped
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&queued).Error; err != nil {
+		return fmt.Errorf("failed to queue notifications: %w", err)
+	}
+	return nil
+}
+
+// Wants reports whether a user with the given preferences receives an event
+// on a channel, and the target they set for it
+func Wants(prefs []models.NotificationPreference, eventType, channel string) (bool, string) {
+	for _, p := range prefs {
+		if p.EventType == eventType && p.Channel == channel {
+			return p.Enabled, p.Target
+		}
+	}
+	return defaultChannels[channel], ""
+}
+
+// templateChannel returns the channel whose template a channel renders.
+// Webhooks carry the short in-app text alongside the event data.
+func templateChannel(channel string) string {
+	if channel == models.ChannelWebhook {
+		return models.ChannelInApp
+	}
+	return channel
+}
+
+// recipientFor returns where a channel delivers to for a user
+func recipientFor(user models.User, channel, target string) string {
+	switch channel {
+	case models.ChannelEmail:
+		return user.Email
+	case models.ChannelSMS:
+		if target != "" {
+			return target
+		}
+		return user.Phone
+	case models.ChannelWebhook:
+		return target
+	}
+	return ""
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
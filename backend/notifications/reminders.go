@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ReminderRun summarises one pass of the reminder job
+type ReminderRun struct {
+	Leases   []uint `json:"leases"`
+	Invoices []uint `json:"invoices"`
+}
+
+// QueueReminders notifies the tenant and landlord of active leases ending
+// within the lease expiry notice, unless the lease has been renewed, and
+// tenants of pending invoices due within the invoice due notice. Each lease
+// end date and invoice due date is reminded about once, however often the
+// job runs.
+func QueueReminders(database *gorm.DB, now time.Time) (*ReminderRun, error) {
+	run := &ReminderRun{}
+	today := truncateDay(now)
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var leases []models.Lease
+		if err := tx.Preload("Property").
+			Where("status = ? AND end_date >= ? AND end_date < ? AND deleted_at IS NULL", "active", today, now.Add(settings.LeaseExpiryNotice)).
+			Where("NOT EXISTS (SELECT 1 FROM leases renewals WHERE renewals.previous_lease_id = leases.id AND renewals.deleted_at IS NULL)").
+			Order("end_date, id").Find(&leases).Error; err != nil {
+			return fmt.Errorf("failed to load expiring leases: %w", err)
+		}
+		for _, lease := range leases {
+			event := Event{
+				Type: models.EventLeaseExpiring,
+				Data: map[string]interface{}{
+					"LeaseID":      lease.ID,
+					"PropertyName": lease.Property.Name,
+					"Address":      lease.Property.Address,
+					"EndDate":      lease.EndDate.Format("2006-01-02"),
+					"DaysLeft":     int(truncateDay(lease.EndDate).Sub(today).Hours() / 24),
+				},
+				Reference: fmt.Sprintf("lease:%d:%s", lease.ID, lease.EndDate.Format("2006-01-02")),
+			}
+			if err := Notify(tx, event, lease.TenantID, lease.Property.OwnerID); err != nil {
+				return fmt.Errorf("failed to remind about lease %d: %w", lease.ID, err)
+			}
+			run.Leases = append(run.Leases, lease.ID)
+		}
+
+		var invoices []models.Invoice
+		if err := tx.Preload("Property").
+			Where("payment_status = ? AND due_date >= ? AND due_date < ? AND deleted_at IS NULL", "pending", today, now.Add(settings.InvoiceDueNotice)).
+			Order("due_date, id").Find(&invoices).Error; err != nil {
+			return fmt.Errorf("failed to load invoices due: %w", err)
+		}
+		for _, invoice := range invoices {
+			event := Event{
+				Type: models.EventInvoiceDue,
+				Data: map[string]interface{}{
+					"InvoiceID":     invoice.ID,
+					"InvoiceNumber": invoice.InvoiceNumber,
+					"PropertyName":  invoice.Property.Name,
+					"Amount":        fmt.Sprintf("%.2f", invoice.Amount),
+					"Balance":       fmt.Sprintf("%.2f", invoice.BalanceRemaining()),
+					"DueDate":       invoice.DueDate.Format("2006-01-02"),
+				},
+				Reference: fmt.Sprintf("invoice:%d:%s", invoice.ID, invoice.DueDate.Format("2006-01-02")),
+			}
+			if err := Notify(tx, event, invoice.TenantID); err != nil {
+				return fmt.Errorf("failed to remind about invoice %d: %w", invoice.ID, err)
+			}
+			run.Invoices = append(run.Invoices, invoice.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(run.Leases) > 0 || len(run.Invoices) > 0 {
+		logger.LogInfo("Notification reminders queued", logrus.Fields{
+			"leases":   len(run.Leases),
+			"invoices": len(run.Invoices),
+		})
+	}
+	return run, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,172 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// inboxChannel is the Postgres channel the notifications table trigger
+	// announces new in-app notifications on
+	inboxChannel = "notification_inbox"
+	// streamBuffer is how many notifications a slow stream can fall behind
+	// before newer ones are dropped for it
+	streamBuffer = 16
+	// listenRetry is the wait before reconnecting a dropped listener
+	listenRetry = 5 * time.Second
+)
+
+// hub fans in-app notifications out to the streams open on this instance
+type hub struct {
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[uint]map[chan *models.Notification]struct{}
+}
+
+var streams = &hub{subscribers: make(map[uint]map[chan *models.Notification]struct{})}
+
+// Subscribe opens a stream of the user's new in-app notifications. Call the
+// returned function when done. The channel is closed by CloseStreams.
+func Subscribe(userID uint) (<-chan *models.Notification, func()) {
+	ch := make(chan *models.Notification, streamBuffer)
+
+	streams.mu.Lock()
+	defer streams.mu.Unlock()
+	if streams.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if streams.subscribers[userID] == nil {
+		streams.subscribers[userID] = make(map[chan *models.Notification]struct{})
+	}
+	streams.subscribers[userID][ch] = struct{}{}
+
+	return ch, func() {
+		streams.mu.Lock()
+		defer streams.mu.Unlock()
+		if _, ok := streams.subscribers[userID][ch]; !ok {
+			return
+		}
+		delete(streams.subscribers[userID], ch)
+		if len(streams.subscribers[userID]) == 0 {
+			delete(streams.subscribers, userID)
+		}
+		close(ch)
+	}
+}
+
+// Publish sends a notification to its user's open streams. A stream that is
+// not keeping up misses it and picks it up from the inbox instead.
+func Publish(n *models.Notification) {
+	streams.mu.Lock()
+	defer streams.mu.Unlock()
+	for ch := range streams.subscribers[n.UserID] {
+		select {
+		case ch <- n:
+		default:
+		}
+	}
+}
+
+// CloseStreams ends every open stream so the server can shut down without
+// waiting for clients to disconnect
+func CloseStreams() {
+	streams.mu.Lock()
+	defer streams.mu.Unlock()
+	streams.closed = true
+	for userID, subs := range streams.subscribers {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(streams.subscribers, userID)
+	}
+}
+
+func watching(userID uint) bool {
+	streams.mu.Lock()
+	defer streams.mu.Unlock()
+	return len(streams.subscribers[userID]) > 0
+}
+
+// ListenInbox publishes new in-app notifications to the streams open on this
+// instance until ctx is cancelled. Postgres announces each one when the
+// transaction that stored it commits, so every replica hears about it
+// whichever one made the change.
+func ListenInbox(ctx context.Context, database *gorm.DB) {
+	for {
+		err := listen(ctx, database)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.LogWarning("Notification inbox listener stopped, reconnecting", logrus.Fields{
+			"error": err,
+		})
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetry):
+		}
+	}
+}
+
+func listen(ctx context.Context, database *gorm.DB) error {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		pgConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("database driver does not support LISTEN")
+		}
+		if _, err := pgConn.Conn().Exec(ctx, "LISTEN "+inboxChannel); err != nil {
+			return err
+		}
+		// The connection goes back to the pool afterwards
+		defer pgConn.Conn().Exec(context.Background(), "UNLISTEN "+inboxChannel)
+
+		for {
+			msg, err := pgConn.Conn().WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+
+			var ref struct {
+				ID     uint `json:"id"`
+				UserID uint `json:"user_id"`
+			}
+			if err := json.Unmarshal([]byte(msg.Payload), &ref); err != nil {
+				logger.LogWarning("Ignoring malformed inbox notification", logrus.Fields{"payload": msg.Payload})
+				continue
+			}
+			if !watching(ref.UserID) {
+				continue
+			}
+
+			var n models.Notification
+			if err := database.WithContext(ctx).First(&n, ref.ID).Error; err != nil {
+				logger.LogWarning("Failed to load notification for streaming", logrus.Fields{
+					"notification_id": ref.ID,
+					"error":           err.Error(),
+				})
+				continue
+			}
+			Publish(&n)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/geoo115/property-manager/models"
+)
+
+// Render fills a template's subject and body with an event's data. Fields
+// missing from the data render as "<no value>" rather than failing, so an
+// edited template cannot block a notification.
+func Render(t models.NotificationTemplate, data map[string]interface{}) (string, string, error) {
+	subject, err := render("subject", t.Subject, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := render("body", t.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+func render(name, source string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New(name).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return out.String(), nil
+}
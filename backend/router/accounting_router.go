@@ -11,6 +11,21 @@ func AccountingRouter(rg *gin.RouterGroup) {
 	rg.POST("/invoices", accounting.CreateInvoice)
 	rg.PUT("/invoices/:id", accounting.UpdateInvoice)
 	rg.DELETE("/invoices/:id", accounting.DeleteInvoice)
+	rg.GET("/invoices/:id/payments", accounting.GetInvoicePayments)
+	rg.POST("/invoices/:id/payments", accounting.RecordInvoicePayment)
+	rg.POST("/invoices/:id/refunds", accounting.RefundInvoice)
+
+	rg.GET("/payments", accounting.GetPayments)
+	rg.GET("/payments/:id", accounting.GetPaymentByID)
+	rg.POST("/payments", accounting.RecordPayment)
+
+	rg.GET("/recurring-invoices/preview", accounting.GetRecurringPreview)
+	rg.POST("/recurring-invoices/generate", accounting.GenerateRecurringInvoices)
+
+	rg.GET("/late-fee-policies", accounting.GetLateFeePolicies)
+	rg.GET("/late-fee-policies/:landlordID", accounting.GetLateFeePolicy)
+	rg.PUT("/late-fee-policies/:landlordID", accounting.UpdateLateFeePolicy)
+	rg.POST("/late-fees/apply", accounting.ApplyLateFees)
 
 	rg.GET("/expenses", accounting.GetExpenses)
 	rg.GET("/expense/:id", accounting.GetExpenseByID)
@@ -18,6 +33,9 @@ func AccountingRouter(rg *gin.RouterGroup) {
 	rg.PUT("/expense/:id", accounting.UpdateExpense)
 	rg.DELETE("/expense/:id", accounting.DeleteExpense)
 
+	rg.GET("/tenants/:id/statement", accounting.GetTenantStatement)
+	rg.GET("/landlords/:id/statement", accounting.GetOwnerStatement)
+
 	rg.GET("/tenant/invoices", accounting.GetInvoicesForTenant)
 	rg.GET("/landlord/invoices", accounting.GetInvoicesForLandlord)
 }
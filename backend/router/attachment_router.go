@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/attachment"
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentRouter mounts file uploads for every attachable entity. Which
+// records a user reaches is decided by their role in the handlers.
+func AttachmentRouter(rg *gin.RouterGroup) {
+	for entity := range attachment.Entities {
+		rg.GET("/"+entity+"/:id/attachments", attachment.GetAttachments)
+		rg.POST("/"+entity+"/:id/attachments", attachment.UploadAttachment)
+	}
+	rg.DELETE("/attachments/:id", attachment.DeleteAttachment)
+}
@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/auditlog"
+	"github.com/gin-gonic/gin"
+)
+
+func AuditRouter(rg *gin.RouterGroup) {
+	rg.GET("/audit-logs", auditlog.GetAuditLogs)
+	// One route per entity: a "/:entity/:id/history" wildcard would not be
+	// reachable behind static routes such as "/users/:id"
+	for entity := range auditlog.HistoryEntities {
+		rg.GET("/"+entity+"/:id/history", auditlog.GetEntityHistory)
+	}
+}
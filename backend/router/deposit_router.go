@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/deposit"
+	"github.com/gin-gonic/gin"
+)
+
+func DepositRouter(rg *gin.RouterGroup) {
+	rg.GET("/leases/:id/deposit", deposit.GetDeposit)
+	rg.POST("/leases/:id/deposit/receive", deposit.ReceiveDeposit)
+	rg.POST("/leases/:id/deposit/deductions", deposit.AddDeduction)
+	rg.DELETE("/leases/:id/deposit/deductions/:deductionID", deposit.DeleteDeduction)
+	rg.POST("/leases/:id/deposit/propose", deposit.ProposeSettlement)
+	rg.POST("/leases/:id/deposit/settle", deposit.SettleDeposit)
+}
@@ -10,7 +10,11 @@ func LeaseRouter(rg *gin.RouterGroup) {
 	rg.GET("/leases/:id", lease.GetLeaseByID)
 	rg.GET("/leases/active", lease.GetActiveLeaseForTenant)
 	rg.GET("/properties/:id/lease", lease.GetLeaseForProperty)
+	rg.GET("/properties/:id/availability", lease.GetPropertyAvailability)
 	rg.POST("/leases", lease.CreateLease)
 	rg.PUT("/leases/:id", lease.UpdateLease)
+	rg.POST("/leases/:id/activate", lease.ActivateLease)
+	rg.POST("/leases/:id/renew", lease.RenewLease)
+	rg.POST("/leases/:id/terminate", lease.TerminateLease)
 	rg.DELETE("/leases/:id", lease.DeleteLease)
 }
@@ -8,8 +8,18 @@ import (
 func MaintenanceRoutes(rg *gin.RouterGroup) {
 	rg.GET("/maintenances", maintenance.GetMaintenances)
 	rg.GET("/maintenance/:id", maintenance.GetMaintenance)
-	rg.POST("/leases/:leaseID/maintenance", maintenance.CreateMaintenanceByLease)            // Tenant
-	rg.POST("/properties/:propertyID/maintenances", maintenance.CreateMaintenanceByProperty) // Admin/Landlord
+	rg.POST("/leases/:id/maintenance", maintenance.CreateMaintenanceByLease)         // Tenant
+	rg.POST("/properties/:id/maintenances", maintenance.CreateMaintenanceByProperty) // Admin/Landlord
 	rg.PUT("/maintenance/:id", maintenance.UpdateMaintenance)
+	rg.POST("/maintenance/:id/dispatch", maintenance.DispatchMaintenance)
 	rg.DELETE("/maintenance/:id", maintenance.DeleteMaintenance)
 }
+
+// MaintenanceThreadRoutes mounts the comment thread and status history of the
+// maintenance request at path, e.g. "/maintenance/:id" or
+// "/leases/:id/maintenance/:requestID"
+func MaintenanceThreadRoutes(rg *gin.RouterGroup, path string) {
+	rg.GET(path+"/comments", maintenance.GetComments)
+	rg.POST(path+"/comments", maintenance.CreateComment)
+	rg.GET(path+"/status-history", maintenance.GetStatusHistory)
+}
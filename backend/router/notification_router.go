@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/notification"
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationRouter mounts the signed-in user's notification settings, inbox
+// and live stream. It is mounted for every role.
+func NotificationRouter(rg *gin.RouterGroup) {
+	rg.GET("/notifications/preferences", notification.GetPreferences)
+	rg.PUT("/notifications/preferences", notification.UpdatePreferences)
+	rg.GET("/notifications/inbox", notification.GetInbox)
+	rg.GET("/notifications/inbox/unread-count", notification.GetUnreadCount)
+	rg.POST("/notifications/inbox/read-all", notification.MarkAllRead)
+	rg.POST("/notifications/inbox/:id/read", notification.MarkRead)
+	rg.POST("/notifications/inbox/:id/archive", notification.ArchiveNotification)
+	rg.GET("/notifications/stream", notification.StreamInbox)
+}
+
+// NotificationAdminRouter mounts the notification templates and the delivery
+// log
+func NotificationAdminRouter(rg *gin.RouterGroup) {
+	rg.GET("/notification-templates", notification.GetTemplates)
+	rg.PUT("/notification-templates/:id", notification.UpdateTemplate)
+	rg.GET("/notifications", notification.GetDeliveries)
+	rg.POST("/notifications/:id/retry", notification.RetryDelivery)
+}
@@ -1,22 +1,33 @@
 package router
 
 import (
-	"net/http"
 	"time"
 
 	"github.com/geoo115/property-manager/api/accounting"
+	"github.com/geoo115/property-manager/api/attachment"
+	"github.com/geoo115/property-manager/api/deposit"
+	"github.com/geoo115/property-manager/api/event"
 	"github.com/geoo115/property-manager/api/lease"
 	"github.com/geoo115/property-manager/api/maintenance"
 	"github.com/geoo115/property-manager/api/property"
+	"github.com/geoo115/property-manager/api/schedule"
+	"github.com/geoo115/property-manager/api/unit"
 	"github.com/geoo115/property-manager/api/user"
+	"github.com/geoo115/property-manager/api/vendor"
 	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/health"
+	"github.com/geoo115/property-manager/metrics"
 	"github.com/geoo115/property-manager/middleware"
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRouter(r *gin.Engine, cfg *config.Config) {
 	// Add global middleware
+	if cfg.Monitoring.EnableMetrics {
+		r.Use(metrics.GinMiddleware())
+	}
 	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.RequestID())
 	r.Use(middleware.RequestLogger())
 	r.Use(middleware.SecurityHeaders())
 	r.Use(middleware.CORSFromConfig(cfg))
@@ -25,20 +36,20 @@ func SetupRouter(r *gin.Engine, cfg *config.Config) {
 	r.NoRoute(middleware.NotFoundHandler())
 	r.NoMethod(middleware.MethodNotAllowedHandler())
 
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "healthy",
-			"service": "property-manager",
-			"version": "1.0.0",
-		})
-	})
+	// Health probes: liveness never touches dependencies, readiness pings
+	// Postgres, Redis and Kafka. /health keeps answering with the full report.
+	checker := health.NewChecker(health.DependencyChecks(cfg)...)
+	r.GET("/health", health.ReadyHandler(checker))
+	r.GET("/health/live", health.LiveHandler())
+	r.GET("/health/ready", health.ReadyHandler(checker))
 
 	// Public routes with development-friendly rate limiting
 	public := r.Group("/api/v1")
 	public.Use(middleware.IPRateLimit(500, time.Minute)) // 500 requests per minute per IP for development
 	{
 		AuthRoutes(public)
+		// Signed, expiring download links handed out by the attachment endpoints
+		public.GET("/files/:id", attachment.DownloadAttachment)
 	}
 
 	// Admin group: full access to all endpoints
@@ -51,8 +62,21 @@ func SetupRouter(r *gin.Engine, cfg *config.Config) {
 	{
 		UserRouter(admin)
 		PropertyRouter(admin)
+		UnitRouter(admin)
 		LeaseRouter(admin)
+		DepositRouter(admin)
+		AuditRouter(admin)
+		AttachmentRouter(admin)
 		MaintenanceRoutes(admin)
+		MaintenanceThreadRoutes(admin, "/maintenance/:id")
+		ScheduleRouter(admin, "/technicians/:id")
+		admin.PUT("/technicians/:id/skills", schedule.UpdateSkills)
+		VendorRouter(admin)
+		WorkOrderRouter(admin)
+		NotificationRouter(admin)
+		NotificationAdminRouter(admin)
+		WebhookRouter(admin)
+		admin.GET("/events/catalog", event.GetCatalog)
 		// Mount accounting endpoints under "/admin/accounting"
 		accountingGroup := admin.Group("/accounting")
 		AccountingRouter(accountingGroup)
@@ -70,12 +94,28 @@ func SetupRouter(r *gin.Engine, cfg *config.Config) {
 	{
 		landlord.GET("/properties", property.GetProperties)
 		landlord.GET("/properties/:id", property.GetPropertyByID)
+		landlord.GET("/properties/:id/units", unit.GetUnits)
+		landlord.GET("/properties/:id/units/:unitID", unit.GetUnitByID)
+		landlord.GET("/properties/:id/availability", lease.GetPropertyAvailability)
 		landlord.GET("/leases", lease.GetLeases)
 		landlord.GET("/leases/:id", lease.GetLeaseByID)
+		landlord.POST("/leases/:id/renew", lease.RenewLease)
+		landlord.POST("/leases/:id/terminate", lease.TerminateLease)
+		DepositRouter(landlord)
+		AttachmentRouter(landlord)
 		landlord.GET("/properties/:id/maintenances", maintenance.GetLandlordMaintenances)
 		landlord.POST("/properties/:id/maintenances", maintenance.CreateMaintenanceByProperty)
+		MaintenanceThreadRoutes(landlord, "/properties/:id/maintenances/:requestID")
+		landlord.GET("/vendors", vendor.GetVendors)
+		landlord.GET("/vendors/:id", vendor.GetVendor)
+		WorkOrderRouter(landlord)
 		landlord.GET("/invoices", accounting.GetInvoicesForLandlord)
 		landlord.GET("/expenses", accounting.GetExpensesForLandlord)
+		landlord.GET("/statement", accounting.GetOwnerStatement)
+		landlord.GET("/late-fee-policy", accounting.GetLateFeePolicy)
+		landlord.PUT("/late-fee-policy", accounting.UpdateLateFeePolicy)
+		NotificationRouter(landlord)
+		WebhookRouter(landlord)
 		// Mount dashboard endpoints for landlords
 		DashboardRouter(landlord)
 	}
@@ -87,9 +127,17 @@ func SetupRouter(r *gin.Engine, cfg *config.Config) {
 		tenant.GET("/leases", lease.GetLeasesForTenant)
 		tenant.GET("/leases/:id", lease.GetLeaseByID)
 		tenant.GET("/leases/active", lease.GetActiveLeaseForTenant) // Ensure this route is defined
+		tenant.POST("/leases/:id/notice", lease.TerminateLease)
+		tenant.GET("/leases/:id/deposit", deposit.GetDeposit)
+		tenant.POST("/leases/:id/deposit/dispute", deposit.DisputeDeposit)
+		AttachmentRouter(tenant)
 		tenant.GET("/leases/:id/maintenance", maintenance.GetMaintenances)
 		tenant.POST("/leases/:id/maintenance", maintenance.CreateMaintenanceByLease)
+		MaintenanceThreadRoutes(tenant, "/leases/:id/maintenance/:requestID")
 		tenant.GET("/invoices", accounting.GetInvoicesForTenant)
+		tenant.GET("/payments", accounting.GetPaymentsForTenant)
+		tenant.GET("/statement", accounting.GetTenantStatement)
+		NotificationRouter(tenant)
 		// Mount dashboard endpoints for tenants
 		DashboardRouter(tenant)
 	}
@@ -101,8 +149,16 @@ func SetupRouter(r *gin.Engine, cfg *config.Config) {
 		maintenanceTeam.GET("/maintenances", maintenance.GetMaintenances)
 		maintenanceTeam.GET("/maintenance/:id", maintenance.GetMaintenance)
 		maintenanceTeam.PUT("/maintenance/:id", maintenance.UpdateMaintenance)
+		MaintenanceThreadRoutes(maintenanceTeam, "/maintenance/:id")
+		maintenanceTeam.POST("/maintenance/:id/dispatch", maintenance.DispatchMaintenance)
+		ScheduleRouter(maintenanceTeam, "")
+		maintenanceTeam.GET("/vendors", vendor.GetVendors)
+		maintenanceTeam.GET("/vendors/:id", vendor.GetVendor)
+		WorkOrderRouter(maintenanceTeam)
+		AttachmentRouter(maintenanceTeam)
 		maintenanceTeam.GET("/users", user.GetUsers)
 		maintenanceTeam.GET("/properties", property.GetProperties)
+		NotificationRouter(maintenanceTeam)
 		// Mount dashboard endpoints for maintenance team
 		DashboardRouter(maintenanceTeam)
 	}
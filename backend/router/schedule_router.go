@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/schedule"
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleRouter mounts a technician's calendar under prefix: "" for the
+// signed-in maintenance team member, "/technicians/:id" for admins
+func ScheduleRouter(rg *gin.RouterGroup, prefix string) {
+	rg.GET(prefix+"/schedule", schedule.GetAgenda)
+	rg.PUT(prefix+"/schedule/hours", schedule.UpdateWorkingHours)
+	rg.POST(prefix+"/schedule/time-off", schedule.CreateTimeOff)
+	rg.DELETE(prefix+"/schedule/time-off/:timeOffID", schedule.DeleteTimeOff)
+}
@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/unit"
+	"github.com/gin-gonic/gin"
+)
+
+func UnitRouter(rg *gin.RouterGroup) {
+	rg.GET("/properties/:id/units", unit.GetUnits)
+	rg.GET("/properties/:id/units/:unitID", unit.GetUnitByID)
+	rg.POST("/properties/:id/units", unit.CreateUnit)
+	rg.PUT("/properties/:id/units/:unitID", unit.UpdateUnit)
+	rg.DELETE("/properties/:id/units/:unitID", unit.DeleteUnit)
+}
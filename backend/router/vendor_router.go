@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/vendor"
+	"github.com/geoo115/property-manager/api/workorder"
+	"github.com/gin-gonic/gin"
+)
+
+// VendorRouter mounts the vendor directory. Only admins maintain it; other
+// staff read it with explicit routes.
+func VendorRouter(rg *gin.RouterGroup) {
+	rg.GET("/vendors", vendor.GetVendors)
+	rg.GET("/vendors/:id", vendor.GetVendor)
+	rg.POST("/vendors", vendor.CreateVendor)
+	rg.PUT("/vendors/:id", vendor.UpdateVendor)
+}
+
+// WorkOrderRouter mounts work orders, quotes and vendor invoices. Which
+// records a user reaches and who may approve is decided by role in the
+// handlers.
+func WorkOrderRouter(rg *gin.RouterGroup) {
+	rg.GET("/maintenance/:id/work-orders", workorder.GetWorkOrders)
+	rg.POST("/maintenance/:id/work-orders", workorder.CreateWorkOrder)
+	rg.GET("/work-orders/:id", workorder.GetWorkOrder)
+	rg.POST("/work-orders/:id/quotes", workorder.AddQuote)
+	rg.POST("/work-orders/:id/quotes/:quoteID/approve", workorder.ApproveQuote)
+	rg.POST("/work-orders/:id/invoice", workorder.RecordInvoice)
+	rg.POST("/work-orders/:id/cancel", workorder.CancelWorkOrder)
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/geoo115/property-manager/api/webhook"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRouter mounts the signed-in user's webhook endpoints and their
+// delivery logs. Admins manage every endpoint, landlords their own.
+func WebhookRouter(rg *gin.RouterGroup) {
+	rg.GET("/webhooks", webhook.GetEndpoints)
+	rg.POST("/webhooks", webhook.CreateEndpoint)
+	rg.GET("/webhooks/:id", webhook.GetEndpoint)
+	rg.PUT("/webhooks/:id", webhook.UpdateEndpoint)
+	rg.DELETE("/webhooks/:id", webhook.DeleteEndpoint)
+	rg.POST("/webhooks/:id/rotate-secret", webhook.RotateSecret)
+	rg.POST("/webhooks/:id/test", webhook.SendTestEvent)
+	rg.GET("/webhooks/:id/deliveries", webhook.GetDeliveries)
+	rg.POST("/webhooks/:id/deliveries/:deliveryID/retry", webhook.RetryDelivery)
+}
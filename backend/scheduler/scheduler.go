@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their own tickers until stopped
+type Scheduler struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates an empty scheduler
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches every registered job in its own goroutine. Each job runs
+// once immediately and then on every tick of its interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			logger.LogWarning("Skipping scheduled job with invalid interval", logrus.Fields{
+				"job":      job.Name,
+				"interval": job.Interval,
+			})
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	logger.LogInfo("Scheduler started", logrus.Fields{
+		"jobs": len(s.jobs),
+	})
+}
+
+// Stop cancels all jobs and waits for running executions to finish
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	logger.LogInfo("Scheduler stopped", nil)
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.runOnce(ctx, job)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runOnce(ctx, job)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.LogError(nil, "Scheduled job panicked", logrus.Fields{
+				"job":   job.Name,
+				"panic": r,
+			})
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil {
+		logger.LogError(err, "Scheduled job failed", logrus.Fields{
+			"job":      job.Name,
+			"duration": time.Since(start),
+		})
+		return
+	}
+
+	logger.LogDebug("Scheduled job completed", logrus.Fields{
+		"job":      job.Name,
+		"duration": time.Since(start),
+	})
+}
@@ -0,0 +1,258 @@
+package servicing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// rechargeDueDays is how long a tenant has to pay a damage recharge
+const rechargeDueDays = 14
+
+var (
+	// ErrNoTenantToRecharge is returned when a recharged request has no tenant to invoice
+	ErrNoTenantToRecharge = errors.New("maintenance request has no tenant to recharge")
+	// ErrRechargePaid is returned when a correction would take a recharge invoice below what the tenant has paid
+	ErrRechargePaid = errors.New("recharge invoice has already been paid")
+)
+
+// CostPosting is what PostCosts booked for a request. Either may be nil.
+type CostPosting struct {
+	Expense *models.Expense
+	Invoice *models.Invoice
+}
+
+// ExpenseCategory returns the expense category a request's cost is booked
+// under: routine work is maintenance, everything else a repair
+func ExpenseCategory(category string) string {
+	if category == "general" {
+		return "maintenance"
+	}
+	return "repairs"
+}
+
+// PostableCost returns the part of a request's actual cost not already
+// booked through vendor invoices
+func PostableCost(actualCost, vendorBilled float64) float64 {
+	return math.Max(roundCurrency(actualCost-vendorBilled), 0)
+}
+
+// PostCosts books a completed request's actual cost to accounting. The cost,
+// less any vendor invoices already booked through work orders, becomes one
+// expense against the property; when RechargeTenant is set the cost, or
+// RechargeAmount, is also invoiced to the tenant. Calling it again after the
+// cost or recharge is corrected updates both, so it is safe to call on every
+// save of a completed request.
+func PostCosts(tx *gorm.DB, m *models.Maintenance, userID uint, now time.Time) (*CostPosting, error) {
+	posting := &CostPosting{}
+	if m.Status != "completed" {
+		return posting, nil
+	}
+
+	expense, err := postExpense(tx, m, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	posting.Expense = expense
+
+	invoice, err := postRecharge(tx, m, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	posting.Invoice = invoice
+	return posting, nil
+}
+
+// ReverseCosts takes back what PostCosts booked when a completed request is
+// reopened: its maintenance expense is removed and its recharge invoice is
+// cancelled, so the books do not show a cost for work that is open again.
+// Completing the request again posts afresh. A recharge the tenant has
+// already paid cannot be taken back and returns ErrRechargePaid.
+func ReverseCosts(tx *gorm.DB, m *models.Maintenance) (*CostPosting, error) {
+	posting := &CostPosting{}
+
+	var expense models.Expense
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("maintenance_id = ? AND source = ?", m.ID, "maintenance").First(&expense).Error
+	switch {
+	case err == nil:
+		if err := tx.Delete(&expense).Error; err != nil {
+			return nil, fmt.Errorf("failed to remove maintenance expense: %w", err)
+		}
+		posting.Expense = &expense
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, fmt.Errorf("failed to load maintenance expense: %w", err)
+	}
+
+	if m.RechargeInvoiceID != nil {
+		var invoice models.Invoice
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, *m.RechargeInvoiceID).Error; err != nil {
+			return nil, fmt.Errorf("failed to load recharge invoice: %w", err)
+		}
+		if err := cancelRecharge(tx, m, &invoice); err != nil {
+			return nil, err
+		}
+		posting.Invoice = &invoice
+	}
+	return posting, nil
+}
+
+func postExpense(tx *gorm.DB, m *models.Maintenance, userID uint, now time.Time) (*models.Expense, error) {
+	var vendorBilled float64
+	if err := tx.Model(&models.Expense{}).Select("COALESCE(SUM(amount), 0)").
+		Where("maintenance_id = ? AND source = ?", m.ID, "work_order").
+		Scan(&vendorBilled).Error; err != nil {
+		return nil, fmt.Errorf("failed to total vendor invoices: %w", err)
+	}
+	amount := PostableCost(m.ActualCost, vendorBilled)
+
+	var expense models.Expense
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("maintenance_id = ? AND source = ?", m.ID, "maintenance").First(&expense).Error
+	found := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load maintenance expense: %w", err)
+	}
+
+	if amount == 0 {
+		if found {
+			if err := tx.Delete(&expense).Error; err != nil {
+				return nil, fmt.Errorf("failed to remove maintenance expense: %w", err)
+			}
+			return &expense, nil
+		}
+		return nil, nil
+	}
+
+	expenseDate := now
+	if m.CompletedAt != nil {
+		expenseDate = *m.CompletedAt
+	}
+	expense.PropertyID = m.PropertyID
+	expense.Description = fmt.Sprintf("Maintenance #%d: %s", m.ID, m.Title)
+	expense.Category = ExpenseCategory(m.Category)
+	expense.Amount = amount
+	expense.ExpenseDate = expenseDate
+	if !found {
+		expense.CreatedByID = userID
+		expense.ExpenseNumber = billing.NewExpenseNumber()
+		expense.MaintenanceID = &m.ID
+		expense.Source = "maintenance"
+	}
+	if err := tx.Save(&expense).Error; err != nil {
+		return nil, fmt.Errorf("failed to post maintenance expense: %w", err)
+	}
+	return &expense, nil
+}
+
+func postRecharge(tx *gorm.DB, m *models.Maintenance, userID uint, now time.Time) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if m.RechargeInvoiceID != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invoice, *m.RechargeInvoiceID).Error; err != nil {
+			return nil, fmt.Errorf("failed to load recharge invoice: %w", err)
+		}
+	}
+	paid := roundCurrency(invoice.PaidAmount - invoice.RefundedAmount)
+
+	amount := m.ActualCost
+	if m.RechargeAmount > 0 {
+		amount = m.RechargeAmount
+	}
+	amount = roundCurrency(amount)
+
+	// Withdrawing the recharge, or correcting it to nothing, cancels the invoice
+	if !m.RechargeTenant || amount == 0 {
+		if m.RechargeInvoiceID == nil {
+			return nil, nil
+		}
+		if err := cancelRecharge(tx, m, &invoice); err != nil {
+			return nil, err
+		}
+		return &invoice, nil
+	}
+
+	if m.RechargeInvoiceID != nil {
+		if amount < paid {
+			return nil, ErrRechargePaid
+		}
+		if roundCurrency(invoice.Amount) == amount {
+			return &invoice, nil
+		}
+		if err := tx.Model(&invoice).Update("amount", amount).Error; err != nil {
+			return nil, fmt.Errorf("failed to correct recharge invoice: %w", err)
+		}
+		return billing.RecalculateInvoice(tx, invoice.ID)
+	}
+
+	tenantID, err := rechargeTenant(tx, m)
+	if err != nil {
+		return nil, err
+	}
+	invoice = models.Invoice{
+		InvoiceNumber: billing.NewInvoiceNumber(),
+		CreatedByID:   userID,
+		TenantID:      tenantID,
+		PropertyID:    m.PropertyID,
+		LeaseID:       m.LeaseID,
+		UnitID:        m.UnitID,
+		Amount:        amount,
+		InvoiceDate:   now,
+		DueDate:       now.AddDate(0, 0, rechargeDueDays),
+		Category:      "maintenance",
+		PaymentStatus: "pending",
+		Notes:         fmt.Sprintf("Recharge for maintenance #%d: %s", m.ID, m.Title),
+	}
+	if err := tx.Create(&invoice).Error; err != nil {
+		return nil, fmt.Errorf("failed to raise recharge invoice: %w", err)
+	}
+	if err := billing.PublishInvoice(tx, models.WebhookInvoiceCreated, invoice); err != nil {
+		return nil, err
+	}
+	if err := tx.Model(m).Update("recharge_invoice_id", invoice.ID).Error; err != nil {
+		return nil, fmt.Errorf("failed to link recharge invoice: %w", err)
+	}
+	m.RechargeInvoiceID = &invoice.ID
+	return &invoice, nil
+}
+
+// cancelRecharge cancels a request's unpaid recharge invoice and unlinks it
+func cancelRecharge(tx *gorm.DB, m *models.Maintenance, invoice *models.Invoice) error {
+	if roundCurrency(invoice.PaidAmount-invoice.RefundedAmount) > 0 {
+		return ErrRechargePaid
+	}
+	if err := tx.Model(invoice).Update("payment_status", "cancelled").Error; err != nil {
+		return fmt.Errorf("failed to cancel recharge invoice: %w", err)
+	}
+	if err := tx.Model(m).Update("recharge_invoice_id", nil).Error; err != nil {
+		return fmt.Errorf("failed to unlink recharge invoice: %w", err)
+	}
+	m.RechargeInvoiceID = nil
+	invoice.PaymentStatus = "cancelled"
+	return nil
+}
+
+// rechargeTenant returns who pays for tenant-caused damage: the tenant on the
+// request's lease, otherwise the tenant who raised it
+func rechargeTenant(tx *gorm.DB, m *models.Maintenance) (uint, error) {
+	if m.LeaseID != nil {
+		var lease models.Lease
+		if err := tx.Select("id", "tenant_id").First(&lease, *m.LeaseID).Error; err == nil {
+			return lease.TenantID, nil
+		}
+	}
+	var requester models.User
+	if err := tx.Select("id", "role").First(&requester, m.RequestedByID).Error; err == nil && requester.Role == "tenant" {
+		return requester.ID, nil
+	}
+	return 0, ErrNoTenantToRecharge
+}
+
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,134 @@
+package servicing
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// bookedStatuses are the statuses whose appointments hold a technician's time
+var bookedStatuses = []string{"assigned", "in_progress"}
+
+// LoadCalendar loads a technician's working hours with the time off and
+// booked appointments that overlap [from, to). Request excludeID is left out
+// so a request being rescheduled does not conflict with itself.
+func LoadCalendar(tx *gorm.DB, technicianID uint, from, to time.Time, excludeID uint) (Calendar, error) {
+	var cal Calendar
+	if err := tx.Where("user_id = ?", technicianID).Order("weekday, start_time").Find(&cal.Hours).Error; err != nil {
+		return cal, fmt.Errorf("failed to load working hours: %w", err)
+	}
+	if err := tx.Where("user_id = ? AND starts_at < ? AND ends_at > ?", technicianID, to, from).
+		Order("starts_at").Find(&cal.TimeOff).Error; err != nil {
+		return cal, fmt.Errorf("failed to load time off: %w", err)
+	}
+	// Appointments without an end get the default length, so look back that far
+	if err := tx.Preload("Property").
+		Where("assigned_to_id = ? AND id <> ? AND status IN ? AND deleted_at IS NULL", technicianID, excludeID, bookedStatuses).
+		Where("scheduled_at < ? AND COALESCE(scheduled_end_at, scheduled_at + ?::interval) > ?", to, fmt.Sprintf("%d seconds", int(appointmentLength.Seconds())), from).
+		Order("scheduled_at").Find(&cal.Appointments).Error; err != nil {
+		return cal, fmt.Errorf("failed to load appointments: %w", err)
+	}
+	return cal, nil
+}
+
+// CheckAppointment returns why m's assignee cannot take m's appointment, or
+// nil when m is unassigned, unscheduled or the technician is free. It locks
+// the technician's user row so concurrent bookings of the same technician
+// are checked one after the other; call it inside the transaction that
+// saves m.
+func CheckAppointment(tx *gorm.DB, m *models.Maintenance) error {
+	start, end, ok := Appointment(m)
+	if m.AssignedToID == nil || !ok || !m.IsOpen() {
+		return nil
+	}
+
+	var technician models.User
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&technician, *m.AssignedToID).Error; err != nil {
+		return fmt.Errorf("failed to lock technician: %w", err)
+	}
+	cal, err := LoadCalendar(tx, technician.ID, start, end, m.ID)
+	if err != nil {
+		return err
+	}
+	return cal.Check(start, end)
+}
+
+// Candidate is a technician who could take a request
+type Candidate struct {
+	Technician models.Participant `json:"technician"`
+	OpenJobs   int                `json:"open_jobs"`
+	Available  bool               `json:"available"`
+	Reason     string             `json:"reason,omitempty"` // Why an unavailable technician cannot take the appointment
+}
+
+// Candidates ranks the active maintenance team members skilled in m's
+// category for an appointment from start to end: available technicians
+// first, then those with the fewest open jobs.
+func Candidates(tx *gorm.DB, m *models.Maintenance, start, end time.Time) ([]Candidate, error) {
+	var technicians []models.User
+	if err := tx.Where("role = ? AND is_active = ? AND deleted_at IS NULL", "maintenanceTeam", true).
+		Where("id IN (?)", tx.Model(&models.TechnicianSkill{}).Select("user_id").Where("category = ?", m.Category)).
+		Order("id").Find(&technicians).Error; err != nil {
+		return nil, fmt.Errorf("failed to load technicians: %w", err)
+	}
+	if len(technicians) == 0 {
+		return []Candidate{}, nil
+	}
+
+	ids := make([]uint, 0, len(technicians))
+	for _, technician := range technicians {
+		ids = append(ids, technician.ID)
+	}
+	var loads []struct {
+		AssignedToID uint
+		Count        int
+	}
+	if err := tx.Model(&models.Maintenance{}).Select("assigned_to_id, COUNT(*) AS count").
+		Where("assigned_to_id IN ? AND status IN ? AND deleted_at IS NULL", ids, bookedStatuses).
+		Group("assigned_to_id").Scan(&loads).Error; err != nil {
+		return nil, fmt.Errorf("failed to count open jobs: %w", err)
+	}
+	openJobs := make(map[uint]int, len(loads))
+	for _, load := range loads {
+		openJobs[load.AssignedToID] = load.Count
+	}
+
+	candidates := make([]Candidate, 0, len(technicians))
+	for i := range technicians {
+		cal, err := LoadCalendar(tx, technicians[i].ID, start, end, m.ID)
+		if err != nil {
+			return nil, err
+		}
+		candidate := Candidate{
+			Technician: technicians[i].ToParticipant(),
+			OpenJobs:   openJobs[technicians[i].ID],
+			Available:  true,
+		}
+		if err := cal.Check(start, end); err != nil {
+			candidate.Available = false
+			candidate.Reason = err.Error()
+		}
+		candidates = append(candidates, candidate)
+	}
+	RankCandidates(candidates)
+	return candidates, nil
+}
+
+// RankCandidates orders candidates available first, then by fewest open
+// jobs, then by ID so ties are stable
+func RankCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Available != b.Available {
+			return a.Available
+		}
+		if a.OpenJobs != b.OpenJobs {
+			return a.OpenJobs < b.OpenJobs
+		}
+		return a.Technician.ID < b.Technician.ID
+	})
+}
@@ -0,0 +1,197 @@
+package servicing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// slaLockKey is the Postgres advisory lock that serialises SLA checks across
+// replicas
+const slaLockKey int64 = 7301004
+
+// Kinds of SLA breach
+const (
+	BreachAcknowledgement = "acknowledgement"
+	BreachResolution      = "resolution"
+)
+
+// openStatuses are the statuses whose SLA clocks are running
+var openStatuses = []string{"pending", "assigned", "in_progress"}
+
+// Escalation is one SLA breach acted on by the checker
+type Escalation struct {
+	MaintenanceID uint      `json:"maintenance_id"`
+	PropertyID    uint      `json:"property_id"`
+	Title         string    `json:"title"`
+	Breach        string    `json:"breach"`
+	DueAt         time.Time `json:"due_at"`
+	FromPriority  string    `json:"from_priority"`
+	ToPriority    string    `json:"to_priority"`
+	// Recipients are the landlord's and admins' email addresses
+	Recipients []string `json:"-"`
+}
+
+// Notifier tells the landlord and admins about an escalation
+type Notifier func(Escalation) error
+
+// SLARun summarises one pass of the SLA checker
+type SLARun struct {
+	ClocksStarted []uint       `json:"clocks_started"`
+	Escalations   []Escalation `json:"escalations"`
+}
+
+// CheckSLAs escalates open requests that have missed a target: each new
+// breach bumps the priority one step and notifies the landlord and admins.
+// An acknowledgement breach and a resolution breach are each escalated once.
+// Open requests without deadlines get their clocks started from when they
+// were requested.
+func CheckSLAs(database *gorm.DB, now time.Time, notify Notifier) (*SLARun, error) {
+	run := &SLARun{}
+
+	err := database.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", slaLockKey).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to acquire SLA check lock: %w", err)
+		}
+		if !locked {
+			logger.LogInfo("Maintenance SLA check already in progress on another instance", nil)
+			return nil
+		}
+
+		var untracked []models.Maintenance
+		if err := tx.Where("status IN ? AND resolve_due_at IS NULL AND deleted_at IS NULL", openStatuses).
+			Find(&untracked).Error; err != nil {
+			return fmt.Errorf("failed to load untracked requests: %w", err)
+		}
+		for i := range untracked {
+			m := &untracked[i]
+			from := m.RequestedAt
+			if from.IsZero() {
+				from = m.CreatedAt
+			}
+			StartClock(m, from)
+			if err := tx.Model(m).Updates(map[string]interface{}{
+				"acknowledge_due_at": m.AcknowledgeDueAt,
+				"resolve_due_at":     m.ResolveDueAt,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to start SLA clock for request %d: %w", m.ID, err)
+			}
+			run.ClocksStarted = append(run.ClocksStarted, m.ID)
+		}
+
+		var breached []models.Maintenance
+		if err := tx.Preload("Property.Owner").
+			Where("status IN ? AND deleted_at IS NULL", openStatuses).
+			Where("(escalation_level < 1 AND acknowledged_at IS NULL AND acknowledge_due_at < ?) OR (escalation_level < 2 AND resolve_due_at < ?)", now, now).
+			Order("id").Find(&breached).Error; err != nil {
+			return fmt.Errorf("failed to load breached requests: %w", err)
+		}
+		if len(breached) == 0 {
+			return nil
+		}
+
+		var adminEmails []string
+		if err := tx.Model(&models.User{}).Where("role = ? AND is_active = ?", "admin", true).
+			Pluck("email", &adminEmails).Error; err != nil {
+			return fmt.Errorf("failed to load admin emails: %w", err)
+		}
+
+		for i := range breached {
+			escalations := escalate(&breached[i], now, adminEmails)
+			if len(escalations) == 0 {
+				continue
+			}
+			m := breached[i]
+			if err := tx.Model(&m).Updates(map[string]interface{}{
+				"priority":         m.Priority,
+				"escalation_level": m.EscalationLevel,
+				"escalated_at":     m.EscalatedAt,
+			}).Error; err != nil {
+				return fmt.Errorf("failed to escalate request %d: %w", m.ID, err)
+			}
+			run.Escalations = append(run.Escalations, escalations...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Notify only once the escalation is committed
+	for _, escalation := range run.Escalations {
+		if notify == nil {
+			break
+		}
+		if err := notify(escalation); err != nil {
+			logger.LogError(err, "Failed to send SLA escalation", logrus.Fields{
+				"maintenance_id": escalation.MaintenanceID,
+				"breach":         escalation.Breach,
+			})
+		}
+	}
+
+	if len(run.ClocksStarted) > 0 || len(run.Escalations) > 0 {
+		InvalidateCache(context.Background())
+		logger.LogInfo("Maintenance SLA check completed", logrus.Fields{
+			"clocks_started": len(run.ClocksStarted),
+			"escalations":    len(run.Escalations),
+		})
+	}
+	return run, nil
+}
+
+// escalate applies every breach of m not yet escalated and returns them
+func escalate(m *models.Maintenance, now time.Time, adminEmails []string) []Escalation {
+	recipients := append([]string{}, adminEmails...)
+	if m.Property.Owner.Email != "" {
+		recipients = append(recipients, m.Property.Owner.Email)
+	}
+
+	var escalations []Escalation
+	add := func(breach string, dueAt time.Time, level int) {
+		from := m.Priority
+		m.Priority = NextPriority(m.Priority)
+		m.EscalationLevel = level
+		m.EscalatedAt = &now
+		escalations = append(escalations, Escalation{
+			MaintenanceID: m.ID,
+			PropertyID:    m.PropertyID,
+			Title:         m.Title,
+			Breach:        breach,
+			DueAt:         dueAt,
+			FromPriority:  from,
+			ToPriority:    m.Priority,
+			Recipients:    recipients,
+		})
+	}
+
+	if m.EscalationLevel < 1 && m.AcknowledgedAt == nil &&
+		m.AcknowledgeDueAt != nil && m.AcknowledgeDueAt.Before(now) {
+		add(BreachAcknowledgement, *m.AcknowledgeDueAt, 1)
+	}
+	if m.EscalationLevel < 2 && m.ResolveDueAt != nil && m.ResolveDueAt.Before(now) {
+		add(BreachResolution, *m.ResolveDueAt, 2)
+	}
+	return escalations
+}
+
+// InvalidateCache drops every cached maintenance list and request
+func InvalidateCache(ctx context.Context) {
+	if db.RedisClient == nil {
+		return
+	}
+	iter := db.RedisClient.Scan(ctx, 0, "maintenance*", 100).Iterator()
+	for iter.Next(ctx) {
+		db.RedisClient.Del(ctx, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		logger.LogError(err, "Failed to invalidate maintenance cache", nil)
+	}
+}
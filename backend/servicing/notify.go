@@ -0,0 +1,104 @@
+package servicing
+
+import (
+	"fmt"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/notifications"
+	"gorm.io/gorm"
+)
+
+// EmailNotifier emails each escalation to the landlord and admins
+func EmailNotifier(cfg config.EmailConfig) Notifier {
+	return func(e Escalation) error {
+		subject := fmt.Sprintf("SLA breach: maintenance request #%d escalated to %s", e.MaintenanceID, e.ToPriority)
+		body := fmt.Sprintf(
+			"Maintenance request #%d on property %d missed its %s target.\n\n"+
+				"Title: %s\n"+
+				"Due: %s\n"+
+				"Priority: %s -> %s\n\n"+
+				"Please make sure it is picked up.",
+			e.MaintenanceID, e.PropertyID, e.Breach,
+			e.Title, e.DueAt.Format("2006-01-02 15:04"), e.FromPriority, e.ToPriority,
+		)
+		return notifications.SendEmail(cfg, e.Recipients, subject, body)
+	}
+}
+
+// NotifyMaintenance queues a maintenance notification for the people it
+// concerns: new requests go to the maintenance team and landlord, assignments
+// to the technician and requester, completions to the requester and landlord
+func NotifyMaintenance(tx *gorm.DB, eventType string, m *models.Maintenance) error {
+	return notifyMaintenance(tx, eventType, m, nil)
+}
+
+func notifyMaintenance(tx *gorm.DB, eventType string, m *models.Maintenance, extra map[string]interface{}) error {
+	var property models.Property
+	if err := tx.Select("id", "name", "owner_id").First(&property, m.PropertyID).Error; err != nil {
+		return fmt.Errorf("failed to load property: %w", err)
+	}
+
+	var recipients []uint
+	switch eventType {
+	case models.EventMaintenanceCreated:
+		if err := tx.Model(&models.User{}).Where("role = ? AND is_active = ? AND deleted_at IS NULL", "maintenanceTeam", true).
+			Pluck("id", &recipients).Error; err != nil {
+			return fmt.Errorf("failed to load maintenance team: %w", err)
+		}
+		// Landlords raising a request themselves need no telling
+		if property.OwnerID != m.RequestedByID {
+			recipients = append(recipients, property.OwnerID)
+		}
+	case models.EventMaintenanceAssigned:
+		if m.AssignedToID != nil {
+			recipients = append(recipients, *m.AssignedToID)
+		}
+		recipients = append(recipients, m.RequestedByID)
+	case models.EventMaintenanceCompleted:
+		recipients = append(recipients, m.RequestedByID, property.OwnerID)
+	case models.EventMaintenanceStatusChanged:
+		recipients = append(recipients, m.RequestedByID)
+	}
+
+	scheduledAt := ""
+	if m.ScheduledAt != nil {
+		scheduledAt = m.ScheduledAt.In(location).Format("2006-01-02 15:04")
+	}
+	event := notifications.Event{
+		Type: eventType,
+		Data: map[string]interface{}{
+			"MaintenanceID": m.ID,
+			"Title":         m.Title,
+			"Description":   m.Description,
+			"Status":        m.Status,
+			"Priority":      m.Priority,
+			"Category":      m.Category,
+			"PropertyID":    property.ID,
+			"PropertyName":  property.Name,
+			"ScheduledAt":   scheduledAt,
+		},
+	}
+	for k, v := range extra {
+		event.Data[k] = v
+	}
+	return notifications.Notify(tx, event, recipients...)
+}
+
+// NotifyStatusChange queues the notification for a request's move from one
+// status to its current one. Assignment and completion have their own events;
+// any other move is reported to the requester.
+func NotifyStatusChange(tx *gorm.DB, m *models.Maintenance, from string) error {
+	if m.Status == from {
+		return nil
+	}
+	switch m.Status {
+	case "assigned":
+		return NotifyMaintenance(tx, models.EventMaintenanceAssigned, m)
+	case "completed":
+		return NotifyMaintenance(tx, models.EventMaintenanceCompleted, m)
+	}
+	return notifyMaintenance(tx, models.EventMaintenanceStatusChanged, m, map[string]interface{}{
+		"PreviousStatus": from,
+	})
+}
@@ -0,0 +1,137 @@
+package servicing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/models"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrOutsideWorkingHours is returned when an appointment does not fit in one of the technician's shifts
+	ErrOutsideWorkingHours = errors.New("outside the technician's working hours")
+	// ErrTimeOff is returned when an appointment falls in the technician's time off
+	ErrTimeOff = errors.New("the technician is on time off")
+)
+
+// ConflictError is returned when an appointment overlaps another booked
+// appointment of the same technician
+type ConflictError struct {
+	Conflicts []models.Maintenance
+}
+
+func (e *ConflictError) Error() string {
+	ids := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		ids = append(ids, fmt.Sprintf("%d", conflict.ID))
+	}
+	return "appointment overlaps maintenance request(s) " + strings.Join(ids, ", ")
+}
+
+var (
+	// location is the time zone working hours are given in
+	location = time.UTC
+	// appointmentLength is used for appointments booked without an end
+	appointmentLength = 2 * time.Hour
+)
+
+// initSchedule applies the configured scheduling time zone and default
+// appointment length
+func initSchedule(cfg config.SchedulingConfig) {
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			logger.LogError(err, "Invalid scheduling time zone, using UTC", logrus.Fields{"timezone": cfg.Timezone})
+		} else {
+			location = loc
+		}
+	}
+	if cfg.DefaultAppointment > 0 {
+		appointmentLength = cfg.DefaultAppointment
+	}
+}
+
+// Schedule books m's appointment from start until end, or for the default
+// appointment length when end is nil
+func Schedule(m *models.Maintenance, start time.Time, end *time.Time) {
+	finish := start.Add(appointmentLength)
+	if end != nil {
+		finish = *end
+	}
+	m.ScheduledAt = &start
+	m.ScheduledEndAt = &finish
+}
+
+// Appointment returns the window m is booked for. Requests scheduled before
+// appointments had an end get the default length.
+func Appointment(m *models.Maintenance) (start, end time.Time, ok bool) {
+	if m.ScheduledAt == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	start = *m.ScheduledAt
+	end = start.Add(appointmentLength)
+	if m.ScheduledEndAt != nil {
+		end = *m.ScheduledEndAt
+	}
+	return start, end, true
+}
+
+// Calendar is a technician's weekly hours, time off and booked appointments
+type Calendar struct {
+	Hours        []models.WorkingHours
+	TimeOff      []models.TimeOff
+	Appointments []models.Maintenance
+}
+
+// Check returns why the technician cannot take an appointment from start to
+// end, or nil when they are free. The appointment must fit in a single shift;
+// a technician without any working hours set can be booked at any time.
+func (cal Calendar) Check(start, end time.Time) error {
+	if !cal.withinHours(start, end) {
+		return ErrOutsideWorkingHours
+	}
+	for _, off := range cal.TimeOff {
+		if overlaps(start, end, off.StartsAt, off.EndsAt) {
+			return fmt.Errorf("%w from %s to %s", ErrTimeOff,
+				off.StartsAt.Format(time.RFC3339), off.EndsAt.Format(time.RFC3339))
+		}
+	}
+
+	var conflicts []models.Maintenance
+	for _, appointment := range cal.Appointments {
+		bookedStart, bookedEnd, ok := Appointment(&appointment)
+		if ok && overlaps(start, end, bookedStart, bookedEnd) {
+			conflicts = append(conflicts, appointment)
+		}
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+func (cal Calendar) withinHours(start, end time.Time) bool {
+	if len(cal.Hours) == 0 {
+		return true
+	}
+	local := start.In(location)
+	from := local.Hour()*60 + local.Minute()
+	to := from + int(end.Sub(start).Round(time.Minute)/time.Minute)
+	for _, shift := range cal.Hours {
+		shiftStart, shiftEnd := shift.Minutes()
+		if shift.Weekday == int(local.Weekday()) && shiftStart <= from && to <= shiftEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether [aStart, aEnd) and [bStart, bEnd) share any time
+func overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
@@ -0,0 +1,86 @@
+package servicing
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/models"
+)
+
+// Target is the time allowed to acknowledge and to resolve a request
+type Target struct {
+	Acknowledge time.Duration
+	Resolve     time.Duration
+}
+
+// Policy maps each priority to its SLA target
+type Policy map[string]Target
+
+// DefaultPolicy matches the defaults in config.LoadConfig
+func DefaultPolicy() Policy {
+	return Policy{
+		"urgent": {Acknowledge: time.Hour, Resolve: 24 * time.Hour},
+		"high":   {Acknowledge: 4 * time.Hour, Resolve: 72 * time.Hour},
+		"medium": {Acknowledge: 24 * time.Hour, Resolve: 7 * 24 * time.Hour},
+		"low":    {Acknowledge: 72 * time.Hour, Resolve: 14 * 24 * time.Hour},
+	}
+}
+
+// PolicyFromConfig builds a policy from the configured targets, falling back
+// to the defaults for any priority left out
+func PolicyFromConfig(cfg config.MaintenanceSLAConfig) Policy {
+	policy := DefaultPolicy()
+	for priority, target := range policy {
+		if d, ok := cfg.Acknowledge[priority]; ok && d > 0 {
+			target.Acknowledge = d
+		}
+		if d, ok := cfg.Resolve[priority]; ok && d > 0 {
+			target.Resolve = d
+		}
+		policy[priority] = target
+	}
+	return policy
+}
+
+var policy = DefaultPolicy()
+
+// Init applies the configured SLA targets and scheduling settings
+func Init(cfg *config.Config) {
+	policy = PolicyFromConfig(cfg.MaintenanceSLA)
+	initSchedule(cfg.Scheduling)
+}
+
+// TargetFor returns the SLA target for a priority. Requests saved without a
+// priority get the database default, medium.
+func TargetFor(priority string) Target {
+	if target, ok := policy[priority]; ok {
+		return target
+	}
+	return policy["medium"]
+}
+
+// StartClock sets the acknowledgement and resolution deadlines from the
+// request's priority, counting from the given time
+func StartClock(m *models.Maintenance, from time.Time) {
+	target := TargetFor(m.Priority)
+	acknowledgeBy := from.Add(target.Acknowledge)
+	resolveBy := from.Add(target.Resolve)
+	m.AcknowledgeDueAt = &acknowledgeBy
+	m.ResolveDueAt = &resolveBy
+}
+
+// priorities is the escalation ladder, lowest first
+var priorities = []string{"low", "medium", "high", "urgent"}
+
+// NextPriority returns the priority one step above p; urgent stays urgent
+func NextPriority(p string) string {
+	for i, priority := range priorities {
+		if priority == p && i+1 < len(priorities) {
+			return priorities[i+1]
+		}
+	}
+	if p == "" {
+		return "high"
+	}
+	return "urgent"
+}
@@ -0,0 +1,13 @@
+package servicing
+
+import (
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/webhooks"
+	"gorm.io/gorm"
+)
+
+// PublishMaintenance queues a maintenance event for the webhook endpoints
+// subscribed to it
+func PublishMaintenance(tx *gorm.DB, eventType string, m *models.Maintenance) error {
+	return webhooks.Publish(tx, eventType, m.PropertyID, webhooks.MaintenanceData(*m))
+}
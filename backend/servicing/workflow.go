@@ -0,0 +1,99 @@
+package servicing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidTransition is returned when a request cannot move to the requested status
+	ErrInvalidTransition = errors.New("invalid maintenance status transition")
+	// ErrAssigneeRequired is returned when a request is assigned to nobody
+	ErrAssigneeRequired = errors.New("an assignee is required")
+)
+
+// transitions lists the statuses each maintenance status may move to.
+// Moving a completed or cancelled request back to pending reopens it.
+var transitions = map[string][]string{
+	"pending":     {"assigned", "cancelled"},
+	"assigned":    {"in_progress", "pending", "cancelled"},
+	"in_progress": {"completed", "cancelled"},
+	"completed":   {"pending"},
+	"cancelled":   {"pending"},
+}
+
+// CanTransition reports whether a request may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves a request to a new status and applies the side effects of
+// that step to m. The caller saves the request.
+func Transition(m *models.Maintenance, to string, now time.Time) error {
+	if !CanTransition(m.Status, to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, m.Status, to)
+	}
+
+	switch to {
+	case "assigned":
+		if m.AssignedToID == nil {
+			return ErrAssigneeRequired
+		}
+		acknowledge(m, now)
+	case "in_progress":
+		acknowledge(m, now)
+	case "completed":
+		if m.CompletedAt == nil {
+			m.CompletedAt = &now
+		}
+	case "pending":
+		m.AssignedToID = nil
+		if m.Status == "completed" || m.Status == "cancelled" {
+			reopen(m, now)
+		}
+	}
+
+	m.Status = to
+	return nil
+}
+
+// acknowledge stops the acknowledgement clock the first time staff pick a
+// request up
+func acknowledge(m *models.Maintenance, now time.Time) {
+	if m.AcknowledgedAt == nil {
+		m.AcknowledgedAt = &now
+	}
+}
+
+// reopen treats a closed request as new: its SLA clocks restart from now
+func reopen(m *models.Maintenance, now time.Time) {
+	m.CompletedAt = nil
+	m.AcknowledgedAt = nil
+	m.EscalationLevel = 0
+	m.EscalatedAt = nil
+	StartClock(m, now)
+}
+
+// RecordStatusChange appends an entry to a request's status history. from is
+// empty for the entry recording creation; changedByID is nil for jobs.
+func RecordStatusChange(tx *gorm.DB, maintenanceID uint, from, to string, changedByID *uint) error {
+	change := models.MaintenanceStatusChange{
+		MaintenanceID: maintenanceID,
+		FromStatus:    from,
+		ToStatus:      to,
+		ChangedByID:   changedByID,
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+	return nil
+}
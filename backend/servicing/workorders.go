@@ -0,0 +1,175 @@
+package servicing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrWorkOrderClosed is returned when quoting on or approving a work order that is no longer requested
+	ErrWorkOrderClosed = errors.New("work order is no longer collecting quotes")
+	// ErrWorkOrderNotApproved is returned when invoicing a work order without an approved quote
+	ErrWorkOrderNotApproved = errors.New("work order has no approved quote")
+	// ErrVendorInactive is returned when quoting with a deactivated vendor
+	ErrVendorInactive = errors.New("vendor is inactive")
+	// ErrVendorUninsured is returned when approving a quote from a vendor whose insurance has lapsed
+	ErrVendorUninsured = errors.New("vendor insurance has expired or is not on file")
+	// ErrQuoteExpired is returned when approving a quote past its valid_until date
+	ErrQuoteExpired = errors.New("quote has expired")
+)
+
+// AddQuote records a vendor's quote on an open work order
+func AddQuote(tx *gorm.DB, order *models.WorkOrder, req models.QuoteCreateRequest, createdByID uint) (*models.WorkOrderQuote, error) {
+	if !order.IsOpen() {
+		return nil, ErrWorkOrderClosed
+	}
+	var vendor models.Vendor
+	if err := tx.Where("deleted_at IS NULL").First(&vendor, req.VendorID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load vendor: %w", err)
+	}
+	if !vendor.IsActive {
+		return nil, ErrVendorInactive
+	}
+
+	quote := models.WorkOrderQuote{
+		WorkOrderID: order.ID,
+		VendorID:    vendor.ID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		ValidUntil:  req.ValidUntil,
+		Status:      "submitted",
+		CreatedByID: createdByID,
+	}
+	if err := tx.Create(&quote).Error; err != nil {
+		return nil, fmt.Errorf("failed to save quote: %w", err)
+	}
+	quote.Vendor = vendor
+	return &quote, nil
+}
+
+// ApproveQuote accepts one quote, rejects the competing ones and assigns the
+// quote's vendor to the work order. The vendor must be insured and the quote
+// still valid.
+func ApproveQuote(tx *gorm.DB, order *models.WorkOrder, quoteID, approvedByID uint, now time.Time) error {
+	if err := lockWorkOrder(tx, order); err != nil {
+		return err
+	}
+	if !order.IsOpen() {
+		return ErrWorkOrderClosed
+	}
+
+	var quote models.WorkOrderQuote
+	if err := tx.Preload("Vendor").Where("work_order_id = ?", order.ID).First(&quote, quoteID).Error; err != nil {
+		return fmt.Errorf("failed to load quote: %w", err)
+	}
+	if err := CheckQuote(quote, now); err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.WorkOrderQuote{}).Where("work_order_id = ? AND id <> ?", order.ID, quote.ID).
+		Update("status", "rejected").Error; err != nil {
+		return fmt.Errorf("failed to reject competing quotes: %w", err)
+	}
+	if err := tx.Model(&quote).Update("status", "accepted").Error; err != nil {
+		return fmt.Errorf("failed to accept quote: %w", err)
+	}
+
+	order.Status = "approved"
+	order.VendorID = &quote.VendorID
+	order.ApprovedQuoteID = &quote.ID
+	order.ApprovedByID = &approvedByID
+	order.ApprovedAt = &now
+	if err := tx.Save(order).Error; err != nil {
+		return fmt.Errorf("failed to approve work order: %w", err)
+	}
+	return nil
+}
+
+// CheckQuote returns why a quote cannot be approved at now, or nil
+func CheckQuote(quote models.WorkOrderQuote, now time.Time) error {
+	if quote.ValidUntil != nil && quote.ValidUntil.Before(now) {
+		return ErrQuoteExpired
+	}
+	if !quote.Vendor.IsActive {
+		return ErrVendorInactive
+	}
+	if !quote.Vendor.IsInsured(now) {
+		return ErrVendorUninsured
+	}
+	return nil
+}
+
+// InvoiceWorkOrder books the approved vendor's final invoice as a repairs
+// expense against the property and links it to the work order. If the
+// request is already completed, its own cost expense is reduced so the
+// vendor's share is not counted twice.
+func InvoiceWorkOrder(tx *gorm.DB, order *models.WorkOrder, req models.VendorInvoiceRequest, createdByID uint, now time.Time) (*models.Expense, error) {
+	if err := lockWorkOrder(tx, order); err != nil {
+		return nil, err
+	}
+	if order.Status != "approved" || order.VendorID == nil {
+		return nil, ErrWorkOrderNotApproved
+	}
+
+	var vendor models.Vendor
+	if err := tx.First(&vendor, *order.VendorID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load vendor: %w", err)
+	}
+	var maintenance models.Maintenance
+	if err := tx.First(&maintenance, order.MaintenanceID).Error; err != nil {
+		return nil, fmt.Errorf("failed to load maintenance request: %w", err)
+	}
+
+	notes := req.Notes
+	if req.Reference != "" {
+		notes = fmt.Sprintf("Vendor invoice %s. %s", req.Reference, req.Notes)
+	}
+	expense := models.Expense{
+		PropertyID:    order.PropertyID,
+		CreatedByID:   createdByID,
+		ExpenseNumber: billing.NewExpenseNumber(),
+		Description:   fmt.Sprintf("Work order #%d: %s", order.ID, maintenance.Title),
+		Category:      "repairs",
+		Amount:        req.Amount,
+		ExpenseDate:   req.InvoiceDate,
+		VendorName:    vendor.Name,
+		VendorEmail:   vendor.Email,
+		VendorPhone:   vendor.Phone,
+		VendorID:      &vendor.ID,
+		MaintenanceID: &maintenance.ID,
+		Source:        "work_order",
+		PaymentMethod: req.PaymentMethod,
+		Notes:         notes,
+	}
+	if err := tx.Create(&expense).Error; err != nil {
+		return nil, fmt.Errorf("failed to book vendor invoice: %w", err)
+	}
+	if maintenance.Status == "completed" {
+		if _, err := postExpense(tx, &maintenance, createdByID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	order.Status = "invoiced"
+	order.ExpenseID = &expense.ID
+	order.InvoicedAt = &now
+	if err := tx.Save(order).Error; err != nil {
+		return nil, fmt.Errorf("failed to update work order: %w", err)
+	}
+	return &expense, nil
+}
+
+// lockWorkOrder reloads the work order under a row lock so concurrent
+// approvals and invoices see each other's changes
+func lockWorkOrder(tx *gorm.DB, order *models.WorkOrder) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(order, order.ID).Error; err != nil {
+		return fmt.Errorf("failed to lock work order: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+// Package snapshot captures the rows a write touches, once per statement,
+// for the subscribers that act on them: the audit trail and the domain
+// events. Rows are read before an update or delete and after a create or
+// update, inside the transaction of the change, and every subscriber sees
+// the same copy.
+package snapshot
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Actions reported on a Change
+const (
+	Create = "create"
+	Update = "update"
+	Delete = "delete"
+)
+
+// beforeKey holds the rows captured before an update or delete
+const beforeKey = "snapshot:before"
+
+// Row is a database row keyed by column name
+type Row map[string]interface{}
+
+// Change is one statement's write to a table. Before holds the rows as they
+// were for updates and deletes, After the rows as written for creates and
+// updates, both keyed by primary key.
+type Change struct {
+	Action string
+	Table  string
+	Before map[uint]Row
+	After  map[uint]Row
+}
+
+// IDs returns the primary keys the change touched, in ascending order
+func (c Change) IDs() []uint {
+	rows := c.After
+	if c.Action == Delete {
+		rows = c.Before
+	}
+	ids := make([]uint, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Subscriber is told about writes to its tables. Handle runs in the
+// transaction of the change; an error rolls the change back.
+type Subscriber struct {
+	Name   string
+	Tables []string
+	Handle func(tx *gorm.DB, change Change) error
+}
+
+var (
+	mu          sync.RWMutex
+	subscribers = make(map[string]Subscriber)
+	installed   = make(map[*gorm.Config]bool)
+)
+
+// Register installs the capture callbacks on db, once, and subscribes s to
+// the changes of its tables. Registering a subscriber again under the same
+// name replaces it.
+func Register(db *gorm.DB, s Subscriber) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !installed[db.Config] {
+		callbacks := []struct {
+			name string
+			err  error
+		}{
+			{"snapshot:after_create", db.Callback().Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").
+				Register("snapshot:after_create", afterCreate)},
+			{"snapshot:before_update", db.Callback().Update().After("gorm:begin_transaction").Before("gorm:update").
+				Register("snapshot:before_update", captureBefore)},
+			{"snapshot:after_update", db.Callback().Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").
+				Register("snapshot:after_update", afterUpdate)},
+			{"snapshot:before_delete", db.Callback().Delete().After("gorm:begin_transaction").Before("gorm:delete").
+				Register("snapshot:before_delete", captureBefore)},
+			{"snapshot:after_delete", db.Callback().Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").
+				Register("snapshot:after_delete", afterDelete)},
+		}
+		for _, cb := range callbacks {
+			if cb.err != nil {
+				return fmt.Errorf("failed to register %s callback: %w", cb.name, cb.err)
+			}
+		}
+		installed[db.Config] = true
+	}
+
+	subscribers[s.Name] = s
+	return nil
+}
+
+// Session is a fresh statement on the same connection, so a subscriber's
+// reads and writes join the transaction of the change
+func Session(tx *gorm.DB) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+}
+
+// interested returns the subscribers to a table, in name order
+func interested(table string) []Subscriber {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var matched []Subscriber
+	for _, s := range subscribers {
+		for _, t := range s.Tables {
+			if t == table {
+				matched = append(matched, s)
+				break
+			}
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+// watched returns the subscribers to the statement's table, or none when
+// the statement cannot be captured
+func watched(tx *gorm.DB) []Subscriber {
+	if tx.Error != nil || tx.DryRun || tx.Statement.Schema == nil || tx.Statement.Schema.PrioritizedPrimaryField == nil {
+		return nil
+	}
+	return interested(tx.Statement.Table)
+}
+
+func notify(tx *gorm.DB, subs []Subscriber, change Change) {
+	for _, s := range subs {
+		if err := s.Handle(tx, change); err != nil {
+			tx.AddError(fmt.Errorf("%s: %w", s.Name, err))
+			return
+		}
+	}
+}
+
+func afterCreate(tx *gorm.DB) {
+	// Saving a record with preloaded belongs-to associations upserts them with
+	// ON CONFLICT DO NOTHING, which runs these callbacks without inserting
+	subs := watched(tx)
+	if len(subs) == 0 || tx.RowsAffected == 0 {
+		return
+	}
+	after, err := loadRows(tx, modelIDs(tx))
+	if err != nil {
+		tx.AddError(fmt.Errorf("snapshot: %w", err))
+		return
+	}
+	notify(tx, subs, Change{Action: Create, Table: tx.Statement.Table, After: after})
+}
+
+// captureBefore snapshots the rows an update or delete is about to touch
+func captureBefore(tx *gorm.DB) {
+	if len(watched(tx)) == 0 {
+		return
+	}
+	ids, err := statementIDs(tx)
+	if err != nil {
+		tx.AddError(fmt.Errorf("snapshot: %w", err))
+		return
+	}
+	rows, err := loadRows(tx, ids)
+	if err != nil {
+		tx.AddError(fmt.Errorf("snapshot: %w", err))
+		return
+	}
+	tx.InstanceSet(beforeKey, rows)
+}
+
+func afterUpdate(tx *gorm.DB) {
+	subs := watched(tx)
+	if len(subs) == 0 || tx.RowsAffected == 0 {
+		return
+	}
+	before := captured(tx)
+	if len(before) == 0 {
+		return
+	}
+	ids := make([]interface{}, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+	after, err := loadRows(tx, ids)
+	if err != nil {
+		tx.AddError(fmt.Errorf("snapshot: %w", err))
+		return
+	}
+	notify(tx, subs, Change{Action: Update, Table: tx.Statement.Table, Before: before, After: after})
+}
+
+func afterDelete(tx *gorm.DB) {
+	subs := watched(tx)
+	if len(subs) == 0 || tx.RowsAffected == 0 {
+		return
+	}
+	notify(tx, subs, Change{Action: Delete, Table: tx.Statement.Table, Before: captured(tx)})
+}
+
+func captured(tx *gorm.DB) map[uint]Row {
+	value, ok := tx.InstanceGet(beforeKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := value.(map[uint]Row)
+	return rows
+}
+
+// modelIDs returns the primary keys set on the statement's model
+func modelIDs(tx *gorm.DB) []interface{} {
+	field := tx.Statement.Schema.PrioritizedPrimaryField
+	ctx := tx.Statement.Context
+	value := tx.Statement.ReflectValue
+
+	var ids []interface{}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if id, zero := field.ValueOf(ctx, reflect.Indirect(value.Index(i))); !zero {
+				ids = append(ids, id)
+			}
+		}
+	case reflect.Struct:
+		if id, zero := field.ValueOf(ctx, value); !zero {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// statementIDs returns the primary keys of the rows an update or delete is
+// about to touch: those set on its model, or else those its conditions match
+func statementIDs(tx *gorm.DB) ([]interface{}, error) {
+	if ids := modelIDs(tx); len(ids) > 0 {
+		return ids, nil
+	}
+	return whereIDs(tx)
+}
+
+// whereIDs resolves the primary keys matched by the statement's conditions
+func whereIDs(tx *gorm.DB) ([]interface{}, error) {
+	where, ok := tx.Statement.Clauses["WHERE"]
+	if !ok {
+		return nil, nil
+	}
+	// A fresh model lets gorm resolve primary key placeholders in the conditions
+	model := reflect.New(tx.Statement.Schema.ModelType).Interface()
+	var ids []uint
+	if err := Session(tx).Model(model).Clauses(where.Expression).
+		Pluck(tx.Statement.Schema.PrioritizedPrimaryField.DBName, &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to resolve changed rows: %w", err)
+	}
+	result := make([]interface{}, len(ids))
+	for i, id := range ids {
+		result[i] = id
+	}
+	return result, nil
+}
+
+// loadRows reads rows by primary key, keyed by id
+func loadRows(tx *gorm.DB, ids []interface{}) (map[uint]Row, error) {
+	rows := make(map[uint]Row)
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	pk := tx.Statement.Schema.PrioritizedPrimaryField.DBName
+
+	var records []map[string]interface{}
+	if err := Session(tx).Table(tx.Statement.Table).Where(pk+" IN ?", ids).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to snapshot %s: %w", tx.Statement.Table, err)
+	}
+	for _, record := range records {
+		if id, ok := toUint(record[pk]); ok {
+			rows[id] = record
+		}
+	}
+	return rows, nil
+}
+
+func toUint(value interface{}) (uint, bool) {
+	switch v := value.(type) {
+	case int64:
+		return uint(v), true
+	case int32:
+		return uint(v), true
+	case int:
+		return uint(v), true
+	case uint:
+		return v, true
+	case uint64:
+		return uint(v), true
+	}
+	return 0, false
+}
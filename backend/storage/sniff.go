@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// allowedTypes are the content types accepted for upload, with the file
+// extension they are stored under
+var allowedTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// SniffContentType detects the type of a file from its first bytes, ignoring
+// whatever type the client claimed. It reports false for types that may not
+// be uploaded.
+func SniffContentType(head []byte) (string, bool) {
+	contentType := http.DetectContentType(head)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	_, ok := allowedTypes[contentType]
+	return contentType, ok
+}
+
+// Extension returns the extension files of an allowed type are stored under
+func Extension(contentType string) string {
+	return allowedTypes[contentType]
+}
+
+// CleanFileName strips any directory part from a client-supplied file name
+func CleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/audit"
+)
+
+func TestAuditDiffReportsChangedFieldsOnly(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := audit.Row{
+		"id":         uint(7),
+		"status":     "pending",
+		"amount":     1200.0,
+		"password":   "old-hash",
+		"created_at": created,
+		"updated_at": created,
+	}
+	after := audit.Row{
+		"id":         uint(7),
+		"status":     "paid",
+		"amount":     1200.0,
+		"password":   "new-hash",
+		"created_at": created.In(time.FixedZone("BST", 3600)),
+		"updated_at": created.Add(time.Hour),
+	}
+
+	oldValues, newValues, fields := audit.Diff(before, after)
+
+	if want := []string{"password", "status"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("changed fields = %v, want %v", fields, want)
+	}
+	if oldValues["status"] != "pending" || newValues["status"] != "paid" {
+		t.Errorf("status diff = %v -> %v, want pending -> paid", oldValues["status"], newValues["status"])
+	}
+	if oldValues["password"] == "old-hash" || newValues["password"] == "new-hash" {
+		t.Error("password change must be redacted")
+	}
+}
+
+func TestAuditRedactHidesSensitiveColumns(t *testing.T) {
+	row := audit.Redact(audit.Row{"email": "a@example.com", "password": "hash"})
+	if row["password"] == "hash" {
+		t.Error("Redact kept the password")
+	}
+	if row["email"] != "a@example.com" {
+		t.Errorf("Redact changed email to %v", row["email"])
+	}
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/audit"
+	"github.com/geoo115/property-manager/models"
+)
+
+func TestAuditTimelineIsChronologicalAndFieldLevel(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	logs := []models.AuditLog{
+		{ID: 3, Action: "UPDATE", CreatedAt: start.Add(2 * time.Hour),
+			OldData: `{"status":"active"}`, NewData: `{"status":"terminated"}`},
+		{ID: 1, Action: "CREATE", CreatedAt: start,
+			NewData: `{"id":4,"status":"pending"}`},
+		{ID: 2, Action: "UPDATE", CreatedAt: start.Add(time.Hour),
+			OldData: `{"status":"pending","monthly_rent":1200}`, NewData: `{"status":"active","monthly_rent":1250}`},
+		{ID: 4, Action: "CREATE", CreatedAt: start.Add(3 * time.Hour),
+			NewData: "Maintenance request created: leaking tap", Description: "legacy entry"},
+	}
+
+	timeline := audit.Timeline(logs)
+	if len(timeline) != 4 {
+		t.Fatalf("got %d entries, want 4", len(timeline))
+	}
+	for i, want := range []uint{1, 2, 3, 4} {
+		if timeline[i].ID != want {
+			t.Errorf("entry %d has ID %d, want %d", i, timeline[i].ID, want)
+		}
+	}
+
+	created := timeline[0].Changes
+	if len(created) != 2 || created[0].Field != "id" || created[0].Old != nil {
+		t.Errorf("create changes = %+v, want every field with no old value", created)
+	}
+
+	updated := timeline[1].Changes
+	if len(updated) != 2 || updated[0].Field != "monthly_rent" || updated[1].Field != "status" {
+		t.Fatalf("update changes = %+v, want monthly_rent and status", updated)
+	}
+	if updated[1].Old != "pending" || updated[1].New != "active" {
+		t.Errorf("status change = %v -> %v, want pending -> active", updated[1].Old, updated[1].New)
+	}
+
+	if len(timeline[3].Changes) != 0 || timeline[3].Description != "legacy entry" {
+		t.Errorf("legacy entry = %+v, want description only", timeline[3])
+	}
+}
@@ -11,9 +11,12 @@ import (
 	"github.com/geoo115/property-manager/api/auth"
 	"github.com/geoo115/property-manager/config"
 	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/logger"
 	"github.com/geoo115/property-manager/models"
 	"github.com/geoo115/property-manager/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 func TestMain(m *testing.M) {
@@ -32,6 +35,9 @@ func TestMain(m *testing.M) {
 		}
 	}
 
+	// Log to stderr rather than the rotating log file
+	logger.Log = logrus.New()
+
 	// Initialize the database before running tests.
 	db.Init(cfg) // Pass config to init function
 
@@ -42,6 +48,36 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// requireDatabase skips a test that needs the database when it cannot be reached
+func requireDatabase(t *testing.T) {
+	t.Helper()
+	if err := db.HealthCheck(); err != nil {
+		t.Skipf("database not available: %v", err)
+	}
+}
+
+// seedProperty creates a landlord, a tenant and a property owned by the
+// landlord inside tx, for tests that roll their writes back
+func seedProperty(t *testing.T, tx *gorm.DB) (owner, tenant models.User, property models.Property) {
+	t.Helper()
+	name := randomUsername("seed")
+	owner = models.User{Username: name + "-owner", FirstName: "Olive", LastName: "Owner", Password: "x",
+		Email: randomEmail(name + "-owner"), Role: "landlord", Phone: randomPhone()}
+	tenant = models.User{Username: name + "-tenant", FirstName: "Tom", LastName: "Tenant", Password: "x",
+		Email: randomEmail(name + "-tenant"), Role: "tenant", Phone: randomPhone()}
+	for _, u := range []*models.User{&owner, &tenant} {
+		if err := tx.Create(u).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	property = models.Property{Name: "Test House", Bedrooms: 2, Bathrooms: 1, Price: 1200,
+		Address: "1 High Street", City: "Leeds", OwnerID: owner.ID}
+	if err := tx.Create(&property).Error; err != nil {
+		t.Fatal(err)
+	}
+	return owner, tenant, property
+}
+
 // getTestContext returns a Gin context and a ResponseRecorder for testing.
 func getTestContext(method, url string, body []byte) (*gin.Context, *httptest.ResponseRecorder) {
 	w := httptest.NewRecorder()
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+)
+
+func TestPeriodDateClampsToMonthEnd(t *testing.T) {
+	anchor := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		interval string
+		n        int
+		expected time.Time
+	}{
+		{"Monthly into leap February", "monthly", 1, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"Monthly into April", "monthly", 3, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{"Quarterly", "quarterly", 1, time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)},
+		{"Yearly", "yearly", 1, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := billing.PeriodDate(anchor, tt.interval, tt.n)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected.Format("2006-01-02"), got.Format("2006-01-02"))
+			}
+		})
+	}
+
+	if _, err := billing.PeriodDate(anchor, "weekly", 1); err == nil {
+		t.Error("Expected error for unsupported interval")
+	}
+}
+
+func TestRecurringInvoiceNumberIsDeterministic(t *testing.T) {
+	period := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	first := billing.RecurringInvoiceNumber(42, period)
+	second := billing.RecurringInvoiceNumber(42, period)
+	if first != second {
+		t.Errorf("Expected identical numbers for the same period, got %s and %s", first, second)
+	}
+	if other := billing.RecurringInvoiceNumber(42, period.AddDate(0, 1, 0)); other == first {
+		t.Errorf("Expected a different number for the next period, got %s", other)
+	}
+}
+
+func TestRentScheduleProratesPartialMonths(t *testing.T) {
+	lease := models.Lease{
+		StartDate:   time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC),
+		MonthlyRent: 1550.00,
+	}
+
+	periods := billing.RentSchedule(lease)
+	if len(periods) != 4 {
+		t.Fatalf("Expected 4 periods, got %d", len(periods))
+	}
+
+	expected := []struct {
+		start    string
+		end      string
+		amount   float64
+		prorated bool
+	}{
+		{"2025-01-16", "2025-01-31", 800.00, true},
+		{"2025-02-01", "2025-02-28", 1550.00, false},
+		{"2025-03-01", "2025-03-31", 1550.00, false},
+		{"2025-04-01", "2025-04-15", 775.00, true},
+	}
+
+	for i, want := range expected {
+		got := periods[i]
+		if got.Start.Format("2006-01-02") != want.start || got.End.Format("2006-01-02") != want.end {
+			t.Errorf("Period %d: expected %s..%s, got %s..%s", i, want.start, want.end,
+				got.Start.Format("2006-01-02"), got.End.Format("2006-01-02"))
+		}
+		if got.Amount != want.amount {
+			t.Errorf("Period %d: expected amount %.2f, got %.2f", i, want.amount, got.Amount)
+		}
+		if got.Prorated != want.prorated {
+			t.Errorf("Period %d: expected prorated=%v, got %v", i, want.prorated, got.Prorated)
+		}
+	}
+}
+
+func TestPaymentStatusForDerivesFromLedgerTotals(t *testing.T) {
+	now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+	future := now.AddDate(0, 0, 7)
+	past := now.AddDate(0, 0, -7)
+
+	tests := []struct {
+		name     string
+		invoice  models.Invoice
+		expected string
+	}{
+		{"Unpaid before due date", models.Invoice{Amount: 100, DueDate: future, PaymentStatus: "pending"}, "pending"},
+		{"Partially paid after due date", models.Invoice{Amount: 100, PaidAmount: 40, DueDate: past, PaymentStatus: "pending"}, "overdue"},
+		{"Fully paid", models.Invoice{Amount: 100, PaidAmount: 100, DueDate: past, PaymentStatus: "overdue"}, "paid"},
+		{"Paid then partly refunded", models.Invoice{Amount: 100, PaidAmount: 100, RefundedAmount: 30, DueDate: future, PaymentStatus: "paid"}, "pending"},
+		{"Cancelled stays cancelled", models.Invoice{Amount: 100, PaidAmount: 100, DueDate: past, PaymentStatus: "cancelled"}, "cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := billing.PaymentStatusFor(tt.invoice, now); got != tt.expected {
+				t.Errorf("PaymentStatusFor() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRecordTenantPaymentRejectsOverpayment(t *testing.T) {
+	requireDatabase(t)
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	owner, tenant, property := seedProperty(t, tx)
+	now := time.Now()
+	invoices := []models.Invoice{
+		{Amount: 50, DueDate: now.AddDate(0, 0, 14)},
+		{Amount: 100, DueDate: now.AddDate(0, 0, 7)},
+	}
+	for i := range invoices {
+		invoices[i].InvoiceNumber = billing.NewInvoiceNumber()
+		invoices[i].TenantID, invoices[i].PropertyID, invoices[i].CreatedByID = tenant.ID, property.ID, owner.ID
+		invoices[i].InvoiceDate, invoices[i].Category, invoices[i].PaymentStatus = now, "rent", "pending"
+		if err := tx.Create(&invoices[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	input := billing.PaymentInput{TenantID: tenant.ID, RecordedByID: owner.ID, Amount: 150.01, Method: "cash"}
+	if _, err := billing.RecordTenantPayment(tx, input); !errors.Is(err, billing.ErrOverpayment) {
+		t.Fatalf("expected ErrOverpayment for more than the tenant owes, got %v", err)
+	}
+
+	// The earliest due invoice is settled first
+	input.Amount = 120
+	payment, err := billing.RecordTenantPayment(tx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payment.Allocations) != 2 || payment.Allocations[0].InvoiceID != invoices[1].ID ||
+		payment.Allocations[0].Amount != 100 || payment.Allocations[1].Amount != 20 {
+		t.Errorf("expected 100 then 20 allocated oldest due first, got %+v", payment.Allocations)
+	}
+
+	input.Amount = 30.01
+	if _, err := billing.RecordTenantPayment(tx, input); !errors.Is(err, billing.ErrOverpayment) {
+		t.Errorf("expected ErrOverpayment for more than the 30 left, got %v", err)
+	}
+	input.Amount = 30
+	if _, err := billing.RecordTenantPayment(tx, input); err != nil {
+		t.Errorf("expected the exact balance to be accepted, got %v", err)
+	}
+}
+
+func TestLateFeeAmountAppliesPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   models.LateFeePolicy
+		balance  float64
+		expected float64
+	}{
+		{"Flat fee only", models.LateFeePolicy{Enabled: true, FlatFee: 25}, 1000, 25},
+		{"Flat plus percentage", models.LateFeePolicy{Enabled: true, FlatFee: 10, Percentage: 5}, 500, 35},
+		{"Capped", models.LateFeePolicy{Enabled: true, Percentage: 10, MaxFee: 50}, 1200, 50},
+		{"Disabled policy", models.LateFeePolicy{Enabled: false, FlatFee: 25}, 1000, 0},
+		{"Nothing outstanding", models.LateFeePolicy{Enabled: true, FlatFee: 25}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := billing.LateFeeAmount(tt.policy, tt.balance); got != tt.expected {
+				t.Errorf("LateFeeAmount() = %.2f, want %.2f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLateFeeDueRespectsGraceDays(t *testing.T) {
+	policy := models.LateFeePolicy{Enabled: true, FlatFee: 25, GraceDays: 3}
+	invoice := models.Invoice{DueDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+
+	if billing.LateFeeDue(policy, invoice, time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Error("expected no late fee on the last grace day")
+	}
+	if !billing.LateFeeDue(policy, invoice, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Error("expected a late fee the day after the grace period")
+	}
+}
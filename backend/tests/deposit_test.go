@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/geoo115/property-manager/models"
+)
+
+func TestDepositAmountToReturnSubtractsDeductions(t *testing.T) {
+	deposit := models.Deposit{
+		ReceivedAmount: 1500,
+		Status:         "held",
+		Deductions: []models.DepositDeduction{
+			{Category: "cleaning", Amount: 120.50},
+			{Category: "damage", Amount: 300.25},
+		},
+	}
+
+	if got := deposit.TotalDeductions(); got != 420.75 {
+		t.Errorf("TotalDeductions() = %v, want 420.75", got)
+	}
+	if got := deposit.AmountToReturn(); got != 1079.25 {
+		t.Errorf("AmountToReturn() = %v, want 1079.25", got)
+	}
+
+	deposit.Deductions = append(deposit.Deductions, models.DepositDeduction{Category: "rent_arrears", Amount: 2000})
+	if got := deposit.AmountToReturn(); got != 0 {
+		t.Errorf("AmountToReturn() with deductions above the deposit = %v, want 0", got)
+	}
+}
+
+func TestDepositDeductionsOnlyWhileHeld(t *testing.T) {
+	for status, want := range map[string]bool{
+		"awaiting":            false,
+		"held":                true,
+		"settlement_proposed": true,
+		"disputed":            true,
+		"returned":            false,
+	} {
+		deposit := models.Deposit{Status: status}
+		if got := deposit.CanDeduct(); got != want {
+			t.Errorf("CanDeduct() for %s = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/events"
+	"github.com/geoo115/property-manager/middleware"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+func TestEventCatalog(t *testing.T) {
+	catalog := events.Catalog()
+	if len(catalog) != len(events.Aggregates)*3 {
+		t.Fatalf("expected created, updated and deleted for every aggregate, got %d event types", len(catalog))
+	}
+	for _, eventType := range []string{
+		events.LeaseCreated, events.InvoiceUpdated, events.PaymentDeleted,
+		events.PropertyCreated, events.UserUpdated, events.MaintenanceCreated,
+	} {
+		if _, ok := events.Lookup(eventType); !ok {
+			t.Errorf("expected %s in the catalogue", eventType)
+		}
+	}
+	if _, ok := events.Lookup("lease.archived"); ok {
+		t.Error("expected an unknown event type not to be found")
+	}
+
+	// Every payload field is described by the schema of its version, and the
+	// schema describes nothing else
+	for _, aggregate := range events.Aggregates {
+		raw, err := events.Schema(aggregate.Name, aggregate.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var schema struct {
+			Type       string                     `json:"type"`
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			t.Fatalf("%s schema is not valid JSON: %v", aggregate.Name, err)
+		}
+
+		var fields []string
+		dataType := aggregate.DataType()
+		for i := 0; i < dataType.NumField(); i++ {
+			name, _, _ := strings.Cut(dataType.Field(i).Tag.Get("json"), ",")
+			fields = append(fields, name)
+		}
+		var properties []string
+		for name := range schema.Properties {
+			properties = append(properties, name)
+		}
+		sort.Strings(fields)
+		sort.Strings(properties)
+		if schema.Type != "object" || strings.Join(fields, ",") != strings.Join(properties, ",") {
+			t.Errorf("%s v%d schema describes %v, payload has %v", aggregate.Name, aggregate.Version, properties, fields)
+		}
+		if len(schema.Required) != len(fields) {
+			t.Errorf("expected every %s field to be required, got %v", aggregate.Name, schema.Required)
+		}
+	}
+
+	if _, err := events.Schema("lease", 99); err == nil {
+		t.Error("expected no schema for an unknown version")
+	}
+}
+
+func TestEventEnvelope(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	lease := events.LeaseV1{ID: 12, TenantID: 4, PropertyID: 7, Status: "active", MonthlyRent: 950}
+
+	envelope, err := events.NewEnvelope(events.LeaseUpdated, lease.ID, lease, "req-1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if envelope.SpecVersion != "1.0" || envelope.Type != events.LeaseUpdated || envelope.Version != 1 ||
+		envelope.Source != events.Source || envelope.Subject != "lease/12" || envelope.CorrelationID != "req-1" ||
+		!envelope.Time.Equal(now) || len(envelope.ID) != 32 {
+		t.Errorf("unexpected envelope %+v", envelope)
+	}
+
+	encoded, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(encoded, &attributes); err != nil {
+		t.Fatal(err)
+	}
+	for _, attribute := range []string{"specversion", "id", "type", "version", "source", "subject", "time", "correlationid", "datacontenttype", "data"} {
+		if _, ok := attributes[attribute]; !ok {
+			t.Errorf("expected the envelope to carry %s, got %s", attribute, encoded)
+		}
+	}
+
+	var decoded events.LeaseV1
+	if err := envelope.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != 12 || decoded.MonthlyRent != 950 || decoded.Status != "active" {
+		t.Errorf("unexpected data %+v", decoded)
+	}
+
+	if _, err := events.NewEnvelope("lease.archived", 12, lease, "", now); err == nil {
+		t.Error("expected an unknown event type to be rejected")
+	}
+
+	// The relay keys each event by its subject and labels it as a CloudEvent
+	event, err := events.NewOutboxEvent("property-events", envelope.Subject, envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.EventType = envelope.Type
+	msg := events.Message(&event)
+	if string(msg.Key) != "lease/12" {
+		t.Errorf("expected the message to be keyed by subject, got %q", msg.Key)
+	}
+	headers := map[string]string{}
+	for _, h := range msg.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	if headers["content-type"] != events.ContentType || headers["ce_type"] != events.LeaseUpdated {
+		t.Errorf("unexpected message headers %v", headers)
+	}
+	if plain := events.Message(&models.OutboxEvent{Topic: "property-events", Payload: "{}"}); len(plain.Headers) != 0 {
+		t.Errorf("expected no headers on a message that is not a domain event, got %v", plain.Headers)
+	}
+}
+
+func TestEventCorrelation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.RequestID())
+	var correlationID string
+	r.GET("/ping", func(c *gin.Context) {
+		correlationID = events.CorrelationID(c)
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(middleware.RequestIDHeader, "client-req.42")
+	r.ServeHTTP(w, req)
+	if w.Header().Get(middleware.RequestIDHeader) != "client-req.42" || correlationID != "client-req.42" {
+		t.Errorf("expected the caller's request ID to be kept, got %q and %q", w.Header().Get(middleware.RequestIDHeader), correlationID)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\nwith newline")
+	r.ServeHTTP(w, req)
+	generated := w.Header().Get(middleware.RequestIDHeader)
+	if len(generated) != 32 || correlationID != generated {
+		t.Errorf("expected an invalid request ID to be replaced, got %q and %q", generated, correlationID)
+	}
+
+	ctx := events.WithCorrelationID(context.Background(), "evt-1")
+	if got := events.CorrelationID(ctx); got != "evt-1" {
+		t.Errorf("expected the correlation ID set on the context, got %q", got)
+	}
+	if got := events.CorrelationID(context.Background()); got != "" {
+		t.Errorf("expected no correlation ID outside a request, got %q", got)
+	}
+}
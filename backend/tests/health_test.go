@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/health"
+	"github.com/gin-gonic/gin"
+)
+
+func up(context.Context) error   { return nil }
+func down(context.Context) error { return errors.New("connection refused") }
+
+func TestHealthSummarize(t *testing.T) {
+	tests := []struct {
+		name    string
+		results map[string]health.Result
+		want    string
+	}{
+		{"all up", map[string]health.Result{
+			"postgres": {Status: health.StatusUp, Critical: true},
+			"redis":    {Status: health.StatusUp},
+		}, health.StatusHealthy},
+		{"redis down", map[string]health.Result{
+			"postgres": {Status: health.StatusUp, Critical: true},
+			"redis":    {Status: health.StatusDown},
+		}, health.StatusDegraded},
+		{"postgres down", map[string]health.Result{
+			"postgres": {Status: health.StatusDown, Critical: true},
+			"redis":    {Status: health.StatusDown},
+		}, health.StatusUnavailable},
+	}
+	for _, tt := range tests {
+		if got := health.Summarize(tt.results, false); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHealthCheckerTimeout(t *testing.T) {
+	checker := health.NewChecker(
+		health.Check{Name: "postgres", Critical: true, Ping: up},
+		health.Check{Name: "kafka", Timeout: 50 * time.Millisecond, Ping: func(ctx context.Context) error {
+			// Ignores the context, like a client stuck in a dial
+			time.Sleep(time.Second)
+			return nil
+		}},
+	)
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("checker waited %s for a hung dependency", elapsed)
+	}
+	if report.Status != health.StatusDegraded || !report.Ready {
+		t.Errorf("expected degraded but ready, got %s ready=%v", report.Status, report.Ready)
+	}
+	if kafka := report.Checks["kafka"]; kafka.Status != health.StatusDown || kafka.Error == "" {
+		t.Errorf("expected kafka to be reported down with an error, got %+v", kafka)
+	}
+}
+
+func TestHealthReadyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/health/live", health.LiveHandler())
+	r.GET("/health/ready", health.ReadyHandler(health.NewChecker(
+		health.Check{Name: "postgres", Critical: true, Ping: down},
+		health.Check{Name: "redis", Ping: up},
+	)))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 with postgres down, got %d", w.Code)
+	}
+	var report health.Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Status != health.StatusUnavailable || report.Checks["postgres"].Error == "" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	// Liveness ignores dependencies
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected live 200, got %d", w.Code)
+	}
+}
+
+func TestHealthShutdownFailsReadiness(t *testing.T) {
+	checker := health.NewChecker(health.Check{Name: "postgres", Critical: true, Ping: up})
+	if report := checker.Run(context.Background()); !report.Ready {
+		t.Fatalf("expected ready before shutdown, got %+v", report)
+	}
+
+	health.MarkShuttingDown()
+	report := checker.Run(context.Background())
+	if report.Ready || report.Status != health.StatusShutdown {
+		t.Errorf("expected readiness to fail during shutdown, got %s ready=%v", report.Status, report.Ready)
+	}
+}
+
+func TestHealthDrainFailsReadinessWhileServing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/health/ready", health.ReadyHandler(health.NewChecker(
+		health.Check{Name: "postgres", Critical: true, Ping: up},
+	)))
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	drained := make(chan struct{})
+	go func() {
+		health.Drain(context.Background(), 300*time.Millisecond)
+		close(drained)
+	}()
+	for !health.ShuttingDown() {
+		time.Sleep(time.Millisecond)
+	}
+
+	resp, err := http.Get(server.URL + "/health/ready")
+	if err != nil {
+		t.Fatalf("expected the server to keep serving while draining, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected readiness to return 503 while draining, got %d", resp.StatusCode)
+	}
+
+	select {
+	case <-drained:
+		t.Error("expected Drain to wait for the delay")
+	default:
+	}
+	select {
+	case <-drained:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Drain did not return after the delay")
+	}
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/leasing"
+	"github.com/geoo115/property-manager/models"
+)
+
+func TestLeaseStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{"pending", "active", true},
+		{"pending", "terminated", true},
+		{"pending", "expired", false},
+		{"active", "expired", true},
+		{"active", "terminated", true},
+		{"active", "pending", false},
+		{"expired", "active", false},
+		{"terminated", "active", false},
+	}
+
+	for _, tt := range tests {
+		if got := leasing.CanTransition(tt.from, tt.to); got != tt.allowed {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.allowed)
+		}
+	}
+}
+
+func TestEffectiveEndDateHonoursNoticePeriod(t *testing.T) {
+	lease := models.Lease{
+		StartDate:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:          time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		NoticePeriodDays: 30,
+	}
+	notice := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		termination leasing.Termination
+		expected    time.Time
+	}{
+		{"Too early is pushed to end of notice", leasing.Termination{NoticeDate: notice, EndDate: notice}, time.Date(2024, 6, 9, 0, 0, 0, 0, time.UTC)},
+		{"Later than notice is kept", leasing.Termination{NoticeDate: notice, EndDate: time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)}, time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)},
+		{"Waived notice", leasing.Termination{NoticeDate: notice, EndDate: notice, WaiveNotice: true}, notice},
+		{"Never past the lease end", leasing.Termination{NoticeDate: notice, EndDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}, lease.EndDate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := leasing.EffectiveEndDate(lease, tt.termination); !got.Equal(tt.expected) {
+				t.Errorf("EffectiveEndDate() = %s, want %s", got.Format("2006-01-02"), tt.expected.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestFreeRangesSkipsBookedDays(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+
+	booked := []leasing.DateRange{
+		{Start: day(3, 1), End: day(5, 31)},
+		{Start: day(1, 10), End: day(1, 20)},
+		{Start: day(5, 15), End: day(6, 30)}, // Overlaps the previous booking
+	}
+
+	free := leasing.FreeRanges(day(1, 1), day(12, 31), booked)
+
+	expected := []leasing.DateRange{
+		{Start: day(1, 1), End: day(1, 9)},
+		{Start: day(1, 21), End: day(2, 29)},
+		{Start: day(7, 1), End: day(12, 31)},
+	}
+	if len(free) != len(expected) {
+		t.Fatalf("expected %d free ranges, got %d: %v", len(expected), len(free), free)
+	}
+	for i := range expected {
+		if !free[i].Start.Equal(expected[i].Start) || !free[i].End.Equal(expected[i].End) {
+			t.Errorf("range %d = %s..%s, want %s..%s", i,
+				free[i].Start.Format("2006-01-02"), free[i].End.Format("2006-01-02"),
+				expected[i].Start.Format("2006-01-02"), expected[i].End.Format("2006-01-02"))
+		}
+	}
+
+	if fully := leasing.FreeRanges(day(3, 1), day(4, 30), booked); len(fully) != 0 {
+		t.Errorf("expected no free ranges inside a booking, got %v", fully)
+	}
+}
+
+func TestUpdateOccupancyMovesActiveLease(t *testing.T) {
+	requireDatabase(t)
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	_, tenant, property := seedProperty(t, tx)
+	units := []models.Unit{
+		{PropertyID: property.ID, Name: "Flat 1", Price: 800},
+		{PropertyID: property.ID, Name: "Flat 2", Price: 850, Available: true},
+	}
+	for i := range units {
+		if err := tx.Create(&units[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Flat 1 is let to the tenant
+	tx.Model(&units[0]).Updates(map[string]interface{}{"tenant_id": tenant.ID, "available": false})
+
+	before := models.Lease{TenantID: tenant.ID, PropertyID: property.ID, UnitID: &units[0].ID, Status: "active"}
+	after := before
+	after.UnitID = &units[1].ID
+	if err := leasing.UpdateOccupancy(tx, before, after); err != nil {
+		t.Fatal(err)
+	}
+
+	var old, moved models.Unit
+	tx.First(&old, units[0].ID)
+	tx.First(&moved, units[1].ID)
+	if !old.Available || old.TenantID != nil {
+		t.Errorf("expected the old unit to be freed, got available=%v tenant=%v", old.Available, old.TenantID)
+	}
+	if moved.Available || moved.TenantID == nil || *moved.TenantID != tenant.ID {
+		t.Errorf("expected the new unit to be let to tenant %d, got available=%v tenant=%v", tenant.ID, moved.Available, moved.TenantID)
+	}
+
+	// Pending leases hold nothing, so moving one changes nothing
+	pending := after
+	pending.Status = "pending"
+	back := pending
+	back.UnitID = &units[0].ID
+	if err := leasing.UpdateOccupancy(tx, pending, back); err != nil {
+		t.Fatal(err)
+	}
+	tx.First(&moved, units[1].ID)
+	if moved.Available {
+		t.Error("expected moving a pending lease to leave occupancy alone")
+	}
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/geoo115/property-manager/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsRouteGroup(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/admin/leases/:id":         "admin",
+		"/api/v1/landlord/properties":      "landlord",
+		"/tenant/invoices":                 "tenant",
+		"/maintenanceTeam/maintenance/:id": "maintenance_team",
+		"/api/v1/login":                    "public",
+		"/health":                          "health",
+		"":                                 "unmatched",
+	}
+	for path, want := range tests {
+		if got := metrics.RouteGroup(path); got != want {
+			t.Errorf("RouteGroup(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestMetricsGinMiddlewareObservesRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(metrics.GinMiddleware())
+	r.GET("/tenant/invoices", func(c *gin.Context) { c.Status(http.StatusTeapot) })
+
+	before := testutil.CollectAndCount(metrics.HTTPRequestDuration)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tenant/invoices", nil))
+
+	if after := testutil.CollectAndCount(metrics.HTTPRequestDuration); after != before+1 {
+		t.Errorf("got %d series after request, want %d", after, before+1)
+	}
+}
+
+func TestMetricsRedisHookCountsCacheHitsAndMisses(t *testing.T) {
+	ctx := context.Background()
+	hook := metrics.RedisHook{}
+	hits := metrics.CacheRequests.WithLabelValues("units", "hit")
+	misses := metrics.CacheRequests.WithLabelValues("units", "miss")
+	startHits, startMisses := testutil.ToFloat64(hits), testutil.ToFloat64(misses)
+
+	hit := redis.NewStringCmd(ctx, "get", "units:property:1")
+	hook.AfterProcess(ctx, hit)
+
+	miss := redis.NewStringCmd(ctx, "get", "units:property:2")
+	miss.SetErr(redis.Nil)
+	hook.AfterProcess(ctx, miss)
+
+	limiter := redis.NewStringCmd(ctx, "get", "rate_limit:127.0.0.1")
+	hook.AfterProcess(ctx, limiter)
+
+	if got := testutil.ToFloat64(hits) - startHits; got != 1 {
+		t.Errorf("hits = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(misses) - startMisses; got != 1 {
+		t.Errorf("misses = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("rate_limit", "hit")); got != 0 {
+		t.Errorf("rate limiter lookups counted as cache hits: %v", got)
+	}
+}
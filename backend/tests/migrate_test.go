@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/geoo115/property-manager/migrate"
+)
+
+func TestMigrateLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_units.up.sql":      {Data: []byte("CREATE TABLE units (id bigserial);")},
+		"0002_add_units.down.sql":    {Data: []byte("DROP TABLE units;")},
+		"0001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id bigserial);")},
+		"0001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"README.md":                  {Data: []byte("ignored")},
+	}
+
+	migrations, err := migrate.Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Fatalf("expected migrations 1 and 2 in order, got %+v", migrations)
+	}
+	if migrations[1].String() != "0002_add_units" || migrations[1].Down != "DROP TABLE units;" {
+		t.Errorf("unexpected migration: %+v", migrations[1])
+	}
+}
+
+func TestMigrateLoadRejectsBadFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_create_users.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"bad name": {
+			"create_users.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"conflicting names": {
+			"0001_create_users.up.sql":    {Data: []byte("SELECT 1;")},
+			"0001_create_people.down.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+	for name, fsys := range tests {
+		if _, err := migrate.Load(fsys); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMigratePendingAndStatus(t *testing.T) {
+	migrations := []migrate.Migration{
+		{Version: 1, Name: "create_users", Up: "CREATE TABLE users ();"},
+		{Version: 2, Name: "add_units", Up: "CREATE TABLE units ();"},
+	}
+	applied := map[int64]migrate.Record{
+		1: {Version: 1, Name: "create_users", Checksum: migrations[0].Checksum(), AppliedAt: time.Now()},
+		9: {Version: 9, Name: "from_newer_release", Checksum: "x", AppliedAt: time.Now()},
+	}
+
+	pending, err := migrate.Pending(migrations, applied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Version != 2 {
+		t.Fatalf("expected only migration 2 pending, got %+v", pending)
+	}
+
+	statuses := migrate.Statuses(migrations, applied)
+	if len(statuses) != 3 {
+		t.Fatalf("expected 3 statuses, got %d", len(statuses))
+	}
+	if statuses[0].AppliedAt == nil || statuses[1].AppliedAt != nil || !statuses[2].Missing {
+		t.Errorf("unexpected statuses: %+v", statuses)
+	}
+
+	// Editing an applied migration must stop the run
+	migrations[0].Up = "CREATE TABLE users (id bigserial);"
+	if _, err := migrate.Pending(migrations, applied); err == nil {
+		t.Error("expected a checksum mismatch error")
+	}
+	if statuses := migrate.Statuses(migrations, applied); !statuses[0].Modified {
+		t.Error("expected migration 1 to be reported as modified")
+	}
+}
+
+func TestMigrateCreate(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "0007_existing.up.sql"), []byte("SELECT 1;"), 0o644)
+	os.WriteFile(filepath.Join(dir, "0007_existing.down.sql"), []byte("SELECT 1;"), 0o644)
+
+	upPath, downPath, err := migrate.Create(dir, "Add Vendor Quotes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(upPath) != "0008_add_vendor_quotes.up.sql" || filepath.Base(downPath) != "0008_add_vendor_quotes.down.sql" {
+		t.Errorf("unexpected paths %s, %s", upPath, downPath)
+	}
+
+	migrations, err := migrate.Load(os.DirFS(dir))
+	if err != nil || len(migrations) != 2 {
+		t.Fatalf("expected the new pair to load, got %v (%d migrations)", err, len(migrations))
+	}
+}
+
+func TestShippedMigrationsLoad(t *testing.T) {
+	migrations, err := migrate.Load(os.DirFS("../db/migrations"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %s breaks the sequence, expected version %d", m, i+1)
+		}
+	}
+}
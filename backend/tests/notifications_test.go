@@ -0,0 +1,275 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/notifications"
+)
+
+func TestNotificationRender(t *testing.T) {
+	tmpl := models.NotificationTemplate{
+		Subject: "Invoice {{.InvoiceNumber}} is due on {{.DueDate}}",
+		Body:    "Hi {{.RecipientName}}, {{.Balance}} to pay{{if .Note}} ({{.Note}}){{end}}",
+	}
+	subject, body, err := notifications.Render(tmpl, map[string]interface{}{
+		"InvoiceNumber": "INV-1",
+		"DueDate":       "2025-07-01",
+		"RecipientName": "Sam",
+		"Balance":       "950.00",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Invoice INV-1 is due on 2025-07-01" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+	if body != "Hi Sam, 950.00 to pay" {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	broken := models.NotificationTemplate{Body: "{{.Unclosed"}
+	if _, _, err := notifications.Render(broken, nil); err == nil {
+		t.Error("expected a template that does not parse to fail")
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	prefs := []models.NotificationPreference{
+		{EventType: models.EventInvoiceDue, Channel: models.ChannelEmail, Enabled: false},
+		{EventType: models.EventInvoiceDue, Channel: models.ChannelWebhook, Enabled: true, Target: "https://crm.example/hook"},
+	}
+
+	if enabled, _ := notifications.Wants(prefs, models.EventInvoiceDue, models.ChannelEmail); enabled {
+		t.Error("expected the user to have opted out of invoice emails")
+	}
+	if enabled, target := notifications.Wants(prefs, models.EventInvoiceDue, models.ChannelWebhook); !enabled || target != "https://crm.example/hook" {
+		t.Errorf("expected the webhook opt-in, got %v %q", enabled, target)
+	}
+	if enabled, _ := notifications.Wants(prefs, models.EventLeaseExpiring, models.ChannelEmail); !enabled {
+		t.Error("expected email to be on by default")
+	}
+	if enabled, _ := notifications.Wants(nil, models.EventLeaseExpiring, models.ChannelSMS); enabled {
+		t.Error("expected SMS to be opt-in")
+	}
+}
+
+func TestNotificationPreferencesRequest(t *testing.T) {
+	valid := models.NotificationPreferencesRequest{Preferences: []models.NotificationPreferenceRequest{
+		{EventType: models.EventInvoiceDue, Channel: models.ChannelSMS, Enabled: true},
+		{EventType: models.EventInvoiceDue, Channel: models.ChannelWebhook, Enabled: true, Target: "https://crm.example/hook"},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid preferences, got %v", err)
+	}
+
+	invalid := []models.NotificationPreferenceRequest{
+		{EventType: "lease.signed", Channel: models.ChannelEmail},
+		{EventType: models.EventInvoiceDue, Channel: "pager"},
+		{EventType: models.EventInvoiceDue, Channel: models.ChannelWebhook, Enabled: true},
+		{EventType: models.EventInvoiceDue, Channel: models.ChannelWebhook, Enabled: true, Target: "ftp://crm.example"},
+	}
+	for _, pref := range invalid {
+		req := models.NotificationPreferencesRequest{Preferences: []models.NotificationPreferenceRequest{pref}}
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", pref)
+		}
+	}
+}
+
+func TestNotificationRetries(t *testing.T) {
+	if got := notifications.Backoff(time.Minute, 1); got != time.Minute {
+		t.Errorf("expected the first retry after a minute, got %v", got)
+	}
+	if got := notifications.Backoff(time.Minute, 4); got != 8*time.Minute {
+		t.Errorf("expected the backoff to double, got %v", got)
+	}
+	if got := notifications.Backoff(time.Minute, 30); got != 6*time.Hour {
+		t.Errorf("expected the backoff to be capped, got %v", got)
+	}
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	n := &models.Notification{Status: "queued"}
+	if result := notifications.Attempted(n, errors.New("connection refused"), now); result != "retry" {
+		t.Fatalf("expected a retry, got %s", result)
+	}
+	if n.Status != "queued" || n.Attempts != 1 || !n.NextAttemptAt.After(now) || n.LastError == "" {
+		t.Errorf("unexpected notification after a failure: %+v", n)
+	}
+
+	if result := notifications.Attempted(n, nil, now); result != "sent" || n.Status != "sent" || n.SentAt == nil {
+		t.Errorf("expected the notification to be sent, got %s %+v", result, n)
+	}
+
+	exhausted := &models.Notification{Status: "queued", Attempts: 4}
+	if result := notifications.Attempted(exhausted, errors.New("timeout"), now); result != "failed" || exhausted.Status != "failed" {
+		t.Errorf("expected the fifth failure to give up, got %s %+v", result, exhausted)
+	}
+}
+
+// smtpStandIn accepts one message over plain SMTP and sends its DATA on the
+// returned channel
+func smtpStandIn(t *testing.T) (string, int, <-chan string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				received <- data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	return host, portNum, received
+}
+
+func TestEmailChannel(t *testing.T) {
+	host, port, received := smtpStandIn(t)
+	channel := notifications.EmailChannel{Config: config.EmailConfig{
+		SMTPHost: host,
+		SMTPPort: port,
+		From:     "notifications@example.com",
+	}}
+
+	n := &models.Notification{Recipient: "tenant@example.com", Subject: "Invoice INV-1 is due", Body: "950.00 to pay"}
+	if err := channel.Send(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case data := <-received:
+		for _, want := range []string{"From: notifications@example.com", "To: tenant@example.com", "Subject: Invoice INV-1 is due", "950.00 to pay"} {
+			if !strings.Contains(data, want) {
+				t.Errorf("expected the message to contain %q, got %q", want, data)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the SMTP stand-in received no message")
+	}
+}
+
+func TestWebhookChannel(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if payload["event_type"] == models.EventLeaseExpiring {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	channel := notifications.WebhookChannel{Client: server.Client()}
+	n := &models.Notification{
+		ID:        7,
+		EventType: models.EventInvoiceDue,
+		Recipient: server.URL,
+		Subject:   "Invoice due soon",
+		Data:      map[string]interface{}{"InvoiceID": 12},
+	}
+	if err := channel.Send(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	if payload["subject"] != "Invoice due soon" || payload["data"].(map[string]interface{})["InvoiceID"] != float64(12) {
+		t.Errorf("unexpected webhook payload %v", payload)
+	}
+
+	n.EventType = models.EventLeaseExpiring
+	if err := channel.Send(context.Background(), n); err == nil {
+		t.Error("expected a 503 from the webhook to fail the delivery")
+	}
+}
+
+func TestNotificationStreams(t *testing.T) {
+	tenant, unsubscribeTenant := notifications.Subscribe(101)
+	secondTab, unsubscribeSecondTab := notifications.Subscribe(101)
+	landlord, unsubscribeLandlord := notifications.Subscribe(102)
+	defer unsubscribeTenant()
+	defer unsubscribeLandlord()
+
+	notifications.Publish(&models.Notification{ID: 1, UserID: 101, Subject: "Maintenance request updated"})
+	for _, stream := range []<-chan *models.Notification{tenant, secondTab} {
+		select {
+		case n := <-stream:
+			if n.ID != 1 {
+				t.Errorf("expected notification 1, got %d", n.ID)
+			}
+		default:
+			t.Error("expected every stream the tenant has open to get the notification")
+		}
+	}
+	select {
+	case n := <-landlord:
+		t.Errorf("expected the landlord's stream to stay quiet, got %+v", n)
+	default:
+	}
+
+	unsubscribeSecondTab()
+	if _, open := <-secondTab; open {
+		t.Error("expected unsubscribing to close the stream")
+	}
+	unsubscribeSecondTab()
+
+	// A stream that stops reading misses notifications rather than holding
+	// up the publisher
+	for i := 0; i < 100; i++ {
+		notifications.Publish(&models.Notification{ID: uint(i + 2), UserID: 102})
+	}
+	if n := <-landlord; n.ID != 2 {
+		t.Errorf("expected the oldest buffered notification first, got %d", n.ID)
+	}
+}
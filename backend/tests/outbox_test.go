@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/events"
+	"github.com/geoo115/property-manager/models"
+)
+
+func TestOutboxEvents(t *testing.T) {
+	maintenance := models.Maintenance{ID: 42, PropertyID: 7, Description: "Leaking tap", Status: "pending"}
+	event, err := events.NewOutboxEvent("maintenance-requests", "42", maintenance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Status != "pending" || event.Topic != "maintenance-requests" || event.Key != "42" {
+		t.Errorf("unexpected outbox event %+v", event)
+	}
+
+	msg := events.Message(&event)
+	if msg.Topic != "maintenance-requests" || string(msg.Key) != "42" {
+		t.Errorf("unexpected message topic %q and key %q", msg.Topic, msg.Key)
+	}
+	var decoded models.Maintenance
+	if err := json.Unmarshal(msg.Value, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != 42 || decoded.PropertyID != 7 || decoded.Description != "Leaking tap" {
+		t.Errorf("expected the consumer to read the maintenance request back, got %+v", decoded)
+	}
+
+	unkeyed := events.Message(&models.OutboxEvent{Topic: "property-events", Payload: "{}"})
+	if unkeyed.Key != nil {
+		t.Errorf("expected an event without a key to be spread across partitions, got key %q", unkeyed.Key)
+	}
+
+	if _, err := events.NewOutboxEvent("maintenance-requests", "1", func() {}); err == nil {
+		t.Error("expected a value that cannot be encoded to be rejected")
+	}
+}
+
+func TestOutboxRelayed(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := &models.OutboxEvent{Status: "pending"}
+
+	if result := events.Relayed(event, errors.New("broker unavailable"), now); result != "error" {
+		t.Fatalf("expected an error, got %s", result)
+	}
+	if event.Status != "pending" || event.Attempts != 1 || event.LastError != "broker unavailable" || event.SentAt != nil {
+		t.Errorf("expected the event to stay pending, got %+v", event)
+	}
+
+	if result := events.Relayed(event, nil, now); result != "success" {
+		t.Fatalf("expected success, got %s", result)
+	}
+	if event.Status != "sent" || event.Attempts != 2 || event.LastError != "" || event.SentAt == nil || !event.SentAt.Equal(now) {
+		t.Errorf("expected the event to be marked sent, got %+v", event)
+	}
+}
+
+func TestOutboxRoundsHoldOneEventPerKey(t *testing.T) {
+	pending := []models.OutboxEvent{
+		{Key: "lease/1"}, {Key: "lease/2"}, {Key: "lease/1"}, {Key: ""}, {Key: ""}, {Key: "lease/1"},
+	}
+
+	rounds := events.Rounds(pending)
+
+	want := [][]int{{0, 1, 3, 4}, {2}, {5}}
+	if !reflect.DeepEqual(rounds, want) {
+		t.Errorf("rounds = %v, want %v", rounds, want)
+	}
+	if rounds := events.Rounds(nil); len(rounds) != 0 {
+		t.Errorf("expected no rounds for an empty batch, got %v", rounds)
+	}
+}
@@ -0,0 +1,347 @@
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+)
+
+func TestMaintenanceTransitions(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"pending", "assigned", true},
+		{"pending", "in_progress", false},
+		{"pending", "completed", false},
+		{"assigned", "in_progress", true},
+		{"assigned", "pending", true},
+		{"in_progress", "completed", true},
+		{"in_progress", "pending", false},
+		{"completed", "pending", true},
+		{"completed", "in_progress", false},
+		{"cancelled", "pending", true},
+	}
+	for _, tt := range tests {
+		if got := servicing.CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestMaintenanceWorkflow(t *testing.T) {
+	requested := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	m := &models.Maintenance{Status: "pending", Priority: "high", RequestedAt: requested}
+	servicing.StartClock(m, requested)
+
+	if err := servicing.Transition(m, "assigned", requested.Add(time.Hour)); !errors.Is(err, servicing.ErrAssigneeRequired) {
+		t.Fatalf("expected ErrAssigneeRequired, got %v", err)
+	}
+
+	technician := uint(7)
+	m.AssignedToID = &technician
+	if err := servicing.Transition(m, "assigned", requested.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if m.AcknowledgedAt == nil || !m.AcknowledgedAt.Equal(requested.Add(time.Hour)) {
+		t.Errorf("expected assignment to acknowledge the request, got %v", m.AcknowledgedAt)
+	}
+
+	if err := servicing.Transition(m, "completed", requested.Add(2*time.Hour)); !errors.Is(err, servicing.ErrInvalidTransition) {
+		t.Fatalf("expected completing an assigned request to be rejected, got %v", err)
+	}
+	servicing.Transition(m, "in_progress", requested.Add(2*time.Hour))
+	if err := servicing.Transition(m, "completed", requested.Add(5*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if m.CompletedAt == nil {
+		t.Fatal("expected completed_at to be set")
+	}
+
+	// Reopening restarts the clocks from the reopen time
+	reopened := requested.Add(48 * time.Hour)
+	if err := servicing.Transition(m, "pending", reopened); err != nil {
+		t.Fatal(err)
+	}
+	if m.CompletedAt != nil || m.AcknowledgedAt != nil || m.AssignedToID != nil {
+		t.Errorf("expected reopen to clear completion, acknowledgement and assignee: %+v", m)
+	}
+	if want := reopened.Add(4 * time.Hour); !m.AcknowledgeDueAt.Equal(want) {
+		t.Errorf("expected acknowledge due %s, got %s", want, m.AcknowledgeDueAt)
+	}
+}
+
+func TestMaintenanceSLAStatus(t *testing.T) {
+	requested := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	m := &models.Maintenance{Status: "pending", Priority: "urgent"}
+	servicing.StartClock(m, requested)
+
+	if sla := m.SLAStatus(requested.Add(30 * time.Minute)); sla.AcknowledgementBreached || sla.ResolutionBreached {
+		t.Errorf("expected no breach within the targets, got %+v", sla)
+	}
+	if sla := m.SLAStatus(requested.Add(2 * time.Hour)); !sla.AcknowledgementBreached || sla.ResolutionBreached {
+		t.Errorf("expected only an acknowledgement breach, got %+v", sla)
+	}
+
+	// A request completed late stays breached; cancelled ones stop the clock
+	completed := requested.Add(30 * time.Hour)
+	m.Status, m.CompletedAt = "completed", &completed
+	acknowledged := requested.Add(10 * time.Minute)
+	m.AcknowledgedAt = &acknowledged
+	if sla := m.SLAStatus(requested.Add(100 * time.Hour)); sla.AcknowledgementBreached || !sla.ResolutionBreached {
+		t.Errorf("expected only a resolution breach, got %+v", sla)
+	}
+
+	cancelled := &models.Maintenance{Status: "cancelled", Priority: "urgent"}
+	servicing.StartClock(cancelled, requested)
+	if sla := cancelled.SLAStatus(requested.Add(100 * time.Hour)); sla.AcknowledgementBreached || sla.ResolutionBreached {
+		t.Errorf("expected cancelled requests not to breach, got %+v", sla)
+	}
+}
+
+func TestMaintenanceSLAPolicy(t *testing.T) {
+	if got := servicing.NextPriority("medium"); got != "high" {
+		t.Errorf("NextPriority(medium) = %s", got)
+	}
+	if got := servicing.NextPriority("urgent"); got != "urgent" {
+		t.Errorf("NextPriority(urgent) = %s", got)
+	}
+
+	policy := servicing.PolicyFromConfig(config.MaintenanceSLAConfig{
+		Acknowledge: map[string]time.Duration{"urgent": 30 * time.Minute},
+	})
+	if policy["urgent"].Acknowledge != 30*time.Minute || policy["urgent"].Resolve != 24*time.Hour {
+		t.Errorf("expected configured urgent acknowledge and default resolve, got %+v", policy["urgent"])
+	}
+	if policy["low"] != servicing.DefaultPolicy()["low"] {
+		t.Errorf("expected defaults for unconfigured priorities, got %+v", policy["low"])
+	}
+}
+
+func TestMaintenanceCommentRequest(t *testing.T) {
+	valid := models.MaintenanceCommentRequest{Body: "Plumber booked for Thursday", Visibility: "internal"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid comment, got %v", err)
+	}
+	if err := (&models.MaintenanceCommentRequest{Body: "Hi", Visibility: "private"}).Validate(); err == nil {
+		t.Error("expected unknown visibility to be rejected")
+	}
+	if err := (&models.MaintenanceCommentRequest{Body: strings.Repeat("a", 5001)}).Validate(); err == nil {
+		t.Error("expected an over-long body to be rejected")
+	}
+
+	comment := models.MaintenanceComment{
+		ID:         3,
+		Body:       "Parts on order",
+		Visibility: "internal",
+		Author:     models.User{ID: 9, FirstName: "Sam", Email: "sam@example.com", Role: "maintenanceTeam"},
+	}
+	if !comment.IsInternal() {
+		t.Error("expected comment to be internal")
+	}
+	response := comment.ToResponse(nil)
+	if response.Attachments == nil || len(response.Attachments) != 0 {
+		t.Errorf("expected an empty attachment list, got %v", response.Attachments)
+	}
+	if response.Author.ID != 9 || response.Author.Role != "maintenanceTeam" {
+		t.Errorf("unexpected author %+v", response.Author)
+	}
+}
+
+func TestMaintenanceStatusChangeResponse(t *testing.T) {
+	change := models.MaintenanceStatusChange{ID: 1, FromStatus: "pending", ToStatus: "assigned"}
+	if response := change.ToResponse(); response.ChangedBy != nil {
+		t.Errorf("expected no actor for a job's change, got %+v", response.ChangedBy)
+	}
+	change.ChangedBy = &models.User{ID: 2, FirstName: "Ada", Role: "admin"}
+	if response := change.ToResponse(); response.ChangedBy == nil || response.ChangedBy.ID != 2 {
+		t.Errorf("expected actor 2, got %+v", response.ChangedBy)
+	}
+}
+
+func TestTechnicianCalendar(t *testing.T) {
+	// Monday 3 March 2025
+	monday := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return monday.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	booked := &models.Maintenance{ID: 21, Status: "assigned"}
+	servicing.Schedule(booked, at(13, 0), nil) // default length, until 15:00
+
+	cal := servicing.Calendar{
+		Hours: []models.WorkingHours{{Weekday: 1, StartTime: "08:00", EndTime: "17:00"}},
+		TimeOff: []models.TimeOff{{
+			StartsAt: at(24+8, 0), // all of Tuesday
+			EndsAt:   at(48, 0),
+		}},
+		Appointments: []models.Maintenance{*booked},
+	}
+
+	if err := cal.Check(at(9, 0), at(11, 0)); err != nil {
+		t.Errorf("expected a free slot, got %v", err)
+	}
+	if err := cal.Check(at(7, 0), at(9, 0)); !errors.Is(err, servicing.ErrOutsideWorkingHours) {
+		t.Errorf("expected ErrOutsideWorkingHours before the shift, got %v", err)
+	}
+	if err := cal.Check(at(16, 0), at(18, 0)); !errors.Is(err, servicing.ErrOutsideWorkingHours) {
+		t.Errorf("expected ErrOutsideWorkingHours past the shift, got %v", err)
+	}
+	var conflict *servicing.ConflictError
+	if err := cal.Check(at(14, 0), at(16, 0)); !errors.As(err, &conflict) || conflict.Conflicts[0].ID != 21 {
+		t.Errorf("expected a conflict with request 21, got %v", err)
+	}
+	if err := cal.Check(at(15, 0), at(17, 0)); err != nil {
+		t.Errorf("expected back-to-back appointments to be allowed, got %v", err)
+	}
+
+	// Tuesday has no shift, so clear the hours to reach the time off check
+	cal.Hours = nil
+	if err := cal.Check(at(24+10, 0), at(24+11, 0)); !errors.Is(err, servicing.ErrTimeOff) {
+		t.Errorf("expected ErrTimeOff, got %v", err)
+	}
+}
+
+func TestRankCandidates(t *testing.T) {
+	candidates := []servicing.Candidate{
+		{Technician: models.Participant{ID: 1}, OpenJobs: 0, Available: false},
+		{Technician: models.Participant{ID: 2}, OpenJobs: 3, Available: true},
+		{Technician: models.Participant{ID: 3}, OpenJobs: 1, Available: true},
+		{Technician: models.Participant{ID: 4}, OpenJobs: 1, Available: true},
+	}
+	servicing.RankCandidates(candidates)
+
+	want := []uint{3, 4, 2, 1}
+	for i, id := range want {
+		if candidates[i].Technician.ID != id {
+			t.Fatalf("position %d: expected technician %d, got %d", i, id, candidates[i].Technician.ID)
+		}
+	}
+}
+
+func TestWorkingHoursRequest(t *testing.T) {
+	valid := models.WorkingHoursRequest{Hours: []models.ShiftRequest{
+		{Weekday: 1, StartTime: "08:00", EndTime: "12:00"},
+		{Weekday: 1, StartTime: "13:00", EndTime: "17:00"},
+	}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid hours, got %v", err)
+	}
+
+	invalid := []models.ShiftRequest{
+		{Weekday: 7, StartTime: "08:00", EndTime: "12:00"},
+		{Weekday: 1, StartTime: "8am", EndTime: "12:00"},
+		{Weekday: 1, StartTime: "12:00", EndTime: "08:00"},
+	}
+	for _, shift := range invalid {
+		req := models.WorkingHoursRequest{Hours: []models.ShiftRequest{shift}}
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", shift)
+		}
+	}
+
+	overlapping := models.WorkingHoursRequest{Hours: []models.ShiftRequest{
+		{Weekday: 2, StartTime: "08:00", EndTime: "12:00"},
+		{Weekday: 2, StartTime: "11:00", EndTime: "15:00"},
+	}}
+	if err := overlapping.Validate(); err == nil {
+		t.Error("expected overlapping shifts to be rejected")
+	}
+}
+
+func TestMaintenanceCostPosting(t *testing.T) {
+	if got := servicing.ExpenseCategory("general"); got != "maintenance" {
+		t.Errorf("expected general work to be booked as maintenance, got %s", got)
+	}
+	if got := servicing.ExpenseCategory("plumbing"); got != "repairs" {
+		t.Errorf("expected plumbing work to be booked as repairs, got %s", got)
+	}
+
+	tests := []struct {
+		actual, vendorBilled, want float64
+	}{
+		{250, 0, 250},
+		{250, 180.5, 69.5},
+		{0.3, 0.1, 0.2},
+		{100, 150, 0}, // the vendor invoice covers the whole cost
+	}
+	for _, tt := range tests {
+		if got := servicing.PostableCost(tt.actual, tt.vendorBilled); got != tt.want {
+			t.Errorf("PostableCost(%v, %v) = %v, want %v", tt.actual, tt.vendorBilled, got, tt.want)
+		}
+	}
+
+	// Only completed requests reach accounting, so no database is needed here
+	open := &models.Maintenance{Status: "in_progress", ActualCost: 100, RechargeTenant: true}
+	posting, err := servicing.PostCosts(nil, open, 1, time.Now())
+	if err != nil || posting.Expense != nil || posting.Invoice != nil {
+		t.Errorf("expected nothing posted for an open request, got %+v, %v", posting, err)
+	}
+}
+
+func TestMaintenanceReopenReversesCosts(t *testing.T) {
+	requireDatabase(t)
+	tx := db.DB.Begin()
+	defer tx.Rollback()
+
+	owner, tenant, property := seedProperty(t, tx)
+
+	now := time.Now()
+	m := &models.Maintenance{RequestedByID: tenant.ID, PropertyID: property.ID, Title: "Broken window",
+		Description: "Cracked pane", Status: "completed", CompletedAt: &now, RequestedAt: now,
+		ActualCost: 120, RechargeTenant: true}
+	if err := tx.Create(m).Error; err != nil {
+		t.Fatal(err)
+	}
+	posted, err := servicing.PostCosts(tx, m, owner.ID, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if posted.Expense == nil || posted.Invoice == nil {
+		t.Fatalf("expected completion to post an expense and a recharge, got %+v", posted)
+	}
+	firstInvoice := posted.Invoice.ID
+
+	// Reopening takes both back
+	if err := servicing.Transition(m, "pending", now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := servicing.ReverseCosts(tx, m); err != nil {
+		t.Fatal(err)
+	}
+	var expenses int64
+	tx.Model(&models.Expense{}).Where("maintenance_id = ? AND source = ?", m.ID, "maintenance").Count(&expenses)
+	if expenses != 0 {
+		t.Errorf("expected the maintenance expense to be removed, found %d", expenses)
+	}
+	var invoice models.Invoice
+	tx.First(&invoice, firstInvoice)
+	if invoice.PaymentStatus != "cancelled" || m.RechargeInvoiceID != nil {
+		t.Errorf("expected the recharge to be cancelled and unlinked, got %s, %v", invoice.PaymentStatus, m.RechargeInvoiceID)
+	}
+
+	// Completing it again posts afresh
+	m.Status, m.CompletedAt = "completed", &now
+	reposted, err := servicing.PostCosts(tx, m, owner.ID, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reposted.Expense == nil || reposted.Expense.Amount != 120 {
+		t.Errorf("expected a new 120 expense, got %+v", reposted.Expense)
+	}
+	if reposted.Invoice == nil || reposted.Invoice.ID == firstInvoice || reposted.Invoice.PaymentStatus != "pending" {
+		t.Errorf("expected a new pending recharge, got %+v", reposted.Invoice)
+	}
+
+	// A recharge the tenant has paid cannot be taken back
+	tx.Model(reposted.Invoice).Update("paid_amount", 50)
+	if _, err := servicing.ReverseCosts(tx, m); !errors.Is(err, servicing.ErrRechargePaid) {
+		t.Errorf("expected ErrRechargePaid, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/geoo115/property-manager/snapshot"
+)
+
+func TestSnapshotChangeIDs(t *testing.T) {
+	rows := map[uint]snapshot.Row{9: {"id": int64(9)}, 2: {"id": int64(2)}, 5: {"id": int64(5)}}
+
+	update := snapshot.Change{Action: snapshot.Update, Before: rows, After: map[uint]snapshot.Row{5: rows[5], 2: rows[2]}}
+	if ids := update.IDs(); !reflect.DeepEqual(ids, []uint{2, 5}) {
+		t.Errorf("update IDs = %v, want the rows written [2 5]", ids)
+	}
+
+	deleted := snapshot.Change{Action: snapshot.Delete, Before: rows}
+	if ids := deleted.IDs(); !reflect.DeepEqual(ids, []uint{2, 5, 9}) {
+		t.Errorf("delete IDs = %v, want the rows removed [2 5 9]", ids)
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/billing"
+	"github.com/geoo115/property-manager/models"
+)
+
+func TestBuildTenantStatementRunsBalance(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	invoices := []models.Invoice{
+		{InvoiceNumber: "INV-1", InvoiceDate: jan, Amount: 1000, Category: "rent"},
+		{InvoiceNumber: "INV-2", InvoiceDate: jan.AddDate(0, 0, 20), Amount: 25, Category: "late_fee"},
+	}
+	payments := []models.Payment{
+		{ReceiptNumber: "RCP-1", PaymentDate: jan, Amount: 600, Type: "payment", Method: "card"},
+		{ReceiptNumber: "RCP-2", PaymentDate: jan.AddDate(0, 0, 25), Amount: 50, Type: "refund", Method: "card"},
+	}
+
+	statement := billing.BuildTenantStatement(200, invoices, payments)
+
+	expectedBalances := []float64{1200, 600, 625, 675}
+	if len(statement.Lines) != len(expectedBalances) {
+		t.Fatalf("expected %d lines, got %d", len(expectedBalances), len(statement.Lines))
+	}
+	for i, expected := range expectedBalances {
+		if statement.Lines[i].Balance != expected {
+			t.Errorf("line %d balance = %.2f, want %.2f", i, statement.Lines[i].Balance, expected)
+		}
+	}
+	if statement.TotalInvoiced != 1025 || statement.TotalPaid != 550 || statement.ClosingBalance != 675 {
+		t.Errorf("unexpected totals: invoiced %.2f, paid %.2f, closing %.2f",
+			statement.TotalInvoiced, statement.TotalPaid, statement.ClosingBalance)
+	}
+}
+
+func TestStatementPDFsRender(t *testing.T) {
+	period := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	tenant := billing.BuildTenantStatement(0, []models.Invoice{
+		{InvoiceNumber: "INV-1", InvoiceDate: period, Amount: 1000, Category: "rent"},
+	}, nil)
+	tenant.From, tenant.To = period.AddDate(0, -1, 1), period
+
+	owner := billing.OwnerStatement{
+		From: period.AddDate(0, -1, 1),
+		To:   period,
+		Properties: []billing.PropertyStatement{{
+			Name: "Maple Court", Address: "1 Maple Street", Income: 1000,
+			ExpensesByCategory: map[string]float64{"repairs": 150}, TotalExpenses: 150, Net: 850,
+		}},
+		TotalIncome: 1000, TotalExpenses: 150, NetToOwner: 850,
+	}
+
+	tenantPDF, err := billing.RenderTenantStatementPDF(tenant)
+	if err != nil {
+		t.Fatalf("tenant statement: %v", err)
+	}
+	ownerPDF, err := billing.RenderOwnerStatementPDF(owner)
+	if err != nil {
+		t.Fatalf("owner statement: %v", err)
+	}
+	for name, pdf := range map[string][]byte{"tenant": tenantPDF, "owner": ownerPDF} {
+		if !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+			t.Errorf("%s statement is not a PDF", name)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/storage"
+)
+
+// s3StandIn is a minimal in-memory S3 endpoint: PUT, HEAD, GET and DELETE on
+// path-style object URLs
+type s3StandIn struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newS3StandIn() *s3StandIn {
+	return &s3StandIn{objects: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (s *s3StandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		body, err := readS3Body(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = body
+		s.types[key] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"standin"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead, http.MethodGet:
+		body, ok := s.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				io.WriteString(w, `<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", s.types[key])
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"standin"`)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(body)
+		}
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// readS3Body decodes the aws-chunked encoding clients use for streaming
+// signed uploads over plain HTTP
+func readS3Body(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	var body bytes.Buffer
+	reader := bufio.NewReader(r.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex := strings.TrimSpace(strings.SplitN(line, ";", 2)[0])
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return body.Bytes(), nil
+		}
+		if _, err := io.CopyN(&body, reader, size); err != nil {
+			return nil, err
+		}
+		reader.ReadString('\n')
+	}
+}
+
+func exerciseStore(t *testing.T, store storage.Store) {
+	t.Helper()
+	ctx := context.Background()
+	content := []byte("%PDF-1.4 test document")
+
+	if err := store.Put(ctx, "lease/1/doc.pdf", bytes.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reader, err := store.Open(ctx, "lease/1/doc.pdf")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Fatalf("Open() read %q, %v; want %q", got, err, content)
+	}
+
+	if err := store.Delete(ctx, "lease/1/doc.pdf"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Open(ctx, "lease/1/doc.pdf"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("Open() after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalStoreRoundTrip(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	exerciseStore(t, store)
+
+	if err := store.Put(context.Background(), "../escape.pdf", strings.NewReader("x"), 1, "application/pdf"); err == nil {
+		t.Error("Put() accepted a key outside the upload directory")
+	}
+}
+
+func TestS3StoreAgainstStandIn(t *testing.T) {
+	server := httptest.NewServer(newS3StandIn())
+	defer server.Close()
+
+	store, err := storage.NewS3Store(config.S3Config{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		Bucket:    "uploads",
+		Region:    "us-east-1",
+		AccessKey: "test",
+		SecretKey: "test-secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exerciseStore(t, store)
+}
+
+func TestSignedURLExpiresAndRejectsTampering(t *testing.T) {
+	signer := storage.NewURLSigner("secret")
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(15 * time.Minute)
+
+	url := signer.URL(7, expires)
+	query := url[strings.Index(url, "?")+1:]
+	params := map[string]string{}
+	for _, pair := range strings.Split(query, "&") {
+		kv := strings.SplitN(pair, "=", 2)
+		params[kv[0]] = kv[1]
+	}
+
+	if err := signer.Verify(7, params["expires"], params["signature"], now); err != nil {
+		t.Errorf("Verify() error = %v, want nil", err)
+	}
+	if err := signer.Verify(8, params["expires"], params["signature"], now); !errors.Is(err, storage.ErrInvalidSignature) {
+		t.Errorf("Verify() for another file error = %v, want ErrInvalidSignature", err)
+	}
+	later := strconv.FormatInt(expires.Add(time.Hour).Unix(), 10)
+	if err := signer.Verify(7, later, params["signature"], now); !errors.Is(err, storage.ErrInvalidSignature) {
+		t.Errorf("Verify() with extended expiry error = %v, want ErrInvalidSignature", err)
+	}
+	if err := signer.Verify(7, params["expires"], params["signature"], expires.Add(time.Second)); !errors.Is(err, storage.ErrURLExpired) {
+		t.Errorf("Verify() after expiry error = %v, want ErrURLExpired", err)
+	}
+}
+
+func TestInitKeepsDownloadKeyApartFromJWTSecret(t *testing.T) {
+	cfg := &config.Config{
+		Server:     config.ServerConfig{GinMode: "release"},
+		JWT:        config.JWTConfig{Secret: "jwt-secret"},
+		FileUpload: config.FileUploadConfig{UploadPath: t.TempDir(), URLExpiry: time.Minute},
+	}
+	if err := storage.Init(cfg); !errors.Is(err, storage.ErrSigningKeyRequired) {
+		t.Fatalf("Init() in release mode without a key error = %v, want ErrSigningKeyRequired", err)
+	}
+
+	signedWith := func(key string) error {
+		url, expires := storage.SignedURL(7)
+		signature := url[strings.Index(url, "signature=")+len("signature="):]
+		return storage.NewURLSigner(key).Verify(7, strconv.FormatInt(expires.Unix(), 10), signature, time.Now())
+	}
+
+	cfg.Server.GinMode = "debug"
+	if err := storage.Init(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := signedWith("jwt-secret"); !errors.Is(err, storage.ErrInvalidSignature) {
+		t.Errorf("expected links not to be signed with the JWT secret, got %v", err)
+	}
+
+	cfg.Server.GinMode = "release"
+	cfg.FileUpload.SigningKey = "upload-key"
+	if err := storage.Init(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := signedWith("upload-key"); err != nil {
+		t.Errorf("expected links signed with UPLOAD_SIGNING_KEY, got %v", err)
+	}
+}
+
+func TestSniffContentTypeIgnoresClaimedType(t *testing.T) {
+	tests := []struct {
+		head    []byte
+		want    string
+		allowed bool
+	}{
+		{[]byte("\x89PNG\r\n\x1a\n0000"), "image/png", true},
+		{[]byte("%PDF-1.7"), "application/pdf", true},
+		{[]byte("\xff\xd8\xff\xe0"), "image/jpeg", true},
+		{[]byte("<html><script>alert(1)</script>"), "text/html", false},
+		{[]byte("MZ\x90\x00"), "application/octet-stream", false},
+	}
+	for _, tt := range tests {
+		got, allowed := storage.SniffContentType(tt.head)
+		if got != tt.want || allowed != tt.allowed {
+			t.Errorf("SniffContentType(%q) = %s, %v; want %s, %v", tt.head, got, allowed, tt.want, tt.allowed)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/api/accounting"
+	"github.com/geoo115/property-manager/api/unit"
+	"github.com/geoo115/property-manager/db"
+	"github.com/geoo115/property-manager/models"
+	"github.com/gin-gonic/gin"
+)
+
+// seedUnits creates two properties with a unit and a tenant each and removes
+// them, and their owners, when the test ends
+func seedUnits(t *testing.T) (tenants [2]models.User, properties [2]models.Property, units [2]models.Unit) {
+	t.Helper()
+	for i := range properties {
+		owner, tenant, property := seedProperty(t, db.DB)
+		units[i] = models.Unit{PropertyID: property.ID, Name: fmt.Sprintf("Flat %d", i+1), Price: 800, Available: true}
+		if err := db.DB.Create(&units[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			db.DB.Where("property_id = ?", property.ID).Delete(&models.Lease{})
+			db.DB.Where("property_id = ?", property.ID).Delete(&models.Unit{})
+			db.DB.Delete(&property)
+			db.DB.Delete(&models.User{}, []uint{owner.ID, tenant.ID})
+		})
+		tenants[i], properties[i] = tenant, property
+	}
+	return tenants, properties, units
+}
+
+func TestValidateForProperty(t *testing.T) {
+	requireDatabase(t)
+	_, properties, units := seedUnits(t)
+
+	if got, err := unit.ValidateForProperty(nil, properties[0].ID); got != nil || err != nil {
+		t.Errorf("expected no unit to mean the whole property, got %v, %v", got, err)
+	}
+	if got, err := unit.ValidateForProperty(&units[0].ID, properties[0].ID); err != nil || got.ID != units[0].ID {
+		t.Errorf("expected the property's own unit to be accepted, got %v, %v", got, err)
+	}
+	if _, err := unit.ValidateForProperty(&units[1].ID, properties[0].ID); err == nil {
+		t.Error("expected a unit of another property to be rejected")
+	}
+	unknown := units[1].ID + 1000000
+	if _, err := unit.ValidateForProperty(&unknown, properties[0].ID); err == nil {
+		t.Error("expected an unknown unit to be rejected")
+	}
+}
+
+func TestCreateInvoiceRejectsLeaseOfAnotherTenant(t *testing.T) {
+	requireDatabase(t)
+	gin.SetMode(gin.TestMode)
+	tenants, properties, units := seedUnits(t)
+
+	lease := models.Lease{TenantID: tenants[1].ID, PropertyID: properties[0].ID, UnitID: &units[0].ID,
+		StartDate: time.Now(), EndDate: time.Now().AddDate(1, 0, 0), MonthlyRent: 800, SecurityDeposit: 800, Status: "pending"}
+	if err := db.DB.Create(&lease).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(gin.H{
+		"tenant_id":    tenants[0].ID,
+		"property_id":  properties[0].ID,
+		"lease_id":     lease.ID,
+		"amount":       800,
+		"invoice_date": "2025-01-01",
+		"due_date":     "2025-01-08",
+		"category":     "rent",
+	})
+	c, w := getTestContext("POST", "/admin/accounting/invoices", body)
+	accounting.CreateInvoice(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for another tenant's lease, got %d: %s", w.Code, w.Body.String())
+	}
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if response["error"] != "Invalid lease" {
+		t.Errorf("expected an invalid lease error, got %v", response)
+	}
+}
@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/servicing"
+)
+
+func TestVendorRequestValidation(t *testing.T) {
+	name := "Acme Plumbing"
+	rating := 4.5
+	valid := models.VendorRequest{Name: &name, Trades: []string{"plumbing", "heating"}, Rating: &rating}
+	if err := valid.Validate(true); err != nil {
+		t.Errorf("expected valid vendor, got %v", err)
+	}
+
+	if err := (&models.VendorRequest{}).Validate(true); err == nil {
+		t.Error("expected a vendor without a name to be rejected")
+	}
+	if err := (&models.VendorRequest{}).Validate(false); err != nil {
+		t.Errorf("expected an empty update to be valid, got %v", err)
+	}
+
+	tooHigh := 6.0
+	if err := (&models.VendorRequest{Rating: &tooHigh}).Validate(false); err == nil {
+		t.Error("expected a rating above 5 to be rejected")
+	}
+	if err := (&models.VendorRequest{Trades: []string{"roofing"}}).Validate(false); err == nil {
+		t.Error("expected an unknown trade to be rejected")
+	}
+
+	vendor := models.Vendor{}
+	valid.Apply(&vendor)
+	if vendor.Name != name || !vendor.HasTrade("heating") || vendor.HasTrade("electrical") {
+		t.Errorf("unexpected vendor after apply: %+v", vendor)
+	}
+}
+
+func TestQuoteApproval(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	insuredUntil := now.AddDate(1, 0, 0)
+	lapsed := now.AddDate(0, -1, 0)
+
+	quote := models.WorkOrderQuote{
+		Amount: 450,
+		Vendor: models.Vendor{IsActive: true, InsuranceExpiresAt: &insuredUntil},
+	}
+	if err := servicing.CheckQuote(quote, now); err != nil {
+		t.Errorf("expected quote to be approvable, got %v", err)
+	}
+
+	uninsured := quote
+	uninsured.Vendor.InsuranceExpiresAt = &lapsed
+	if err := servicing.CheckQuote(uninsured, now); !errors.Is(err, servicing.ErrVendorUninsured) {
+		t.Errorf("expected ErrVendorUninsured, got %v", err)
+	}
+	uninsured.Vendor.InsuranceExpiresAt = nil
+	if err := servicing.CheckQuote(uninsured, now); !errors.Is(err, servicing.ErrVendorUninsured) {
+		t.Errorf("expected a vendor without insurance on file to be refused, got %v", err)
+	}
+
+	expired := quote
+	expired.ValidUntil = &lapsed
+	if err := servicing.CheckQuote(expired, now); !errors.Is(err, servicing.ErrQuoteExpired) {
+		t.Errorf("expected ErrQuoteExpired, got %v", err)
+	}
+
+	inactive := quote
+	inactive.Vendor.IsActive = false
+	if err := servicing.CheckQuote(inactive, now); !errors.Is(err, servicing.ErrVendorInactive) {
+		t.Errorf("expected ErrVendorInactive, got %v", err)
+	}
+}
+
+func TestVendorInvoiceValidation(t *testing.T) {
+	valid := models.VendorInvoiceRequest{Amount: 480, InvoiceDate: time.Now(), Reference: "ACME-1042", PaymentMethod: "bank_transfer"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid invoice, got %v", err)
+	}
+	if err := (&models.VendorInvoiceRequest{Amount: 0}).Validate(); err == nil {
+		t.Error("expected a zero amount to be rejected")
+	}
+	if err := (&models.VendorInvoiceRequest{Amount: 10, PaymentMethod: "bitcoin"}).Validate(); err == nil {
+		t.Error("expected an unknown payment method to be rejected")
+	}
+}
@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/webhooks"
+)
+
+// initWebhooks applies test webhook settings and restores the defaults after
+// the test
+func initWebhooks(t *testing.T, allowPrivate bool) {
+	t.Helper()
+	settings := config.WebhookConfig{
+		MaxAttempts:         3,
+		RetryBackoff:        time.Minute,
+		Timeout:             5 * time.Second,
+		DisableAfter:        15,
+		AllowPrivateTargets: allowPrivate,
+	}
+	webhooks.Init(&config.Config{Webhooks: settings})
+	t.Cleanup(func() {
+		webhooks.Init(&config.Config{Webhooks: config.WebhookConfig{
+			MaxAttempts:  8,
+			RetryBackoff: time.Minute,
+			Timeout:      10 * time.Second,
+			DisableAfter: 15,
+		}})
+	})
+}
+
+func TestWebhookSignatures(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"evt_1","type":"invoice.paid"}`)
+	header := webhooks.Sign("whsec_test", now, body)
+
+	if err := webhooks.Verify("whsec_test", header, body, 5*time.Minute, now.Add(time.Minute)); err != nil {
+		t.Fatalf("expected the signature to verify, got %v", err)
+	}
+	if err := webhooks.Verify("whsec_test", header, []byte(`{"id":"evt_1","type":"invoice.created"}`), 5*time.Minute, now); !errors.Is(err, webhooks.ErrInvalidSignature) {
+		t.Errorf("expected a tampered body to be rejected, got %v", err)
+	}
+	if err := webhooks.Verify("whsec_other", header, body, 5*time.Minute, now); !errors.Is(err, webhooks.ErrInvalidSignature) {
+		t.Errorf("expected the wrong secret to be rejected, got %v", err)
+	}
+	if err := webhooks.Verify("whsec_test", header, body, 5*time.Minute, now.Add(10*time.Minute)); !errors.Is(err, webhooks.ErrSignatureExpired) {
+		t.Errorf("expected an old signature to be rejected, got %v", err)
+	}
+	if err := webhooks.Verify("whsec_test", "v1=abc", body, 5*time.Minute, now); !errors.Is(err, webhooks.ErrInvalidSignature) {
+		t.Errorf("expected a header without a timestamp to be rejected, got %v", err)
+	}
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	endpoint := models.WebhookEndpoint{EventTypes: []string{models.WebhookInvoicePaid}}
+	if !endpoint.Subscribed(models.WebhookInvoicePaid) {
+		t.Error("expected the endpoint to receive invoice.paid")
+	}
+	if endpoint.Subscribed(models.WebhookLeaseCreated) {
+		t.Error("expected the endpoint not to receive lease.created")
+	}
+
+	all := models.WebhookEndpoint{EventTypes: []string{models.WebhookAllEvents}}
+	if !all.Subscribed(models.WebhookMaintenanceUpdated) {
+		t.Error("expected * to subscribe to every event")
+	}
+
+	valid := models.WebhookEndpointRequest{URL: "https://crm.example/hooks", EventTypes: []string{models.WebhookLeaseCreated, models.WebhookInvoicePaid}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected the registration to be valid, got %v", err)
+	}
+	for _, req := range []models.WebhookEndpointRequest{
+		{URL: "not a url", EventTypes: []string{models.WebhookLeaseCreated}},
+		{URL: "https://crm.example/hooks"},
+		{URL: "https://crm.example/hooks", EventTypes: []string{"lease.deleted"}},
+		{URL: "https://crm.example/hooks", EventTypes: []string{models.WebhookTest}},
+	} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", req)
+		}
+	}
+
+	empty := []string{}
+	if err := (&models.WebhookEndpointUpdateRequest{EventTypes: &empty}).Validate(); err == nil {
+		t.Error("expected an update removing every event type to be rejected")
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	initWebhooks(t, true)
+
+	var (
+		header    http.Header
+		body      []byte
+		failUntil = 1
+		calls     int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		if calls <= failUntil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("try later"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	eventID, payload, err := webhooks.NewEvent(models.WebhookInvoicePaid, webhooks.Invoice{ID: 12, PaymentStatus: "paid"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoint := &models.WebhookEndpoint{ID: 3, URL: server.URL, Secret: "whsec_test"}
+	d := &models.WebhookDelivery{ID: 9, EndpointID: 3, EventID: eventID, EventType: models.WebhookInvoicePaid, Payload: payload, Status: "queued"}
+
+	err = webhooks.Send(context.Background(), endpoint, d)
+	if err == nil {
+		t.Fatal("expected a 503 to fail the delivery")
+	}
+	if d.ResponseStatus != http.StatusServiceUnavailable || d.ResponseBody != "try later" {
+		t.Errorf("expected the response to be logged, got %d %q", d.ResponseStatus, d.ResponseBody)
+	}
+	if result := webhooks.Attempted(d, err, now); result != "retry" || d.Status != "queued" || !d.NextAttemptAt.After(now) {
+		t.Errorf("expected a retry, got %s %+v", result, d)
+	}
+
+	err = webhooks.Send(context.Background(), endpoint, d)
+	if result := webhooks.Attempted(d, err, now); result != "sent" || d.Status != "sent" || d.DeliveredAt == nil {
+		t.Fatalf("expected the delivery to be sent, got %s %+v", result, d)
+	}
+	if header.Get("X-Webhook-Event") != models.WebhookInvoicePaid || header.Get("X-Webhook-ID") != eventID || header.Get("X-Webhook-Delivery") != "9" {
+		t.Errorf("unexpected delivery headers %v", header)
+	}
+	if err := webhooks.Verify("whsec_test", header.Get(webhooks.SignatureHeader), body, 5*time.Minute, time.Now()); err != nil {
+		t.Errorf("expected the delivery to be signed when sent, got %v", err)
+	}
+
+	var received map[string]interface{}
+	if err := json.Unmarshal(body, &received); err != nil {
+		t.Fatal(err)
+	}
+	if received["id"] != eventID || received["type"] != models.WebhookInvoicePaid || received["data"].(map[string]interface{})["payment_status"] != "paid" {
+		t.Errorf("unexpected payload %v", received)
+	}
+
+	exhausted := &models.WebhookDelivery{Status: "queued", Attempts: 2}
+	if result := webhooks.Attempted(exhausted, errors.New("timeout"), now); result != "failed" || exhausted.Status != "failed" {
+		t.Errorf("expected the last attempt to give up, got %s %+v", result, exhausted)
+	}
+}
+
+func TestWebhookPrivateTargets(t *testing.T) {
+	initWebhooks(t, false)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected the request to a loopback address to be refused")
+	}))
+	defer server.Close()
+
+	endpoint := &models.WebhookEndpoint{URL: server.URL, Secret: "whsec_test"}
+	d := &models.WebhookDelivery{EventType: models.WebhookTest, Payload: map[string]interface{}{}}
+	if err := webhooks.Send(context.Background(), endpoint, d); !errors.Is(err, webhooks.ErrPrivateTarget) {
+		t.Errorf("expected ErrPrivateTarget, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
 	"unicode"
@@ -122,6 +123,26 @@ func ValidatePhone(phone string, fieldName string) *ValidationError {
 	return nil
 }
 
+func ValidateURL(rawURL string, fieldName string) *ValidationError {
+	if rawURL == "" {
+		return &ValidationError{
+			Field:   fieldName,
+			Message: "is required",
+			Value:   rawURL,
+		}
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ValidationError{
+			Field:   fieldName,
+			Message: "must be a valid http or https URL",
+			Value:   rawURL,
+		}
+	}
+	return nil
+}
+
 func ValidatePassword(password string, fieldName string) *ValidationError {
 	if password == "" {
 		return &ValidationError{
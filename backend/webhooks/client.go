@@ -0,0 +1,49 @@
+package webhooks
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateTarget is returned when an endpoint resolves to an address that
+// deliveries may not be sent to
+var ErrPrivateTarget = errors.New("webhook endpoint resolves to a private address")
+
+// NewClient returns an HTTP client for sending deliveries. Redirects are not
+// followed. Unless allowPrivate is set it refuses to connect to loopback,
+// private and link-local addresses, so endpoints cannot be pointed at
+// services inside the network; the check runs on the resolved address, so a
+// public hostname resolving to one is refused too. Deliveries never go
+// through HTTP_PROXY or HTTPS_PROXY: through a proxy the check would only
+// see the proxy's address.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() {
+				return ErrPrivateTarget
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,288 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/geoo115/property-manager/logger"
+	"github.com/geoo115/property-manager/metrics"
+	"github.com/geoo115/property-manager/models"
+	"github.com/geoo115/property-manager/notifications"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// deliveryBatchSize caps how many deliveries one run sends
+	deliveryBatchSize = 100
+	// maxResponseBody caps how much of an endpoint's response is logged
+	maxResponseBody = 1024
+	userAgent       = "property-manager-webhooks/1.0"
+)
+
+// DeliveryRun summarises one pass of the delivery job
+type DeliveryRun struct {
+	Sent     []uint `json:"sent"`
+	Retried  []uint `json:"retried"`
+	Failed   []uint `json:"failed"`
+	Disabled []uint `json:"disabled"` // Endpoints disabled during the run
+}
+
+// Deliver sends the queued deliveries that are due to active endpoints.
+// Failures are retried with a doubling backoff until the configured attempts
+// are used up. An endpoint failing the configured number of attempts in a row
+// is disabled; its queued deliveries wait until it is enabled again.
+//
+// Due rows are claimed in a short transaction with SKIP LOCKED, which pushes
+// their next_attempt_at past the time the run can take, so replicas deliver
+// side by side without holding locks or a connection while the requests are
+// in flight. Each result is then recorded on its own; a delivery whose result
+// could not be recorded is sent again once the claim lapses.
+func Deliver(database *gorm.DB, now time.Time) (*DeliveryRun, error) {
+	run := &DeliveryRun{}
+
+	due, err := claim(database, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(due) == 0 {
+		return run, nil
+	}
+
+	endpointIDs := make([]uint, 0, len(due))
+	for _, d := range due {
+		endpointIDs = append(endpointIDs, d.EndpointID)
+	}
+	var endpoints []models.WebhookEndpoint
+	if err := database.Where("id IN ?", endpointIDs).Find(&endpoints).Error; err != nil {
+		return nil, fmt.Errorf("failed to load webhook endpoints: %w", err)
+	}
+	byID := make(map[uint]*models.WebhookEndpoint, len(endpoints))
+	for i := range endpoints {
+		byID[endpoints[i].ID] = &endpoints[i]
+	}
+
+	for i := range due {
+		d := &due[i]
+		endpoint, ok := byID[d.EndpointID]
+		if !ok || !endpoint.IsActive {
+			continue
+		}
+
+		result := Attempted(d, Send(database.Statement.Context, endpoint, d), now)
+		metrics.WebhookDeliveries.WithLabelValues(result).Inc()
+		if err := database.Model(d).Select("status", "attempts", "next_attempt_at", "response_status", "response_body",
+			"last_error", "duration_ms", "delivered_at").Updates(d).Error; err != nil {
+			logger.LogError(err, "Failed to record webhook delivery", logrus.Fields{"delivery_id": d.ID})
+			continue
+		}
+
+		switch result {
+		case "sent":
+			run.Sent = append(run.Sent, d.ID)
+		case "retry":
+			run.Retried = append(run.Retried, d.ID)
+		default:
+			run.Failed = append(run.Failed, d.ID)
+		}
+
+		disabled, err := recordOutcome(database, endpoint, result == "sent", now)
+		if err != nil {
+			logger.LogError(err, "Failed to record webhook endpoint outcome", logrus.Fields{"endpoint_id": endpoint.ID})
+			continue
+		}
+		if disabled {
+			run.Disabled = append(run.Disabled, endpoint.ID)
+		}
+	}
+
+	if len(run.Sent) > 0 || len(run.Retried) > 0 || len(run.Failed) > 0 {
+		logger.LogInfo("Webhook delivery run completed", logrus.Fields{
+			"sent":     len(run.Sent),
+			"retried":  len(run.Retried),
+			"failed":   len(run.Failed),
+			"disabled": len(run.Disabled),
+		})
+	}
+	return run, nil
+}
+
+// claim locks a batch of due deliveries to active endpoints and moves their
+// next attempt past the longest the run can take to send them, so no other
+// run picks them up meanwhile. The returned rows keep their original
+// next_attempt_at.
+func claim(database *gorm.DB, now time.Time) ([]models.WebhookDelivery, error) {
+	var due []models.WebhookDelivery
+	err := database.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", "queued", now).
+			Where("endpoint_id IN (SELECT id FROM webhook_endpoints WHERE is_active = ?)", true).
+			Order("next_attempt_at, id").Limit(deliveryBatchSize).
+			Find(&due).Error; err != nil {
+			return fmt.Errorf("failed to load queued webhook deliveries: %w", err)
+		}
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(due))
+		for i, d := range due {
+			ids[i] = d.ID
+		}
+		claimedUntil := now.Add(time.Duration(len(due)+1) * settings.Timeout)
+		if err := tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", claimedUntil).Error; err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+// recordOutcome keeps the endpoint's run of failed attempts and disables it
+// once the run reaches the limit. It reports whether the endpoint was disabled.
+func recordOutcome(database *gorm.DB, endpoint *models.WebhookEndpoint, succeeded bool, now time.Time) (bool, error) {
+	if succeeded {
+		if endpoint.ConsecutiveFailures == 0 {
+			return false, nil
+		}
+		endpoint.ConsecutiveFailures = 0
+		if err := database.Model(endpoint).UpdateColumn("consecutive_failures", 0).Error; err != nil {
+			return false, fmt.Errorf("failed to reset webhook endpoint %d: %w", endpoint.ID, err)
+		}
+		return false, nil
+	}
+
+	endpoint.ConsecutiveFailures++
+	if err := database.Model(endpoint).UpdateColumn("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error; err != nil {
+		return false, fmt.Errorf("failed to count webhook endpoint %d failure: %w", endpoint.ID, err)
+	}
+	if endpoint.ConsecutiveFailures < settings.DisableAfter {
+		return false, nil
+	}
+
+	endpoint.IsActive = false
+	endpoint.DisabledAt = &now
+	endpoint.DisabledReason = fmt.Sprintf("Disabled after %d failed deliveries in a row", endpoint.ConsecutiveFailures)
+	if err := database.Model(endpoint).Select("is_active", "disabled_at", "disabled_reason").Updates(endpoint).Error; err != nil {
+		return false, fmt.Errorf("failed to disable webhook endpoint %d: %w", endpoint.ID, err)
+	}
+	logger.LogWarning("Webhook endpoint disabled after repeated failures", logrus.Fields{
+		"endpoint_id": endpoint.ID,
+		"owner_id":    endpoint.OwnerID,
+		"failures":    endpoint.ConsecutiveFailures,
+	})
+	return true, nil
+}
+
+// Send posts a delivery's payload to the endpoint, signed with its secret as
+// it goes out rather than when the run started, and records the response on
+// the delivery. Any response other than 2xx is an error.
+func Send(ctx context.Context, endpoint *models.WebhookEndpoint, d *models.WebhookDelivery) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	body, err := json.Marshal(d.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Webhook-ID", d.EventID)
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, time.Now(), body))
+
+	started := time.Now()
+	resp, err := client.Do(req)
+	d.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		d.ResponseStatus = 0
+		d.ResponseBody = ""
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	d.ResponseStatus = resp.StatusCode
+	d.ResponseBody = string(respBody)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded with %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Attempted records the outcome of one delivery attempt on d and returns
+// "sent", "retry" or "failed"
+func Attempted(d *models.WebhookDelivery, err error, now time.Time) string {
+	d.Attempts++
+	if err == nil {
+		d.Status = "sent"
+		d.DeliveredAt = &now
+		d.LastError = ""
+		return "sent"
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= settings.MaxAttempts {
+		d.Status = "failed"
+		return "failed"
+	}
+	d.NextAttemptAt = now.Add(notifications.Backoff(settings.RetryBackoff, d.Attempts))
+	return "retry"
+}
+
+// SendTest sends a webhook.test event to the endpoint straight away, whether
+// or not it is active, and logs it with its deliveries. Test events are not
+// retried and do not count towards disabling the endpoint.
+func SendTest(ctx context.Context, database *gorm.DB, endpoint *models.WebhookEndpoint, now time.Time) (*models.WebhookDelivery, error) {
+	eventID, payload, err := NewEvent(models.WebhookTest, map[string]interface{}{
+		"endpoint_id": endpoint.ID,
+		"message":     "This is a test event from Property Manager",
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &models.WebhookDelivery{
+		EndpointID:    endpoint.ID,
+		EventID:       eventID,
+		EventType:     models.WebhookTest,
+		Payload:       payload,
+		Status:        "queued",
+		NextAttemptAt: now,
+	}
+	if err := database.Create(d).Error; err != nil {
+		return nil, fmt.Errorf("failed to log test delivery: %w", err)
+	}
+
+	sendErr := Send(ctx, endpoint, d)
+	d.Attempts = 1
+	if sendErr == nil {
+		d.Status = "sent"
+		d.DeliveredAt = &now
+	} else {
+		d.Status = "failed"
+		d.LastError = sendErr.Error()
+	}
+	if err := database.Model(d).Select("status", "attempts", "response_status", "response_body",
+		"last_error", "duration_ms", "delivered_at").Updates(d).Error; err != nil {
+		return nil, fmt.Errorf("failed to record test delivery: %w", err)
+	}
+	return d, nil
+}
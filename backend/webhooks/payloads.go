@@ -0,0 +1,131 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/geoo115/property-manager/models"
+)
+
+const dateLayout = "2006-01-02"
+
+// Lease is the data of lease events
+type Lease struct {
+	ID                    uint    `json:"id"`
+	TenantID              uint    `json:"tenant_id"`
+	PropertyID            uint    `json:"property_id"`
+	UnitID                *uint   `json:"unit_id"`
+	PreviousLeaseID       *uint   `json:"previous_lease_id"`
+	Status                string  `json:"status"`
+	StartDate             string  `json:"start_date"`
+	EndDate               string  `json:"end_date"`
+	MonthlyRent           float64 `json:"monthly_rent"`
+	SecurityDeposit       float64 `json:"security_deposit"`
+	TerminationNoticeDate *string `json:"termination_notice_date"`
+	TerminationReason     string  `json:"termination_reason"`
+}
+
+// LeaseData converts a lease into event data
+func LeaseData(l models.Lease) Lease {
+	return Lease{
+		ID:                    l.ID,
+		TenantID:              l.TenantID,
+		PropertyID:            l.PropertyID,
+		UnitID:                l.UnitID,
+		PreviousLeaseID:       l.PreviousLeaseID,
+		Status:                l.Status,
+		StartDate:             l.StartDate.Format(dateLayout),
+		EndDate:               l.EndDate.Format(dateLayout),
+		MonthlyRent:           l.MonthlyRent,
+		SecurityDeposit:       l.SecurityDeposit,
+		TerminationNoticeDate: formatDate(l.TerminationNoticeDate),
+		TerminationReason:     l.TerminationReason,
+	}
+}
+
+// Invoice is the data of invoice events
+type Invoice struct {
+	ID             uint    `json:"id"`
+	InvoiceNumber  string  `json:"invoice_number"`
+	TenantID       uint    `json:"tenant_id"`
+	PropertyID     uint    `json:"property_id"`
+	LeaseID        *uint   `json:"lease_id"`
+	UnitID         *uint   `json:"unit_id"`
+	Category       string  `json:"category"`
+	Amount         float64 `json:"amount"`
+	PaidAmount     float64 `json:"paid_amount"`
+	RefundedAmount float64 `json:"refunded_amount"`
+	Balance        float64 `json:"balance"`
+	InvoiceDate    string  `json:"invoice_date"`
+	DueDate        string  `json:"due_date"`
+	PaymentStatus  string  `json:"payment_status"`
+}
+
+// InvoiceData converts an invoice into event data
+func InvoiceData(i models.Invoice) Invoice {
+	return Invoice{
+		ID:             i.ID,
+		InvoiceNumber:  i.InvoiceNumber,
+		TenantID:       i.TenantID,
+		PropertyID:     i.PropertyID,
+		LeaseID:        i.LeaseID,
+		UnitID:         i.UnitID,
+		Category:       i.Category,
+		Amount:         i.Amount,
+		PaidAmount:     i.PaidAmount,
+		RefundedAmount: i.RefundedAmount,
+		Balance:        i.BalanceRemaining(),
+		InvoiceDate:    i.InvoiceDate.Format(dateLayout),
+		DueDate:        i.DueDate.Format(dateLayout),
+		PaymentStatus:  i.PaymentStatus,
+	}
+}
+
+// Maintenance is the data of maintenance events
+type Maintenance struct {
+	ID            uint       `json:"id"`
+	PropertyID    uint       `json:"property_id"`
+	UnitID        *uint      `json:"unit_id"`
+	LeaseID       *uint      `json:"lease_id"`
+	RequestedByID uint       `json:"requested_by_id"`
+	AssignedToID  *uint      `json:"assigned_to_id"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Status        string     `json:"status"`
+	Priority      string     `json:"priority"`
+	Category      string     `json:"category"`
+	EstimatedCost float64    `json:"estimated_cost"`
+	ActualCost    float64    `json:"actual_cost"`
+	RequestedAt   time.Time  `json:"requested_at"`
+	ScheduledAt   *time.Time `json:"scheduled_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
+
+// MaintenanceData converts a maintenance request into event data
+func MaintenanceData(m models.Maintenance) Maintenance {
+	return Maintenance{
+		ID:            m.ID,
+		PropertyID:    m.PropertyID,
+		UnitID:        m.UnitID,
+		LeaseID:       m.LeaseID,
+		RequestedByID: m.RequestedByID,
+		AssignedToID:  m.AssignedToID,
+		Title:         m.Title,
+		Description:   m.Description,
+		Status:        m.Status,
+		Priority:      m.Priority,
+		Category:      m.Category,
+		EstimatedCost: m.EstimatedCost,
+		ActualCost:    m.ActualCost,
+		RequestedAt:   m.RequestedAt,
+		ScheduledAt:   m.ScheduledAt,
+		CompletedAt:   m.CompletedAt,
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(dateLayout)
+	return &s
+}
@@ -0,0 +1,122 @@
+// Package webhooks delivers events to the endpoints admins and landlords have
+// registered. Events are queued in the transaction that caused them, one
+// delivery per subscribed endpoint, and sent by a background job that signs
+// each payload with the endpoint's secret and retries failures with a
+// backoff. Endpoints that keep failing are disabled.
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/geoo115/property-manager/config"
+	"github.com/geoo115/property-manager/models"
+	"gorm.io/gorm"
+)
+
+// settings holds the configured delivery behaviour; Init replaces it
+var settings = config.WebhookConfig{
+	MaxAttempts:  8,
+	RetryBackoff: time.Minute,
+	Timeout:      10 * time.Second,
+	DisableAfter: 15,
+}
+
+// client sends deliveries; Init replaces it
+var client = NewClient(settings.Timeout, settings.AllowPrivateTargets)
+
+// Init applies the webhook settings
+func Init(cfg *config.Config) {
+	settings = cfg.Webhooks
+	client = NewClient(settings.Timeout, settings.AllowPrivateTargets)
+}
+
+// Publish queues an event about a property's records for every active
+// endpoint subscribed to its type: all admins' endpoints and the property
+// owner's. Call it inside the transaction making the change so nothing is
+// sent unless it commits.
+func Publish(tx *gorm.DB, eventType string, propertyID uint, data interface{}) error {
+	var endpoints []models.WebhookEndpoint
+	if err := tx.Where("is_active = ?", true).
+		Where("(owner_id IN (SELECT id FROM users WHERE role = ? AND deleted_at IS NULL) OR owner_id = (SELECT owner_id FROM properties WHERE id = ?))", "admin", propertyID).
+		Find(&endpoints).Error; err != nil {
+		return fmt.Errorf("failed to load webhook endpoints: %w", err)
+	}
+
+	var subscribed []models.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.Subscribed(eventType) {
+			subscribed = append(subscribed, endpoint)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	eventID, payload, err := NewEvent(eventType, data, now)
+	if err != nil {
+		return err
+	}
+	deliveries := make([]models.WebhookDelivery, 0, len(subscribed))
+	for _, endpoint := range subscribed {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       eventID,
+			EventType:     eventType,
+			Payload:       payload,
+			Status:        "queued",
+			NextAttemptAt: now,
+		})
+	}
+	if err := tx.Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// NewEvent builds the payload endpoints receive for an event:
+// {"id", "type", "created_at", "data"}. It returns the event's ID with it.
+func NewEvent(eventType string, data interface{}, now time.Time) (string, map[string]interface{}, error) {
+	id, err := randomHex(12)
+	if err != nil {
+		return "", nil, err
+	}
+	eventID := "evt_" + id
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return "", nil, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	return eventID, map[string]interface{}{
+		"id":         eventID,
+		"type":       eventType,
+		"created_at": now.UTC().Format(time.RFC3339),
+		"data":       decoded,
+	}, nil
+}
+
+// NewSecret generates a signing secret for an endpoint
+func NewSecret() (string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+	return "whsec_" + secret, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}